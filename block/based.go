@@ -0,0 +1,87 @@
+package block
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+// BasedLoop derives blocks from transactions posted directly to DA layer (based sequencing).
+//
+// DA heights are processed one by one, in order. All transactions posted at DA height are included in a single
+// block, in DA order. DA heights without transactions don't produce blocks. Blocks are derived deterministically,
+// so all nodes starting from the same DA height derive the same chain.
+func (m *Manager) BasedLoop(ctx context.Context) {
+	if !da.Implements[da.TxRetriever](m.dalc) {
+		m.logger.Error("DA layer client doesn't support based sequencing")
+		return
+	}
+	retriever := m.dalc.(da.TxRetriever)
+
+	daTicker := time.NewTicker(m.conf.DABlockTime)
+	defer daTicker.Stop()
+	heightCh := m.subscribeHeights(ctx)
+	for {
+		m.deriveBlocks(ctx, retriever)
+		select {
+		case <-ctx.Done():
+			return
+		case <-daTicker.C:
+			if heightCh == nil {
+				heightCh = m.subscribeHeights(ctx)
+			}
+		case daHeight, ok := <-heightCh:
+			if !ok {
+				m.logger.Info("DA height subscription closed, polling DA layer")
+				heightCh = nil
+				continue
+			}
+			m.logger.Debug("new DA height", "daHeight", daHeight)
+		}
+	}
+}
+
+// deriveBlocks derives blocks from consecutive DA heights, until DA height that is not available yet is reached.
+func (m *Manager) deriveBlocks(ctx context.Context, retriever da.TxRetriever) {
+	for ctx.Err() == nil {
+		daHeight := atomic.LoadUint64(&m.daHeight)
+		res := retriever.RetrieveTxs(ctx, daHeight)
+		switch res.Code {
+		case da.StatusSuccess, da.StatusNamespaceEmpty:
+		case da.StatusHeightFromFuture:
+			return
+		default:
+			m.logger.Error("failed to retrieve transactions from DA layer", "daHeight", daHeight, "error", res.Message)
+			return
+		}
+		if len(res.Txs) > 0 {
+			if err := m.deriveBlock(ctx, daHeight, res.Txs); err != nil {
+				m.logger.Error("failed to derive block", "daHeight", daHeight, "error", err)
+				return
+			}
+		}
+		atomic.StoreUint64(&m.daHeight, daHeight+1)
+	}
+}
+
+// deriveBlock creates block containing given transactions and applies it on top of current state.
+func (m *Manager) deriveBlock(ctx context.Context, daHeight uint64, txs types.Txs) error {
+	height := m.store.Height()
+	newHeight := height + 1
+	var lastHeaderHash types.Hash
+	if newHeight != uint64(m.genesis.InitialHeight) {
+		lastBlock, err := m.store.LoadBlock(height)
+		if err != nil {
+			return fmt.Errorf("error while loading last block: %w", err)
+		}
+		lastHeaderHash = lastBlock.SignedHeader.Header.Hash()
+	}
+
+	block := m.executor.CreateBasedBlock(newHeight, txs, lastHeaderHash, m.getLastState())
+	m.logger.Info("derived block from DA layer", "height", newHeight, "daHeight", daHeight, "num_tx", len(txs))
+	return m.syncBlock(ctx, block, daHeight)
+}
@@ -0,0 +1,62 @@
+package block
+
+import (
+	"time"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// blockBatch accumulates blocks that are going to be submitted to DA layer in a single submission.
+type blockBatch struct {
+	maxBlocks uint64
+	maxBytes  uint64
+	timeout   time.Duration
+
+	blocks  []*types.Block
+	size    uint64
+	started time.Time
+}
+
+func newBlockBatch(maxBlocks, maxBytes uint64, timeout time.Duration) *blockBatch {
+	return &blockBatch{
+		maxBlocks: maxBlocks,
+		maxBytes:  maxBytes,
+		timeout:   timeout,
+	}
+}
+
+// fits returns true if block of given size can be added to the batch without exceeding the size limit.
+// Empty batch accepts blocks of any size, so a single large block never gets stuck.
+func (b *blockBatch) fits(size uint64) bool {
+	return len(b.blocks) == 0 || b.maxBytes == 0 || b.size+size <= b.maxBytes
+}
+
+func (b *blockBatch) add(block *types.Block, size uint64) {
+	if len(b.blocks) == 0 {
+		b.started = time.Now()
+	}
+	b.blocks = append(b.blocks, block)
+	b.size += size
+}
+
+// full returns true if batch reached any of the configured limits.
+func (b *blockBatch) full() bool {
+	if uint64(len(b.blocks)) >= b.maxBlocks {
+		return true
+	}
+	return b.maxBytes > 0 && b.size >= b.maxBytes
+}
+
+// expired returns true if the oldest block in batch waits longer than configured timeout.
+func (b *blockBatch) expired() bool {
+	return len(b.blocks) > 0 && b.timeout > 0 && time.Since(b.started) >= b.timeout
+}
+
+func (b *blockBatch) empty() bool {
+	return len(b.blocks) == 0
+}
+
+func (b *blockBatch) reset() {
+	b.blocks = nil
+	b.size = 0
+}
@@ -0,0 +1,41 @@
+package block
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+func TestBlockBatch(t *testing.T) {
+	assert := assert.New(t)
+
+	b := newBlockBatch(3, 100, 50*time.Millisecond)
+	assert.True(b.empty())
+	assert.False(b.full())
+	assert.False(b.expired())
+	assert.True(b.fits(1000), "empty batch should accept block of any size")
+
+	b.add(&types.Block{}, 40)
+	assert.False(b.full())
+	assert.True(b.fits(60))
+	assert.False(b.fits(61))
+
+	b.add(&types.Block{}, 40)
+	assert.False(b.full())
+	b.add(&types.Block{}, 10)
+	assert.True(b.full(), "batch should be full because of number of blocks")
+
+	b.reset()
+	assert.True(b.empty())
+	b.add(&types.Block{}, 100)
+	assert.True(b.full(), "batch should be full because of size")
+
+	b.reset()
+	b.add(&types.Block{}, 1)
+	assert.False(b.expired())
+	time.Sleep(60 * time.Millisecond)
+	assert.True(b.expired())
+}
@@ -0,0 +1,256 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// errForcedTxsMissing is returned when block included in DA layer after the deadline doesn't contain transactions
+// from forced inclusion queue.
+var errForcedTxsMissing = errors.New("block doesn't include transactions from forced inclusion queue")
+
+// forcedTx is a transaction posted directly to DA layer, that has to be included by the aggregator.
+type forcedTx struct {
+	tx types.Tx
+	// daHeight is the DA height at which transaction was posted
+	daHeight uint64
+}
+
+// forcedInclusionQueue holds transactions posted directly to DA layer that are not included in any block yet.
+//
+// Queue is filled by RetrieveLoop before blocks from the same DA height are passed to SyncLoop. Transaction posted at
+// DA height h has to be included in a block included in DA layer at height h+ForcedInclusionDeadline or lower, with
+// ForcedInclusionSlack added, as the block could be produced before the aggregator retrieved DA height h.
+//
+// Queue and transactions included in blocks are persisted in store, so every node (aggregator or not) restores the
+// same queue after restart. Transactions that were already included are not queued again.
+type forcedInclusionQueue struct {
+	mtx   sync.Mutex
+	store store.Store
+	txs   []forcedTx
+	// available is signalled when transactions are added to the queue
+	available chan struct{}
+}
+
+// newForcedInclusionQueue creates queue with transactions restored from store.
+func newForcedInclusionQueue(s store.Store) (*forcedInclusionQueue, error) {
+	q := &forcedInclusionQueue{store: s, available: make(chan struct{}, 1)}
+	saved, err := s.LoadForcedTxs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load forced inclusion queue: %w", err)
+	}
+	daHeights := make([]uint64, 0, len(saved))
+	for daHeight := range saved {
+		daHeights = append(daHeights, daHeight)
+	}
+	sort.Slice(daHeights, func(i, j int) bool { return daHeights[i] < daHeights[j] })
+	for _, daHeight := range daHeights {
+		for _, tx := range saved[daHeight] {
+			q.txs = append(q.txs, forcedTx{tx: tx, daHeight: daHeight})
+		}
+	}
+	return q, nil
+}
+
+// add appends transactions posted at given DA height to the queue. Transactions already in the queue, or already
+// included in a block, are skipped.
+func (q *forcedInclusionQueue) add(txs types.Txs, daHeight uint64) error {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	queued := make(map[string]struct{}, len(q.txs))
+	for _, ftx := range q.txs {
+		queued[string(ftx.tx)] = struct{}{}
+	}
+	var added []forcedTx
+	for _, tx := range txs {
+		if _, ok := queued[string(tx)]; ok {
+			continue
+		}
+		_, err := q.store.LoadTxInclusion(tx.Hash())
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, ds.ErrNotFound) {
+			return err
+		}
+		queued[string(tx)] = struct{}{}
+		added = append(added, forcedTx{tx: tx, daHeight: daHeight})
+	}
+	if len(added) == 0 {
+		return nil
+	}
+	if err := q.save(append(q.txs, added...), daHeight); err != nil {
+		return err
+	}
+	q.txs = append(q.txs, added...)
+	select {
+	case q.available <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// save persists transactions from given DA heights. It has to be called with mtx held.
+func (q *forcedInclusionQueue) save(txs []forcedTx, daHeights ...uint64) error {
+	for _, daHeight := range daHeights {
+		var saved types.Txs
+		for _, ftx := range txs {
+			if ftx.daHeight == daHeight {
+				saved = append(saved, ftx.tx)
+			}
+		}
+		if err := q.store.SaveForcedTxs(daHeight, saved); err != nil {
+			return fmt.Errorf("failed to save forced inclusion queue: %w", err)
+		}
+	}
+	return nil
+}
+
+// pending returns queued transactions in DA order, up to maxBytes in total (if maxBytes is positive).
+func (q *forcedInclusionQueue) pending(maxBytes int64) types.Txs {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	var txs types.Txs
+	var size int64
+	for _, ftx := range q.txs {
+		size += int64(len(ftx.tx))
+		if maxBytes > 0 && size > maxBytes {
+			break
+		}
+		txs = append(txs, ftx.tx)
+	}
+	return txs
+}
+
+// missing returns number of queued transactions with deadline before given DA height, that are not included in txs.
+func (q *forcedInclusionQueue) missing(txs types.Txs, daHeight uint64, deadline uint64) int {
+	included := make(map[string]struct{}, len(txs))
+	for _, tx := range txs {
+		included[string(tx)] = struct{}{}
+	}
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	n := 0
+	for _, ftx := range q.txs {
+		if ftx.daHeight+deadline >= daHeight {
+			continue
+		}
+		if _, ok := included[string(ftx.tx)]; !ok {
+			n++
+		}
+	}
+	return n
+}
+
+// remove records transactions as included in block at given height, and removes them from the queue.
+func (q *forcedInclusionQueue) remove(txs types.Txs, height uint64) error {
+	if len(txs) == 0 {
+		return nil
+	}
+	included := make(map[string]struct{}, len(txs))
+	for _, tx := range txs {
+		included[string(tx)] = struct{}{}
+	}
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	// inclusion is saved first, so transactions are not queued again if node crashes before queue is saved
+	if err := q.store.SaveTxsInclusion(height, txs); err != nil {
+		return fmt.Errorf("failed to save inclusion of transactions: %w", err)
+	}
+	kept := make([]forcedTx, 0, len(q.txs))
+	var changed []uint64
+	for _, ftx := range q.txs {
+		if _, ok := included[string(ftx.tx)]; ok {
+			if len(changed) == 0 || changed[len(changed)-1] != ftx.daHeight {
+				changed = append(changed, ftx.daHeight)
+			}
+			continue
+		}
+		kept = append(kept, ftx)
+	}
+	if err := q.save(kept, changed...); err != nil {
+		return err
+	}
+	q.txs = kept
+	return nil
+}
+
+// forcedTxsAvailable returns channel signalled when transactions are added to forced inclusion queue, or nil channel
+// if forced inclusion is disabled.
+func (m *Manager) forcedTxsAvailable() <-chan struct{} {
+	if m.forced == nil {
+		return nil
+	}
+	return m.forced.available
+}
+
+// queueForcedTxs adds transactions posted directly to DA layer at given DA height to forced inclusion queue.
+func (m *Manager) queueForcedTxs(ctx context.Context, daHeight uint64) error {
+	if m.forced == nil {
+		return nil
+	}
+	res := m.dalc.(da.TxRetriever).RetrieveTxs(ctx, daHeight)
+	switch res.Code {
+	case da.StatusSuccess:
+		m.logger.Debug("queued transactions for forced inclusion", "daHeight", daHeight, "n", len(res.Txs))
+		return m.forced.add(m.includableTxs(res.Txs), daHeight)
+	case da.StatusNamespaceEmpty:
+	default:
+		return fmt.Errorf("failed to retrieve transactions for forced inclusion: %s", res.Message)
+	}
+	return nil
+}
+
+// includableTxs filters out transactions that are too big to be included in any block.
+func (m *Manager) includableTxs(txs types.Txs) types.Txs {
+	maxBytes := m.getLastState().ConsensusParams.Block.MaxBytes
+	if maxBytes <= 0 {
+		return txs
+	}
+	var includable types.Txs
+	for _, tx := range txs {
+		if int64(len(tx)) <= maxBytes {
+			includable = append(includable, tx)
+		}
+	}
+	return includable
+}
+
+// checkForcedInclusion returns an error if block included in DA layer at given DA height misses transactions from
+// forced inclusion queue, that are past the deadline.
+func (m *Manager) checkForcedInclusion(block *types.Block, daHeight uint64) error {
+	if m.forced == nil {
+		return nil
+	}
+	deadline := m.conf.ForcedInclusionDeadline + m.conf.ForcedInclusionSlack
+	if n := m.forced.missing(block.Data.Txs, daHeight, deadline); n > 0 {
+		return fmt.Errorf("%w: %d transactions missing", errForcedTxsMissing, n)
+	}
+	return nil
+}
+
+// forcedTxsIncluded removes transactions included in block from forced inclusion queue.
+func (m *Manager) forcedTxsIncluded(block *types.Block) error {
+	if m.forced == nil {
+		return nil
+	}
+	return m.forced.remove(block.Data.Txs, uint64(block.SignedHeader.Header.Height()))
+}
+
+// pendingForcedTxs returns transactions from forced inclusion queue, that fit into block.
+func (m *Manager) pendingForcedTxs() types.Txs {
+	if m.forced == nil {
+		return nil
+	}
+	maxBytes := m.getLastState().ConsensusParams.Block.MaxBytes
+	return m.forced.pending(maxBytes)
+}
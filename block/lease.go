@@ -0,0 +1,72 @@
+package block
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/rollkit/rollkit/lease"
+)
+
+// defaultLeaseDuration is used only if LeaseDuration is not configured for manager
+const defaultLeaseDuration = 10 * time.Second
+
+// SetLease enables hot-standby mode. Blocks are produced only while holding leader lease from given backend;
+// holder identifies this aggregator among aggregators sharing the same signing key.
+func (m *Manager) SetLease(backend lease.Backend, holder string) {
+	m.lease = backend
+	m.leaseHolder = holder
+	if m.conf.LeaseDuration == 0 {
+		m.conf.LeaseDuration = defaultLeaseDuration
+	}
+}
+
+// acquireLease acquires or renews leader lease. It returns false if block can't be produced, because lease is held
+// by another aggregator. Height of the last block signed by any lease holder is returned.
+func (m *Manager) acquireLease(ctx context.Context) (uint64, bool) {
+	if m.lease == nil {
+		return 0, true
+	}
+	l, err := m.lease.Acquire(ctx, m.leaseHolder, m.conf.LeaseDuration)
+	if err != nil {
+		if m.leader {
+			m.logger.Info("lost leader lease", "error", err)
+		} else if !errors.Is(err, lease.ErrHeld) {
+			m.logger.Error("failed to acquire leader lease", "error", err)
+		}
+		m.leader = false
+		return 0, false
+	}
+	if !m.leader {
+		m.logger.Info("acquired leader lease", "holder", m.leaseHolder, "expiry", l.Expiry, "signedHeight", l.Height)
+		m.leader = true
+	}
+	return l.Height, true
+}
+
+// signLease records in leader lease that block at given height is about to be signed. Standby aggregator, that
+// takes over the lease, produces blocks only after syncing all the blocks up to this height.
+func (m *Manager) signLease(ctx context.Context, height uint64) error {
+	if m.lease == nil {
+		return nil
+	}
+	if err := m.lease.Sign(ctx, m.leaseHolder, height); err != nil {
+		m.leader = false
+		return fmt.Errorf("can't record block at height %d in leader lease: %w", height, err)
+	}
+	return nil
+}
+
+// releaseLease releases leader lease, so standby aggregator can take over block production immediately.
+func (m *Manager) releaseLease() {
+	if m.lease == nil || !m.leader {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := m.lease.Release(ctx, m.leaseHolder); err != nil {
+		m.logger.Error("failed to release leader lease", "error", err)
+	}
+	m.leader = false
+}
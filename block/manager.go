@@ -3,7 +3,9 @@ package block
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"sync"
 	"sync/atomic"
@@ -13,14 +15,17 @@ import (
 	abci "github.com/tendermint/tendermint/abci/types"
 	tmcrypto "github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/merkle"
+	tmstate "github.com/tendermint/tendermint/proto/tendermint/state"
 	"github.com/tendermint/tendermint/proxy"
 	tmtypes "github.com/tendermint/tendermint/types"
 	"go.uber.org/multierr"
 
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/lease"
 	"github.com/rollkit/rollkit/log"
 	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/signer"
 	"github.com/rollkit/rollkit/state"
 	"github.com/rollkit/rollkit/store"
 	"github.com/rollkit/rollkit/types"
@@ -29,18 +34,31 @@ import (
 // defaultDABlockTime is used only if DABlockTime is not configured for manager
 const defaultDABlockTime = 30 * time.Second
 
-// maxSubmitAttempts defines how many times Rollkit will re-try to publish block to DA layer.
-// This is temporary solution. It will be removed in future versions.
-const maxSubmitAttempts = 30
-
 // initialBackoff defines initial value for block submission backoff
 var initialBackoff = 100 * time.Millisecond
 
+var (
+	// errHeightFromFuture is returned when requested DA height is not yet available in DA layer.
+	errHeightFromFuture = errors.New("DA height from the future")
+	// errBlobTooBig is returned when DA layer rejects submission because of its size.
+	errBlobTooBig = errors.New("blob too big for DA layer")
+	// errDAReorg is returned when previously retrieved DA height returned different blocks.
+	errDAReorg = errors.New("DA reorg detected")
+	// errDAInconsistency is returned when DA reorg removed blocks that were already applied.
+	errDAInconsistency = errors.New("DA reorg reverted applied blocks")
+)
+
 type newBlockEvent struct {
 	block    *types.Block
 	daHeight uint64
 }
 
+// unconfirmedDAHeight identifies blocks retrieved from DA height that is not confirmed by DAConfirmationDepth yet.
+type unconfirmedDAHeight struct {
+	daHeight uint64
+	hash     []byte
+}
+
 // Manager is responsible for aggregating transactions into blocks.
 type Manager struct {
 	lastState types.State
@@ -49,6 +67,8 @@ type Manager struct {
 	genesis *tmtypes.GenesisDoc
 
 	proposerKey crypto.PrivKey
+	// signer signs block headers with proposerKey, protecting against double signing
+	signer *signer.Signer
 
 	store    store.Store
 	executor *state.BlockExecutor
@@ -57,13 +77,22 @@ type Manager struct {
 	retriever da.BlockRetriever
 	// daHeight is the height of the latest processed DA block
 	daHeight uint64
+	// unconfirmed holds DA heights that were retrieved, but blocks from them are not passed to SyncLoop yet;
+	// used only by RetrieveLoop
+	unconfirmed []unconfirmedDAHeight
 
 	HeaderCh chan *types.SignedHeader
 
 	FraudProofInCh chan *abci.FraudProof
 
 	blockInCh chan newBlockEvent
-	syncCache map[uint64]*types.Block
+	// syncCache holds verified blocks retrieved from DA layer, that are not applied yet; there can be multiple
+	// conflicting candidates for a single height
+	syncCache map[uint64][]syncCandidate
+	// headerStore provides headers synced from P2P network, used to verify retrieved blocks (optional)
+	headerStore HeaderStore
+	// forced holds transactions that have to be included by the aggregator (nil if forced inclusion is disabled)
+	forced *forcedInclusionQueue
 
 	// retrieveMtx is used by retrieveCond
 	retrieveMtx *sync.Mutex
@@ -72,7 +101,25 @@ type Manager struct {
 
 	lastStateMtx *sync.Mutex
 
-	logger log.Logger
+	// statusMtx guards updates of block finality statuses
+	statusMtx *sync.Mutex
+	eventBus  *tmtypes.EventBus
+
+	logger  log.Logger
+	metrics *Metrics
+
+	// daSubmittedHeight is the height of the last block known to be included in DA layer
+	daSubmittedHeight uint64
+	// submitCh is used to notify submission goroutine (SubmissionLoop) about new pending blocks
+	submitCh chan struct{}
+	// batch collects pending blocks before submission to DA layer; used only by SubmissionLoop
+	batch *blockBatch
+
+	// lease coordinates hot-standby aggregators sharing the same signing key (optional)
+	lease       lease.Backend
+	leaseHolder string
+	// leader is true while this aggregator holds leader lease; used only by AggregationLoop
+	leader bool
 
 	// For usage by Lazy Aggregator mode
 	buildingBlock     bool
@@ -120,7 +167,32 @@ func NewManager(
 		conf.DABlockTime = defaultDABlockTime
 	}
 
-	exec := state.NewBlockExecutor(proposerAddress, conf.NamespaceID, genesis.ChainID, mempool, proxyApp, conf.FraudProofs, eventBus, logger)
+	if conf.Based {
+		if len(genesis.Validators) > 0 {
+			return nil, errors.New("based sequencing can't be used with genesis validators")
+		}
+		if !da.Implements[da.TxRetriever](dalc) {
+			return nil, errors.New("DA layer client doesn't support based sequencing")
+		}
+		// blocks are not proposed by any node
+		proposerAddress = nil
+	}
+	if conf.ForcedInclusionDeadline > 0 {
+		if conf.Based {
+			return nil, errors.New("forced inclusion can't be used with based sequencing")
+		}
+		if !da.Implements[da.TxRetriever](dalc) {
+			return nil, errors.New("DA layer client doesn't support forced inclusion")
+		}
+	}
+
+	sgn, err := signer.New(proposerKey, conf.SignStateFile)
+	if err != nil {
+		return nil, err
+	}
+
+	legacyDataHashHeight := migrateDataHash(store, s, conf.LegacyDataHashHeight, logger)
+	exec := state.NewBlockExecutor(proposerAddress, conf.NamespaceID, genesis.ChainID, mempool, proxyApp, conf.FraudProofs, legacyDataHashHeight, eventBus, logger)
 	if s.LastBlockHeight+1 == genesis.InitialHeight {
 		res, err := exec.InitChain(genesis)
 		if err != nil {
@@ -142,6 +214,7 @@ func NewManager(
 
 	agg := &Manager{
 		proposerKey: proposerKey,
+		signer:      sgn,
 		conf:        conf,
 		genesis:     genesis,
 		lastState:   s,
@@ -150,23 +223,92 @@ func NewManager(
 		dalc:        dalc,
 		retriever:   dalc.(da.BlockRetriever), // TODO(tzdybal): do it in more gentle way (after MVP)
 		daHeight:    s.DAHeight,
+
+		submitCh: make(chan struct{}, 1),
 		// channels are buffered to avoid blocking on input/output operations, buffer sizes are arbitrary
 		HeaderCh:          make(chan *types.SignedHeader, 100),
 		blockInCh:         make(chan newBlockEvent, 100),
 		FraudProofInCh:    make(chan *abci.FraudProof, 100),
 		retrieveMtx:       new(sync.Mutex),
 		lastStateMtx:      new(sync.Mutex),
-		syncCache:         make(map[uint64]*types.Block),
+		statusMtx:         new(sync.Mutex),
+		eventBus:          eventBus,
+		syncCache:         make(map[uint64][]syncCandidate),
 		logger:            logger,
+		metrics:           NopMetrics(),
 		txsAvailable:      txsAvailableCh,
 		doneBuildingBlock: doneBuildingCh,
 		buildingBlock:     false,
 	}
 	agg.retrieveCond = sync.NewCond(agg.retrieveMtx)
 
+	agg.daSubmittedHeight, err = store.LoadDASubmittedHeight()
+	if err != nil {
+		proposer, err := agg.isProposer(s)
+		if err != nil {
+			return nil, err
+		}
+		if proposer {
+			// blocks produced before submission queue was introduced were submitted synchronously
+			agg.daSubmittedHeight = uint64(s.LastBlockHeight)
+			if err := store.SetDASubmittedHeight(agg.daSubmittedHeight); err != nil {
+				return nil, err
+			}
+		} else {
+			// other nodes know only about blocks retrieved from DA layer
+			agg.daSubmittedHeight = lastDAIncludedHeight(store, uint64(s.LastBlockHeight))
+		}
+	}
+	if conf.ForcedInclusionDeadline > 0 {
+		agg.forced, err = newForcedInclusionQueue(store)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if conf.DABatchSize > 1 {
+		if conf.DABatchTimeout == 0 {
+			conf.DABatchTimeout = conf.DABlockTime
+		}
+		agg.batch = newBlockBatch(conf.DABatchSize, conf.DABatchBytes, conf.DABatchTimeout)
+	} else {
+		// without batching, every block is submitted as soon as possible
+		agg.batch = newBlockBatch(1, 0, 0)
+	}
+
 	return agg, nil
 }
 
+// migrateDataHash returns the first height of a block that has to commit to block data with DataHash.
+// Blocks stored before DataHash was introduced can't be changed (headers are signed), so if the last stored block
+// has legacy DataHash, blocks up to this height are accepted as well.
+func migrateDataHash(store store.Store, s types.State, legacyDataHashHeight uint64, logger log.Logger) uint64 {
+	lastHeight := uint64(s.LastBlockHeight)
+	if lastHeight == 0 || legacyDataHashHeight > lastHeight {
+		return legacyDataHashHeight
+	}
+	lastBlock, err := store.LoadBlock(lastHeight)
+	if err != nil || !lastBlock.SignedHeader.HasLegacyDataHash() {
+		return legacyDataHashHeight
+	}
+	logger.Info("WARNING: stored blocks have legacy DataHash, set LegacyDataHashHeight on all nodes",
+		"legacyDataHashHeight", lastHeight+1)
+	return lastHeight + 1
+}
+
+// lastDAIncludedHeight returns the highest height (up to given height) of a block with recorded DA inclusion.
+func lastDAIncludedHeight(store store.Store, height uint64) uint64 {
+	for ; height > 0; height-- {
+		if _, err := store.LoadDAInclusion(height); err == nil {
+			return height
+		}
+		if status, err := store.LoadBlockStatus(height); err == nil && status.Finality >= types.FinalityDAIncluded {
+			return height
+		}
+	}
+	return 0
+}
+
 func getAddress(key crypto.PrivKey) ([]byte, error) {
 	rawKey, err := key.GetPublic().Raw()
 	if err != nil {
@@ -195,7 +337,7 @@ func (m *Manager) AggregationLoop(ctx context.Context, lazy bool) {
 	if height < initialHeight {
 		delay = time.Until(m.genesis.GenesisTime)
 	} else {
-		delay = time.Until(m.lastState.LastBlockTime.Add(m.conf.BlockTime))
+		delay = time.Until(m.getLastState().LastBlockTime.Add(m.conf.BlockTime))
 	}
 
 	if delay > 0 {
@@ -203,6 +345,8 @@ func (m *Manager) AggregationLoop(ctx context.Context, lazy bool) {
 		time.Sleep(delay)
 	}
 
+	defer m.releaseLease()
+
 	//var timer *time.Timer
 	timer := time.NewTimer(0)
 
@@ -233,6 +377,11 @@ func (m *Manager) AggregationLoop(ctx context.Context, lazy bool) {
 					m.buildingBlock = true
 					timer.Reset(1 * time.Second)
 				}
+			case <-m.forcedTxsAvailable():
+				if !m.buildingBlock {
+					m.buildingBlock = true
+					timer.Reset(1 * time.Second)
+				}
 			case <-timer.C:
 				// build a block with all the transactions received in the last 1 second
 				err := m.publishBlock(ctx)
@@ -252,12 +401,26 @@ func (m *Manager) AggregationLoop(ctx context.Context, lazy bool) {
 // SyncLoop is responsible for syncing blocks.
 //
 // SyncLoop processes headers gossiped in P2p network to know what's the latest block height,
-// block data is retrieved from DA layer.
+// block data is retrieved from DA layer. Retrieval is triggered by new DA heights, if DA layer client can notify
+// about them; otherwise DA layer is polled every DABlockTime.
 func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 	daTicker := time.NewTicker(m.conf.DABlockTime)
+	defer daTicker.Stop()
+	heightCh := m.subscribeHeights(ctx)
 	for {
 		select {
 		case <-daTicker.C:
+			if heightCh == nil {
+				m.retrieveCond.Signal()
+				heightCh = m.subscribeHeights(ctx)
+			}
+		case daHeight, ok := <-heightCh:
+			if !ok {
+				m.logger.Info("DA height subscription closed, polling DA layer")
+				heightCh = nil
+				continue
+			}
+			m.logger.Debug("new DA height", "daHeight", daHeight)
 			m.retrieveCond.Signal()
 		case blockEvent := <-m.blockInCh:
 			block := blockEvent.block
@@ -267,10 +430,18 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 				"daHeight", daHeight,
 				"hash", block.Hash(),
 			)
-			m.syncCache[block.SignedHeader.Header.BaseHeader.Height] = block
 			m.retrieveCond.Signal()
+			if uint64(block.SignedHeader.Height()) <= m.store.Height() {
+				// already synced or produced by this node
+				continue
+			}
+			if reason, err := m.verifyBlock(ctx, block); err != nil {
+				m.rejectBlock(block, reason, err)
+				continue
+			}
+			m.addSyncCandidate(block, daHeight)
 
-			err := m.trySyncNextBlock(ctx, daHeight)
+			err := m.trySyncNextBlock(ctx)
 			if err != nil && err.Error() == fmt.Errorf("failed to ApplyBlock: %w", state.ErrFraudProofGenerated).Error() {
 				return
 			}
@@ -305,20 +476,56 @@ func (m *Manager) SyncLoop(ctx context.Context, cancel context.CancelFunc) {
 	}
 }
 
-// trySyncNextBlock tries to progress one step (one block) in sync process.
+// subscribeHeights subscribes to new DA heights. Nil channel is returned if DA layer client doesn't support
+// subscriptions or subscription failed.
+func (m *Manager) subscribeHeights(ctx context.Context) <-chan uint64 {
+	if !da.Implements[da.HeightSubscriber](m.dalc) {
+		return nil
+	}
+	heights, err := m.dalc.(da.HeightSubscriber).SubscribeHeights(ctx)
+	if err != nil {
+		m.logger.Debug("failed to subscribe to DA heights", "error", err)
+		return nil
+	}
+	m.logger.Info("subscribed to DA heights")
+	return heights
+}
+
+// trySyncNextBlock tries to progress sync process, by applying consecutive blocks from sync cache.
 //
 // To be able to apply block and height h, we need to have its Commit. It is contained in block at height h+1.
 // If block at height h+1 is not available, value of last gossiped commit is checked.
 // If commit for block h is available, we proceed with sync process, and remove synced block from sync cache.
-func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
-	var commit *types.Commit
-	currentHeight := m.store.Height() // TODO(tzdybal): maybe store a copy in memory
+// Blocks can be retrieved out of order, so sync continues as long as the next block is available in the cache.
+// If there are conflicting candidates for the next height, the first one that can be applied is synced. Candidates
+// that fail to apply are rejected and removed from sync cache.
+func (m *Manager) trySyncNextBlock(ctx context.Context) error {
+	for {
+		nextHeight := m.store.Height() + 1 // TODO(tzdybal): maybe store a copy in memory
 
-	b, ok := m.syncCache[currentHeight+1]
-	if !ok {
-		return nil
+		c := m.nextSyncCandidate(ctx, nextHeight)
+		if c == nil {
+			return nil
+		}
+		if err := m.syncBlock(ctx, c.block, c.daHeight); err != nil {
+			if !errors.Is(err, errApplyBlock) {
+				return err
+			}
+			// candidate can't be applied, next candidate for the same height is tried
+			m.rejectBlock(c.block, rejectApply, err)
+			if rest := m.syncCache[nextHeight][1:]; len(rest) > 0 {
+				m.syncCache[nextHeight] = rest
+			} else {
+				delete(m.syncCache, nextHeight)
+			}
+			continue
+		}
+		delete(m.syncCache, nextHeight)
 	}
+}
 
+func (m *Manager) syncBlock(ctx context.Context, b *types.Block, daHeight uint64) error {
+	var commit *types.Commit
 	signedHeader := &b.SignedHeader
 	if signedHeader != nil {
 		commit = &b.SignedHeader.Commit
@@ -326,9 +533,10 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 
 	if b != nil && commit != nil {
 		m.logger.Info("Syncing block", "height", b.SignedHeader.Header.Height())
-		newState, responses, err := m.executor.ApplyBlock(ctx, m.lastState, b)
+		lastState := m.getLastState()
+		newState, responses, err := m.executor.ApplyBlock(ctx, lastState, b)
 		if err != nil {
-			return fmt.Errorf("failed to ApplyBlock: %w", err)
+			return fmt.Errorf("%w: %v", errApplyBlock, err)
 		}
 		err = m.store.SaveBlock(b, commit)
 		if err != nil {
@@ -346,29 +554,40 @@ func (m *Manager) trySyncNextBlock(ctx context.Context, daHeight uint64) error {
 		}
 
 		// SaveValidators commits the DB tx
-		err = m.store.SaveValidators(uint64(b.SignedHeader.Header.Height()), m.lastState.Validators)
+		err = m.store.SaveValidators(uint64(b.SignedHeader.Header.Height()), lastState.Validators)
 		if err != nil {
 			return err
 		}
 
-		if daHeight > newState.DAHeight {
-			newState.DAHeight = daHeight
+		nextDAHeight := daHeight
+		if m.conf.Based {
+			// based block is derived from all transactions from DA height, so derivation resumes at next DA height
+			nextDAHeight++
+		}
+		if nextDAHeight > newState.DAHeight {
+			newState.DAHeight = nextDAHeight
+		}
+		if err := m.forcedTxsIncluded(b); err != nil {
+			return err
 		}
-		m.lastStateMtx.Lock()
-		m.lastState = newState
-		m.lastStateMtx.Unlock()
-		err = m.store.UpdateState(m.lastState)
+		m.saveDAInclusion(b, daHeight, nil, m.conf.NamespaceID[:])
+		err = m.setDAIncludedHeight(uint64(b.SignedHeader.Header.Height()))
+		if err != nil {
+			m.logger.Error("failed to save DA included height", "error", err)
+		}
+		m.setLastState(newState)
+		err = m.store.UpdateState(newState)
 		if err != nil {
 			m.logger.Error("failed to save updated state", "error", err)
 		}
-		delete(m.syncCache, currentHeight+1)
 	}
 
 	return nil
 }
 
 // RetrieveLoop is responsible for interacting with DA layer.
-func (m *Manager) RetrieveLoop(ctx context.Context) {
+// Applied blocks can't be reverted, so node is halted (with cancel) if DA reorg removes them from DA layer.
+func (m *Manager) RetrieveLoop(ctx context.Context, cancel context.CancelFunc) {
 	// waitCh is used to signal the retrieve loop, that it should process next blocks
 	// retrieveCond can be signalled in completely async manner, and goroutine below
 	// works as some kind of "buffer" for those signals
@@ -391,12 +610,24 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 			for {
 				daHeight := atomic.LoadUint64(&m.daHeight)
 				m.logger.Debug("retrieve", "daHeight", daHeight)
-				err := m.processNextDABlock(ctx)
+				err := m.processNextDABlocks(ctx)
+				if errors.Is(err, errHeightFromFuture) {
+					m.logger.Debug("waiting for DA layer to reach height", "daHeight", daHeight)
+					break
+				}
+				if errors.Is(err, errDAInconsistency) {
+					m.logger.Error("state is inconsistent with DA layer, halting node", "error", err)
+					cancel()
+					return
+				}
+				if errors.Is(err, errDAReorg) {
+					// retrieval starts again from the reorged DA height
+					continue
+				}
 				if err != nil {
 					m.logger.Error("failed to retrieve block from DALC", "daHeight", daHeight, "errors", err.Error())
 					break
 				}
-				atomic.AddUint64(&m.daHeight, 1)
 			}
 		case <-ctx.Done():
 			return
@@ -404,6 +635,170 @@ func (m *Manager) RetrieveLoop(ctx context.Context) {
 	}
 }
 
+// processNextDABlocks retrieves blocks from up to DAPrefetchWindow consecutive DA heights (starting at m.daHeight)
+// and processes them in DA height order. DA height is advanced after every processed height.
+// Error is returned only if the first height can't be retrieved, or DA reorg was detected.
+func (m *Manager) processNextDABlocks(ctx context.Context) error {
+	daHeight := atomic.LoadUint64(&m.daHeight)
+	window := m.conf.DAPrefetchWindow
+	if window < 1 {
+		window = 1
+	}
+
+	results := m.fetchBlocks(ctx, daHeight, window)
+	for i, res := range results {
+		if res.err != nil {
+			if i > 0 {
+				// next call starts with this height
+				return nil
+			}
+			// fall back to retrieval of a single height, with retries
+			return m.processNextDABlock(ctx)
+		}
+		if err := m.processDAHeight(ctx, daHeight+uint64(i), res.Blocks); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// processDAHeight advances DA height and passes blocks retrieved from DA height to SyncLoop.
+// If DAConfirmationDepth is set, blocks are passed only after DA height is confirmed.
+func (m *Manager) processDAHeight(ctx context.Context, daHeight uint64, blocks []*types.Block) error {
+	m.logger.Debug("retrieved potential blocks", "n", len(blocks), "daHeight", daHeight)
+	if m.conf.DAConfirmationDepth == 0 {
+		if err := m.queueForcedTxs(ctx, daHeight); err != nil {
+			return err
+		}
+		atomic.AddUint64(&m.daHeight, 1)
+		m.sendBlocks(daHeight, blocks)
+		return nil
+	}
+	atomic.AddUint64(&m.daHeight, 1)
+	m.unconfirmed = append(m.unconfirmed, unconfirmedDAHeight{daHeight: daHeight, hash: hashDABlocks(blocks)})
+	return m.confirmDAHeights(ctx, daHeight)
+}
+
+// confirmDAHeights passes blocks to SyncLoop from DA heights that are at least DAConfirmationDepth heights below head.
+// Every DA height is retrieved again before confirmation. If it returns different blocks, DA reorg happened,
+// and retrieval is rolled back. If the reorg is deeper than DAConfirmationDepth and removed applied blocks,
+// errDAInconsistency is returned.
+func (m *Manager) confirmDAHeights(ctx context.Context, head uint64) error {
+	for len(m.unconfirmed) > 0 && m.unconfirmed[0].daHeight+m.conf.DAConfirmationDepth <= head {
+		pending := m.unconfirmed[0]
+		res, err := m.fetchBlock(ctx, pending.daHeight)
+		if err != nil {
+			// confirmation is retried after next DA height is retrieved
+			m.logger.Error("failed to confirm DA height", "daHeight", pending.daHeight, "error", err)
+			return nil
+		}
+		if !bytes.Equal(hashDABlocks(res.Blocks), pending.hash) {
+			rollbackHeight, err := m.checkAppliedBlocks(ctx, pending.daHeight)
+			if err != nil {
+				return err
+			}
+			m.rollbackDA(rollbackHeight)
+			return fmt.Errorf("%w at DA height %d", errDAReorg, pending.daHeight)
+		}
+		if err := m.queueForcedTxs(ctx, pending.daHeight); err != nil {
+			m.logger.Error("failed to confirm DA height", "daHeight", pending.daHeight, "error", err)
+			return nil
+		}
+		m.unconfirmed = m.unconfirmed[1:]
+		m.sendBlocks(pending.daHeight, res.Blocks)
+	}
+	return nil
+}
+
+// checkAppliedBlocks is called after DA reorg was detected at daHeight. It checks that the last block known to be
+// included in DA layer is still included at the DA height it was included at, and returns DA height from which
+// retrieval can be safely restarted. Confirmed DA heights after the last included block are retrieved again, as reorg
+// could reach them. errDAInconsistency is returned if the reorg removed the block.
+func (m *Manager) checkAppliedBlocks(ctx context.Context, daHeight uint64) (uint64, error) {
+	height := atomic.LoadUint64(&m.daSubmittedHeight)
+	inclusion, err := m.store.LoadDAInclusion(height)
+	if err != nil || inclusion.DAHeight >= daHeight {
+		// nothing was applied from confirmed DA heights
+		return daHeight, nil
+	}
+	res, err := m.fetchBlock(ctx, inclusion.DAHeight)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check applied blocks after DA reorg: %w", err)
+	}
+	for _, block := range res.Blocks {
+		if bytes.Equal(block.Hash(), inclusion.Hash) {
+			return inclusion.DAHeight + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: block at height %d is no longer included at DA height %d", errDAInconsistency, height, inclusion.DAHeight)
+}
+
+// rollbackDA discards all unconfirmed DA heights, so retrieval starts again from daHeight.
+// Blocks from DA heights from which retrieval starts again were never applied, so they don't have to be reverted.
+// Blocks retrieved again at heights that are already applied are skipped by SyncLoop.
+func (m *Manager) rollbackDA(daHeight uint64) {
+	m.logger.Error("DA reorg detected, rolling back retrieval", "daHeight", daHeight,
+		"fromDAHeight", atomic.LoadUint64(&m.daHeight)-1, "discardedDAHeights", len(m.unconfirmed))
+	m.unconfirmed = nil
+	atomic.StoreUint64(&m.daHeight, daHeight)
+}
+
+func (m *Manager) sendBlocks(daHeight uint64, blocks []*types.Block) {
+	for _, block := range blocks {
+		m.blockInCh <- newBlockEvent{block, daHeight}
+	}
+}
+
+// hashDABlocks returns hash identifying blocks retrieved from a single DA height.
+// Serialized blocks are hashed, as header hash is not defined for all the blocks that can be posted to DA layer.
+func hashDABlocks(blocks []*types.Block) []byte {
+	hasher := sha256.New()
+	for _, block := range blocks {
+		blob, err := block.MarshalBinary()
+		if err != nil {
+			// such block can't be applied anyway
+			continue
+		}
+		hash := sha256.Sum256(blob)
+		hasher.Write(hash[:])
+	}
+	return hasher.Sum(nil)
+}
+
+type fetchResult struct {
+	da.ResultRetrieveBlocks
+	err error
+}
+
+// fetchBlocks retrieves blocks from n consecutive DA heights, starting at from. Results are returned in order.
+// Range retrieval is used if DA layer client supports it, otherwise heights are retrieved concurrently.
+func (m *Manager) fetchBlocks(ctx context.Context, from uint64, n uint64) []fetchResult {
+	if n > 1 && da.Implements[da.BlockRangeRetriever](m.retriever) {
+		ranged := m.retriever.(da.BlockRangeRetriever).RetrieveBlocksRange(ctx, from, from+n-1)
+		if len(ranged) == 0 {
+			return []fetchResult{{err: errors.New("no results from range retrieval")}}
+		}
+		results := make([]fetchResult, len(ranged))
+		for i := range ranged {
+			results[i] = fetchResult{ranged[i], retrieveError(ranged[i])}
+		}
+		return results
+	}
+
+	results := make([]fetchResult, n)
+	var wg sync.WaitGroup
+	for i := uint64(0); i < n; i++ {
+		wg.Add(1)
+		go func(i uint64) {
+			defer wg.Done()
+			res, err := m.fetchBlock(ctx, from+i)
+			results[i] = fetchResult{res, err}
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
 func (m *Manager) processNextDABlock(ctx context.Context) error {
 	// TODO(tzdybal): extract configuration option
 	maxRetries := 10
@@ -413,30 +808,37 @@ func (m *Manager) processNextDABlock(ctx context.Context) error {
 	m.logger.Debug("trying to retrieve block from DA", "daHeight", daHeight)
 	for r := 0; r < maxRetries; r++ {
 		blockResp, fetchErr := m.fetchBlock(ctx, daHeight)
+		if errors.Is(fetchErr, errHeightFromFuture) {
+			// there is no point in retrying until DA layer produces new block
+			return fetchErr
+		}
 		if fetchErr != nil {
 			err = multierr.Append(err, fetchErr)
 			time.Sleep(100 * time.Millisecond)
 		} else {
-			m.logger.Debug("retrieved potential blocks", "n", len(blockResp.Blocks), "daHeight", daHeight)
-			for _, block := range blockResp.Blocks {
-				m.blockInCh <- newBlockEvent{block, daHeight}
-			}
-			return nil
+			return m.processDAHeight(ctx, daHeight, blockResp.Blocks)
 		}
 	}
 	return err
 }
 
 func (m *Manager) fetchBlock(ctx context.Context, daHeight uint64) (da.ResultRetrieveBlocks, error) {
-	var err error
 	blockRes := m.retriever.RetrieveBlocks(ctx, daHeight)
+	return blockRes, retrieveError(blockRes)
+}
+
+func retrieveError(blockRes da.ResultRetrieveBlocks) error {
 	switch blockRes.Code {
+	case da.StatusSuccess, da.StatusNamespaceEmpty:
+		return nil
+	case da.StatusHeightFromFuture:
+		return fmt.Errorf("%w: %s", errHeightFromFuture, blockRes.Message)
 	case da.StatusError:
-		err = fmt.Errorf("failed to retrieve block: %s", blockRes.Message)
+		return fmt.Errorf("failed to retrieve block: %s", blockRes.Message)
 	case da.StatusTimeout:
-		err = fmt.Errorf("timeout during retrieve block: %s", blockRes.Message)
+		return fmt.Errorf("timeout during retrieve block: %s", blockRes.Message)
 	}
-	return blockRes, err
+	return nil
 }
 
 func (m *Manager) getRemainingSleep(start time.Time) time.Duration {
@@ -448,23 +850,32 @@ func (m *Manager) getRemainingSleep(start time.Time) time.Duration {
 	return sleepDuration
 }
 
+// getCommit signs the header. Signer refuses to sign different header at the same height, so block at given height
+// can't be signed twice, even after restart.
 func (m *Manager) getCommit(header types.Header) (*types.Commit, error) {
 	headerBytes, err := header.MarshalBinary()
 	if err != nil {
 		return nil, err
 	}
-	sign, err := m.proposerKey.Sign(headerBytes)
+	sign, err := m.signer.Sign(uint64(header.Height()), headerBytes)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to sign header at height %d: %w", header.Height(), err)
 	}
 	return &types.Commit{
 		Signatures: []types.Signature{sign},
 	}, nil
 }
 
+// IsProposer returns true if this node is the proposer of the next block. Proposer rotates across validator set
+// according to proposer priorities, which are updated after every block.
 func (m *Manager) IsProposer() (bool, error) {
+	return m.isProposer(m.getLastState())
+}
+
+// isProposer returns true if this node is the proposer of the block following given state.
+func (m *Manager) isProposer(lastState types.State) (bool, error) {
 	// if proposer is not set, assume self proposer
-	if m.lastState.Validators.Proposer == nil {
+	if lastState.Validators.Proposer == nil {
 		return true, nil
 	}
 
@@ -473,7 +884,21 @@ func (m *Manager) IsProposer() (bool, error) {
 		return false, err
 	}
 
-	return bytes.Equal(m.lastState.Validators.Proposer.PubKey.Bytes(), signerPubBytes), nil
+	return bytes.Equal(lastState.Validators.Proposer.PubKey.Bytes(), signerPubBytes), nil
+}
+
+// getLastState returns the last state. State is updated by SyncLoop and AggregationLoop, so it's read under lock.
+func (m *Manager) getLastState() types.State {
+	m.lastStateMtx.Lock()
+	defer m.lastStateMtx.Unlock()
+	return m.lastState
+}
+
+// setLastState updates the last state, under lock.
+func (m *Manager) setLastState(s types.State) {
+	m.lastStateMtx.Lock()
+	defer m.lastStateMtx.Unlock()
+	m.lastState = s
 }
 
 func (m *Manager) publishBlock(ctx context.Context) error {
@@ -483,13 +908,24 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 	height := m.store.Height()
 	newHeight := height + 1
 
-	m.lastStateMtx.Lock()
-	isProposer, err := m.IsProposer()
-	m.lastStateMtx.Unlock()
+	lastState := m.getLastState()
+	// store height is updated before state, while block from another proposer is synced
+	syncing := uint64(lastState.LastBlockHeight) != height
+	isProposer, err := m.isProposer(lastState)
 	if err != nil {
 		return fmt.Errorf("error while checking for proposer: %w", err)
 	}
-	if !isProposer {
+	if !isProposer || syncing {
+		return nil
+	}
+
+	signedHeight, ok := m.acquireLease(ctx)
+	if !ok {
+		return nil
+	}
+
+	if pending := m.pendingBlocks(); m.conf.MaxPendingBlocks > 0 && pending >= m.conf.MaxPendingBlocks {
+		m.logger.Info("too many blocks pending DA submission, waiting before producing new block", "pending", pending)
 		return nil
 	}
 
@@ -510,46 +946,67 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 
 	var block *types.Block
 	var commit *types.Commit
+	var newState types.State
+	var responses *tmstate.ABCIResponses
 
 	// Check if there's an already stored block at a newer height
 	// If there is use that instead of creating a new block
 	pendingBlock, err := m.store.LoadBlock(newHeight)
-	if err == nil {
+	if err == nil && signedHeight <= newHeight {
 		m.logger.Info("Using pending block", "height", newHeight)
 		block = pendingBlock
+		// pending block is already signed; signing it again would return the same signature
+		commit = &pendingBlock.SignedHeader.Commit
+		if len(commit.Signatures) == 0 {
+			// block was saved before it was signed; signer returns the same signature, if it was signed before crash
+			commit, err = m.getCommit(block.SignedHeader.Header)
+			if err != nil {
+				return err
+			}
+			block.SignedHeader.Commit = *commit
+		}
+
+		// Apply the block but DONT commit
+		newState, responses, err = m.executor.ApplyBlock(ctx, lastState, block)
+		if err != nil {
+			return err
+		}
 	} else {
+		if signedHeight > height {
+			// standby aggregator has to sync all the blocks signed by the previous leader, before producing blocks
+			m.logger.Info("waiting to sync blocks signed by previous leader", "height", height, "signedHeight", signedHeight)
+			return nil
+		}
+		// height is recorded in the lease before block is signed, so another lease holder never signs the same height
+		if err := m.signLease(ctx, newHeight); err != nil {
+			return err
+		}
 		m.logger.Info("Creating and publishing block", "height", newHeight)
-		block = m.executor.CreateBlock(newHeight, lastCommit, lastHeaderHash, m.lastState)
+		block = m.executor.CreateBlock(newHeight, lastCommit, lastHeaderHash, lastState, m.pendingForcedTxs())
 		m.logger.Debug("block info", "num_tx", len(block.Data.Txs))
+		block.SignedHeader.Validators = lastState.Validators
 
-		commit, err = m.getCommit(block.SignedHeader.Header)
+		// Execute the block but DONT commit; ISRs are generated while executing the block, so header is signed
+		// only after that, when DataHash commits to final block data
+		newState, responses, err = m.executor.ExecuteBlock(ctx, lastState, block)
 		if err != nil {
 			return err
 		}
 
-		// set the commit to current block's signed header
-		block.SignedHeader.Commit = *commit
-
-		block.SignedHeader.Validators = m.lastState.Validators
-
-		// SaveBlock commits the DB tx
-		err = m.store.SaveBlock(block, commit)
+		// block is saved before it's signed, so after crash the same header is signed again, instead of a new one
+		// with different timestamp, that signer would refuse to sign
+		block.SignedHeader.Commit = types.Commit{}
+		err = m.store.SaveBlock(block, &block.SignedHeader.Commit)
 		if err != nil {
 			return err
 		}
-	}
-
-	// Apply the block but DONT commit
-	newState, responses, err := m.executor.ApplyBlock(ctx, m.lastState, block)
-	if err != nil {
-		return err
-	}
-
-	if commit == nil {
 		commit, err = m.getCommit(block.SignedHeader.Header)
 		if err != nil {
 			return err
 		}
+
+		// set the commit to current block's signed header
+		block.SignedHeader.Commit = *commit
 	}
 
 	// SaveBlock commits the DB tx
@@ -558,23 +1015,19 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 		return err
 	}
 
-	err = m.submitBlockToDA(ctx, block)
-	if err != nil {
-		m.logger.Error("Failed to submit block to DA Layer")
-		return err
-	}
-
 	blockHeight := uint64(block.SignedHeader.Header.Height())
 
-	// Only update the stored height after successfully submitting to DA layer and committing to the DB
-	m.store.SetHeight(blockHeight)
-
 	// Commit the new state and block which writes to disk on the proxy app
 	_, _, err = m.executor.Commit(ctx, newState, block, responses)
 	if err != nil {
 		return err
 	}
 
+	err = m.forcedTxsIncluded(block)
+	if err != nil {
+		return err
+	}
+
 	// SaveBlockResponses commits the DB tx
 	err = m.store.SaveBlockResponses(blockHeight, responses)
 	if err != nil {
@@ -582,53 +1035,250 @@ func (m *Manager) publishBlock(ctx context.Context) error {
 	}
 
 	// SaveValidators commits the DB tx
-	err = m.store.SaveValidators(blockHeight, m.lastState.Validators)
+	err = m.store.SaveValidators(blockHeight, lastState.Validators)
 	if err != nil {
 		return err
 	}
 
+	// Update the stored height after all the block data is saved, block becomes pending DA submission
+	m.store.SetHeight(blockHeight)
+
 	newState.DAHeight = atomic.LoadUint64(&m.daHeight)
 	// After this call m.lastState is the NEW state returned from ApplyBlock
-	m.lastState = newState
+	m.setLastState(newState)
 
 	// UpdateState commits the DB tx
-	err = m.store.UpdateState(m.lastState)
+	err = m.store.UpdateState(newState)
 	if err != nil {
 		return err
 	}
 
+	m.setBlockStatus(blockHeight, types.FinalityProduced, 0)
+
 	// Publish header to channel so that header exchange service can broadcast
 	m.HeaderCh <- &block.SignedHeader
 
 	m.logger.Debug("successfully proposed block", "proposer", hex.EncodeToString(block.SignedHeader.ProposerAddress), "height", block.SignedHeader.Height())
 
+	// notify submission loop about new pending block
+	select {
+	case m.submitCh <- struct{}{}:
+	default:
+	}
+
 	return nil
 }
 
-func (m *Manager) submitBlockToDA(ctx context.Context, block *types.Block) error {
-	m.logger.Info("submitting block to DA layer", "height", block.SignedHeader.Header.Height())
+// SubmissionLoop is responsible for submitting produced blocks to DA layer.
+//
+// Blocks are read from the store, starting from the height after the last successfully submitted block,
+// so submission is resumed after restart of the node. Node is halted (with cancel) if a block can't be submitted at
+// all, because it exceeds DA layer size limit - chain can't progress without it.
+func (m *Manager) SubmissionLoop(ctx context.Context, cancel context.CancelFunc) {
+	interval := m.conf.DABlockTime
+	if m.batch.timeout > 0 {
+		interval = m.batch.timeout
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
-	submitted := false
-	backoff := initialBackoff
-	for attempt := 1; ctx.Err() == nil && !submitted && attempt <= maxSubmitAttempts; attempt++ {
-		res := m.dalc.SubmitBlock(ctx, block)
-		if res.Code == da.StatusSuccess {
-			m.logger.Info("successfully submitted Rollkit block to DA layer", "rollkitHeight", block.SignedHeader.Header.Height(), "daHeight", res.DAHeight)
-			submitted = true
-		} else {
-			m.logger.Error("DA layer submission failed", "error", res.Message, "attempt", attempt)
-			time.Sleep(backoff)
-			backoff = m.exponentialBackoff(backoff)
+	for {
+		err := m.submitPendingBlocks(ctx)
+		if errors.Is(err, errBlobTooBig) {
+			m.logger.Error("block can't be submitted to DA layer, halting node", "error", err)
+			cancel()
+			return
+		}
+		if err != nil {
+			m.logger.Error("error while submitting blocks to DA layer", "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-m.submitCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// pendingBlocks returns the number of blocks that were produced, but not yet submitted to DA layer.
+func (m *Manager) pendingBlocks() uint64 {
+	height := m.store.Height()
+	submitted := atomic.LoadUint64(&m.daSubmittedHeight)
+	if height <= submitted {
+		return 0
+	}
+	return height - submitted
+}
+
+// submitPendingBlocks collects pending blocks into batches and submits them to DA layer.
+// Batch that is neither full nor expired is kept to be completed with the blocks produced later.
+func (m *Manager) submitPendingBlocks(ctx context.Context) error {
+	for ctx.Err() == nil {
+		next := atomic.LoadUint64(&m.daSubmittedHeight) + uint64(len(m.batch.blocks)) + 1
+		overflow := false
+		if !m.batch.full() && next <= m.store.Height() {
+			block, err := m.store.LoadBlock(next)
+			if err != nil {
+				return fmt.Errorf("failed to load pending block: %w", err)
+			}
+			blob, err := block.MarshalBinary()
+			if err != nil {
+				return err
+			}
+			size := uint64(len(blob))
+			if m.batch.fits(size) {
+				m.batch.add(block, size)
+				continue
+			}
+			overflow = true
+		}
+
+		if m.batch.empty() || !(overflow || m.batch.full() || m.batch.expired()) {
+			return nil
+		}
+
+		err := m.submitBatch(ctx, m.batch.blocks)
+		// on error, batch is rebuilt from the store, starting after the last submitted block
+		m.batch.reset()
+		if err != nil {
+			return err
 		}
 	}
+	return ctx.Err()
+}
 
-	if !submitted {
-		return fmt.Errorf("Failed to submit block to DA layer after %d attempts", maxSubmitAttempts)
+// submitBatch submits blocks to DA layer and records their inclusion.
+// If DA layer rejects the blocks as too big, they are split in halves and submitted separately.
+func (m *Manager) submitBatch(ctx context.Context, blocks []*types.Block) error {
+	res, err := m.submitBlocksToDA(ctx, blocks)
+	if errors.Is(err, errBlobTooBig) && len(blocks) > 1 {
+		half := len(blocks) / 2
+		m.logger.Info("splitting DA submission", "blocks", len(blocks), "parts", 2)
+		if err := m.submitBatch(ctx, blocks[:half]); err != nil {
+			return err
+		}
+		return m.submitBatch(ctx, blocks[half:])
+	}
+	if err != nil {
+		return err
 	}
+	for _, block := range blocks {
+		m.saveDAInclusion(block, res.DAHeight, res.Commitment, res.Namespace)
+	}
+	return m.setDAIncludedHeight(uint64(blocks[len(blocks)-1].SignedHeader.Header.Height()))
+}
+
+// setDAIncludedHeight persists the height of the last block known to be included in DA layer.
+// DAIncludedHeight returns height of the last block known to be included in DA layer.
+func (m *Manager) DAIncludedHeight() uint64 {
+	return atomic.LoadUint64(&m.daSubmittedHeight)
+}
 
+func (m *Manager) setDAIncludedHeight(height uint64) error {
+	if height <= atomic.LoadUint64(&m.daSubmittedHeight) {
+		return nil
+	}
+	err := m.store.SetDASubmittedHeight(height)
+	if err != nil {
+		return err
+	}
+	atomic.StoreUint64(&m.daSubmittedHeight, height)
 	return nil
 }
 
+// saveDAInclusion persists information about inclusion of a block in DA layer, and marks it as DA-included.
+func (m *Manager) saveDAInclusion(block *types.Block, daHeight uint64, commitment []byte, namespace []byte) {
+	height := uint64(block.SignedHeader.Header.Height())
+	err := m.store.SaveDAInclusion(types.DAInclusion{
+		Height:     height,
+		Hash:       block.SignedHeader.Header.Hash(),
+		DAHeight:   daHeight,
+		Commitment: commitment,
+		Namespace:  namespace,
+	})
+	if err != nil {
+		m.logger.Error("failed to save DA inclusion", "height", height, "error", err)
+	}
+	m.setBlockStatus(height, types.FinalityDAIncluded, daHeight)
+}
+
+// SetSoftConfirmed marks block at given height as soft-confirmed, i.e. gossiped in P2P network.
+func (m *Manager) SetSoftConfirmed(height uint64) {
+	m.setBlockStatus(height, types.FinalitySoftConfirmed, 0)
+}
+
+// setBlockStatus persists finality status of a block and publishes EventBlockStatus.
+// Status only moves forward - attempts to downgrade it are ignored.
+func (m *Manager) setBlockStatus(height uint64, finality types.FinalityStatus, daHeight uint64) {
+	m.statusMtx.Lock()
+	defer m.statusMtx.Unlock()
+
+	current, err := m.store.LoadBlockStatus(height)
+	if err == nil && current.Finality >= finality {
+		return
+	}
+	status := types.BlockStatus{Height: height, Finality: finality, DAHeight: daHeight}
+	err = m.store.SaveBlockStatus(status)
+	if err != nil {
+		m.logger.Error("failed to save block status", "height", height, "error", err)
+		return
+	}
+	if m.eventBus != nil {
+		err = m.eventBus.Publish(types.EventBlockStatus, status)
+		if err != nil {
+			m.logger.Error("failed to publish block status event", "height", height, "error", err)
+		}
+	}
+}
+
+// submitBlocksToDA submits blocks to DA layer in a single submission and returns result of successful submission.
+// Submission is retried (with backoff) until it succeeds or context is cancelled. Blocks already included in DA layer
+// are treated as submitted, oversized submissions are not retried, and insufficient fee is bumped (if DA layer
+// client supports it) before immediate retry. Once fee can't be bumped any more, submission is retried with backoff.
+func (m *Manager) submitBlocksToDA(ctx context.Context, blocks []*types.Block) (da.ResultSubmitBlock, error) {
+	first := blocks[0].SignedHeader.Header.Height()
+	last := blocks[len(blocks)-1].SignedHeader.Header.Height()
+	m.logger.Info("submitting blocks to DA layer", "fromHeight", first, "toHeight", last)
+
+	backoff := initialBackoff
+	for attempt := 1; ; attempt++ {
+		var res da.ResultSubmitBlock
+		if len(blocks) == 1 {
+			res = m.dalc.SubmitBlock(ctx, blocks[0])
+		} else {
+			res = m.dalc.SubmitBlocks(ctx, blocks)
+		}
+		switch res.Code {
+		case da.StatusSuccess:
+			m.logger.Info("successfully submitted Rollkit blocks to DA layer", "fromHeight", first, "toHeight", last, "daHeight", res.DAHeight, "fee", res.Fee)
+			return res, nil
+		case da.StatusAlreadyIncluded:
+			m.logger.Info("Rollkit blocks already included in DA layer", "fromHeight", first, "toHeight", last, "daHeight", res.DAHeight)
+			return res, nil
+		case da.StatusBlobTooBig:
+			m.logger.Error("DA layer rejected oversized submission", "fromHeight", first, "toHeight", last, "error", res.Message)
+			return res, fmt.Errorf("%w: %s", errBlobTooBig, res.Message)
+		case da.StatusInsufficientFee:
+			if da.Implements[da.FeeBumper](m.dalc) && m.dalc.(da.FeeBumper).BumpFee() {
+				m.logger.Info("DA layer submission fee too low, bumped fee", "error", res.Message, "attempt", attempt)
+				if ctx.Err() != nil {
+					return da.ResultSubmitBlock{}, ctx.Err()
+				}
+				continue
+			}
+		}
+		m.logger.Error("DA layer submission failed", "error", res.Message, "code", res.Code, "attempt", attempt)
+
+		select {
+		case <-ctx.Done():
+			return da.ResultSubmitBlock{}, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff = m.exponentialBackoff(backoff)
+	}
+}
+
 func (m *Manager) exponentialBackoff(backoff time.Duration) time.Duration {
 	backoff *= 2
 	if backoff > m.conf.DABlockTime {
@@ -3,19 +3,38 @@ package block
 import (
 	"context"
 	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	ds "github.com/ipfs/go-datastore"
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cfg "github.com/tendermint/tendermint/config"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/proxy"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/da/chaos"
 	mockda "github.com/rollkit/rollkit/da/mock"
+	"github.com/rollkit/rollkit/lease"
+	rollkitlog "github.com/rollkit/rollkit/log"
+	mempoolv1 "github.com/rollkit/rollkit/mempool/v1"
+	"github.com/rollkit/rollkit/mocks"
+	"github.com/rollkit/rollkit/signer"
+	"github.com/rollkit/rollkit/state"
 	"github.com/rollkit/rollkit/store"
 	"github.com/rollkit/rollkit/types"
 )
@@ -91,6 +110,377 @@ func TestInitialState(t *testing.T) {
 	}
 }
 
+func TestPendingBlocksSubmission(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	logger := log.TestingLogger()
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+	app.On("GetAppHash", mock.Anything).Return(abci.ResponseGetAppHash{})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:        time.Second,
+		DABlockTime:      100 * time.Millisecond,
+		NamespaceID:      types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		MaxPendingBlocks: 3,
+	}
+
+	mockDALC := &mockda.DataAvailabilityLayerClient{}
+	daKV, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(mockDALC.Init(conf.NamespaceID, nil, daKV, logger))
+	require.NoError(mockDALC.Start())
+	defer func() { _ = mockDALC.Stop() }()
+	dalc := &unreliableDALC{DataAvailabilityLayerClient: mockDALC}
+	atomic.StoreUint32(&dalc.failing, 1)
+
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	s1 := store.New(ctx, kv)
+	m1, err := NewManager(key, conf, genesis, s1, mpool, proxy.NewAppConnConsensus(client), dalc, nil, logger, make(chan struct{}))
+	require.NoError(err)
+
+	subCtx, subCancel := context.WithCancel(ctx)
+	go m1.SubmissionLoop(subCtx, subCancel)
+
+	// production is not blocked by failing DA layer, but stops after reaching backlog limit
+	for i := 0; i < 5; i++ {
+		require.NoError(m1.publishBlock(ctx))
+	}
+	assert.Equal(uint64(3), s1.Height())
+	assert.Equal(uint64(3), m1.pendingBlocks())
+	m1.SetSoftConfirmed(1)
+	assertFinality(t, s1, 1, types.FinalitySoftConfirmed)
+	assertFinality(t, s1, 3, types.FinalityProduced)
+	subCancel()
+
+	// pending blocks are submitted after restart
+	atomic.StoreUint32(&dalc.failing, 0)
+	s2 := store.New(ctx, kv)
+	m2, err := NewManager(key, conf, genesis, s2, mpool, proxy.NewAppConnConsensus(client), dalc, nil, logger, make(chan struct{}))
+	require.NoError(err)
+	assert.Equal(uint64(3), m2.pendingBlocks())
+
+	go m2.SubmissionLoop(ctx, cancel)
+	require.Eventually(func() bool {
+		return m2.pendingBlocks() == 0
+	}, 5*time.Second, 50*time.Millisecond)
+
+	submitted, err := s2.LoadDASubmittedHeight()
+	require.NoError(err)
+	assert.Equal(uint64(3), submitted)
+	assert.Equal(uint64(3), atomic.LoadUint64(&dalc.submitted))
+	for h := uint64(1); h <= 3; h++ {
+		assertFinality(t, s2, h, types.FinalityDAIncluded)
+
+		block, err := s2.LoadBlock(h)
+		require.NoError(err)
+		inclusion, err := s2.LoadDAInclusionByHash(block.SignedHeader.Header.Hash())
+		require.NoError(err)
+		assert.Equal(h, inclusion.Height)
+		assert.NotZero(inclusion.DAHeight)
+		assert.NotEmpty(inclusion.Commitment)
+		assert.Equal(conf.NamespaceID[:], []byte(inclusion.Namespace))
+	}
+
+	// status never goes back
+	m2.SetSoftConfirmed(2)
+	assertFinality(t, s2, 2, types.FinalityDAIncluded)
+
+	require.NoError(m2.publishBlock(ctx))
+	assert.Equal(uint64(4), s2.Height())
+}
+
+func assertFinality(t *testing.T, s store.Store, height uint64, expected types.FinalityStatus) {
+	t.Helper()
+	status, err := s.LoadBlockStatus(height)
+	require.NoError(t, err)
+	assert.Equal(t, expected, status.Finality)
+	if expected == types.FinalityDAIncluded {
+		assert.NotZero(t, status.DAHeight)
+	}
+}
+
+// unreliableDALC is used to simulate DA layer outage.
+type unreliableDALC struct {
+	*mockda.DataAvailabilityLayerClient
+	failing   uint32
+	submitted uint64
+}
+
+func (u *unreliableDALC) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	return u.SubmitBlocks(ctx, []*types.Block{block})
+}
+
+func (u *unreliableDALC) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	if atomic.LoadUint32(&u.failing) == 1 {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: "DA layer unavailable"}}
+	}
+	atomic.AddUint64(&u.submitted, uint64(len(blocks)))
+	return u.DataAvailabilityLayerClient.SubmitBlocks(ctx, blocks)
+}
+
+// statusDALC rejects submissions of more than maxBlocks blocks, and returns queued status codes before
+// passing submissions to mock DA layer.
+type statusDALC struct {
+	*mockda.DataAvailabilityLayerClient
+	maxBlocks   int
+	codes       []da.StatusCode
+	submissions [][]*types.Block
+	bumps       int
+}
+
+func (s *statusDALC) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	return s.SubmitBlocks(ctx, []*types.Block{block})
+}
+
+func (s *statusDALC) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	if len(blocks) > s.maxBlocks {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusBlobTooBig, Message: "blob too big"}}
+	}
+	if len(s.codes) > 0 {
+		code := s.codes[0]
+		s.codes = s.codes[1:]
+		if code != da.StatusSuccess {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: code, Message: code.String(), DAHeight: 1}}
+		}
+	}
+	s.submissions = append(s.submissions, blocks)
+	return s.DataAvailabilityLayerClient.SubmitBlocks(ctx, blocks)
+}
+
+func (s *statusDALC) BumpFee() bool {
+	s.bumps++
+	return true
+}
+
+// wrapperDALC wraps single client and forwards fee bumps to it, like multi or failover clients.
+type wrapperDALC struct {
+	da.DataAvailabilityLayerClient
+	bumps int
+}
+
+func (w *wrapperDALC) Unwrap() []da.DataAvailabilityLayerClient {
+	return []da.DataAvailabilityLayerClient{w.DataAvailabilityLayerClient}
+}
+
+func (w *wrapperDALC) BumpFee() bool {
+	w.bumps++
+	if bumper, ok := w.DataAvailabilityLayerClient.(da.FeeBumper); ok {
+		return bumper.BumpFee()
+	}
+	return false
+}
+
+func TestSubmitWrappedFeeBumper(t *testing.T) {
+	logger := log.TestingLogger()
+
+	cases := []struct {
+		name   string
+		bumper bool
+		bumps  int
+	}{
+		{"fee bumper", true, 1},
+		{"client without fee bumping", false, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mockDALC := &mockda.DataAvailabilityLayerClient{}
+			daKV, _ := store.NewDefaultInMemoryKVStore()
+			require.NoError(mockDALC.Init(types.NamespaceID{}, nil, daKV, logger))
+			require.NoError(mockDALC.Start())
+			defer func() { _ = mockDALC.Stop() }()
+
+			status := &statusDALC{DataAvailabilityLayerClient: mockDALC, maxBlocks: 1, codes: []da.StatusCode{da.StatusInsufficientFee}}
+			var inner da.DataAvailabilityLayerClient = status
+			if !c.bumper {
+				// hide BumpFee of statusDALC
+				inner = struct{ da.DataAvailabilityLayerClient }{status}
+			}
+			dalc := &wrapperDALC{DataAvailabilityLayerClient: inner}
+			assert.Equal(c.bumper, da.Implements[da.FeeBumper](dalc))
+
+			m := &Manager{
+				conf:   config.BlockManagerConfig{DABlockTime: 100 * time.Millisecond},
+				dalc:   dalc,
+				logger: logger,
+			}
+			block := &types.Block{}
+			block.SignedHeader.Header.BaseHeader.Height = 1
+			res, err := m.submitBlocksToDA(context.Background(), []*types.Block{block})
+			require.NoError(err)
+			assert.Equal(da.StatusSuccess, res.Code)
+			assert.Len(status.submissions, 1)
+			assert.Equal(c.bumps, dalc.bumps)
+			assert.Equal(c.bumps, status.bumps)
+		})
+	}
+}
+
+func TestFullNodeDAIncludedHeight(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	validatorKey := ed25519.GenPrivKey()
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.BlockManagerConfig{BlockTime: time.Second, DABlockTime: time.Second}
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	m := newTestManagerWithKV(t, key, conf, genesis, &mockda.DataAvailabilityLayerClient{}, kv)
+	assert.Zero(m.DAIncludedHeight())
+
+	// blocks synced before DA included height was tracked
+	s := m.getLastState()
+	s.LastBlockHeight = 4
+	s.LastValidators = s.Validators
+	require.NoError(m.store.UpdateState(s))
+	require.NoError(m.store.SaveDAInclusion(types.DAInclusion{Height: 2, DAHeight: 1}))
+	require.NoError(m.store.SaveBlockStatus(types.BlockStatus{Height: 3, Finality: types.FinalityDAIncluded}))
+	require.NoError(m.store.SaveBlockStatus(types.BlockStatus{Height: 4, Finality: types.FinalitySoftConfirmed}))
+
+	m = newTestManagerWithKV(t, key, conf, genesis, &mockda.DataAvailabilityLayerClient{}, kv)
+	assert.Equal(uint64(3), m.DAIncludedHeight())
+	_, err := m.store.LoadDASubmittedHeight()
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
+
+func TestSubmitBatchStatusCodes(t *testing.T) {
+	logger := log.TestingLogger()
+
+	getBlocks := func(n int) []*types.Block {
+		blocks := make([]*types.Block, n)
+		for i := range blocks {
+			blocks[i] = &types.Block{}
+			blocks[i].SignedHeader.Header.BaseHeader.Height = uint64(i + 1)
+		}
+		return blocks
+	}
+
+	cases := []struct {
+		name        string
+		blocks      int
+		maxBlocks   int
+		codes       []da.StatusCode
+		err         error
+		submissions []int
+		bumps       int
+	}{
+		{"success", 3, 3, nil, nil, []int{3}, 0},
+		{"insufficient fee", 3, 3, []da.StatusCode{da.StatusInsufficientFee, da.StatusInsufficientFee}, nil, []int{3}, 2},
+		{"already included", 3, 3, []da.StatusCode{da.StatusAlreadyIncluded}, nil, nil, 0},
+		{"timeout", 3, 3, []da.StatusCode{da.StatusTimeout, da.StatusError}, nil, []int{3}, 0},
+		{"blob too big", 5, 2, nil, nil, []int{2, 1, 2}, 0},
+		{"single block too big", 1, 0, nil, errBlobTooBig, nil, 0},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			mockDALC := &mockda.DataAvailabilityLayerClient{}
+			daKV, _ := store.NewDefaultInMemoryKVStore()
+			require.NoError(mockDALC.Init(types.NamespaceID{}, nil, daKV, logger))
+			require.NoError(mockDALC.Start())
+			defer func() { _ = mockDALC.Stop() }()
+
+			dalc := &statusDALC{DataAvailabilityLayerClient: mockDALC, maxBlocks: c.maxBlocks, codes: c.codes}
+			kv, _ := store.NewDefaultInMemoryKVStore()
+			m := &Manager{
+				conf:      config.BlockManagerConfig{DABlockTime: 100 * time.Millisecond},
+				store:     store.New(context.Background(), kv),
+				dalc:      dalc,
+				statusMtx: new(sync.Mutex),
+				logger:    logger,
+			}
+
+			blocks := getBlocks(c.blocks)
+			err := m.submitBatch(context.Background(), blocks)
+			if c.err != nil {
+				assert.ErrorIs(err, c.err)
+				assert.Zero(atomic.LoadUint64(&m.daSubmittedHeight))
+				return
+			}
+			require.NoError(err)
+
+			var sizes []int
+			for _, submission := range dalc.submissions {
+				sizes = append(sizes, len(submission))
+			}
+			assert.Equal(c.submissions, sizes)
+			assert.Equal(c.bumps, dalc.bumps)
+			assert.Equal(uint64(c.blocks), atomic.LoadUint64(&m.daSubmittedHeight))
+			for h := uint64(1); h <= uint64(c.blocks); h++ {
+				assertFinality(t, m.store, h, types.FinalityDAIncluded)
+			}
+		})
+	}
+}
+
+func TestSubmissionLoopHaltsOnOversizedBlock(t *testing.T) {
+	require := require.New(t)
+	logger := log.TestingLogger()
+
+	mockDALC := &mockda.DataAvailabilityLayerClient{}
+	daKV, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(mockDALC.Init(types.NamespaceID{}, nil, daKV, logger))
+	require.NoError(mockDALC.Start())
+	defer func() { _ = mockDALC.Stop() }()
+
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	m := &Manager{
+		conf:      config.BlockManagerConfig{DABlockTime: 100 * time.Millisecond},
+		store:     store.New(context.Background(), kv),
+		dalc:      &statusDALC{DataAvailabilityLayerClient: mockDALC, maxBlocks: 0},
+		statusMtx: new(sync.Mutex),
+		batch:     newBlockBatch(1, 0, 0),
+		submitCh:  make(chan struct{}, 1),
+		logger:    logger,
+	}
+	block := &types.Block{}
+	block.SignedHeader.Header.BaseHeader.Height = 1
+	block.SignedHeader.Header.AggregatorsHash = make([]byte, 32)
+	require.NoError(m.store.SaveBlock(block, &types.Commit{}))
+	m.store.SetHeight(1)
+
+	// block that never fits into DA layer blob is not retried forever - node is halted
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go m.SubmissionLoop(ctx, cancel)
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("node wasn't halted")
+	}
+	require.Zero(atomic.LoadUint64(&m.daSubmittedHeight))
+}
+
 func getMockDALC(logger log.Logger) da.DataAvailabilityLayerClient {
 	dalc := &mockda.DataAvailabilityLayerClient{}
 	_ = dalc.Init([8]byte{}, nil, nil, logger)
@@ -108,3 +498,1128 @@ func getRandomValidatorSet() *tmtypes.ValidatorSet {
 		},
 	}
 }
+
+// testRetriever serves blocks from memory; heights after lastHeight are not available yet.
+type testRetriever struct {
+	blocks     map[uint64][]*types.Block
+	lastHeight uint64
+	calls      uint64
+}
+
+func (r *testRetriever) RetrieveBlocks(_ context.Context, daHeight uint64) da.ResultRetrieveBlocks {
+	atomic.AddUint64(&r.calls, 1)
+	// random delay, so concurrent requests complete out of order
+	time.Sleep(time.Duration(daHeight%5) * time.Millisecond)
+	if daHeight > r.lastHeight {
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusHeightFromFuture, Message: "block not found"}}
+	}
+	if len(r.blocks[daHeight]) == 0 {
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusNamespaceEmpty, DAHeight: daHeight}}
+	}
+	return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: daHeight}, Blocks: r.blocks[daHeight]}
+}
+
+// testRangeRetriever additionally supports range retrieval.
+type testRangeRetriever struct {
+	*testRetriever
+	rangeCalls uint64
+}
+
+func (r *testRangeRetriever) RetrieveBlocksRange(ctx context.Context, from, to uint64) []da.ResultRetrieveBlocks {
+	atomic.AddUint64(&r.rangeCalls, 1)
+	var results []da.ResultRetrieveBlocks
+	for h := from; h <= to; h++ {
+		res := r.RetrieveBlocks(ctx, h)
+		results = append(results, res)
+		if res.Code != da.StatusSuccess && res.Code != da.StatusNamespaceEmpty {
+			break
+		}
+	}
+	return results
+}
+
+func TestRetrievePrefetch(t *testing.T) {
+	const lastHeight = 50
+
+	blocks := make(map[uint64][]*types.Block)
+	var expected []uint64
+	for h := uint64(1); h <= lastHeight; h++ {
+		// some DA heights don't contain any blocks
+		for i := uint64(0); i < h%3; i++ {
+			block := &types.Block{}
+			block.SignedHeader.Header.BaseHeader.Height = uint64(len(expected) + 1)
+			blocks[h] = append(blocks[h], block)
+			expected = append(expected, h)
+		}
+	}
+
+	cases := []struct {
+		name      string
+		window    uint64
+		retriever func() da.BlockRetriever
+	}{
+		{"no prefetch", 0, func() da.BlockRetriever { return &testRetriever{blocks: blocks, lastHeight: lastHeight} }},
+		{"parallel", 8, func() da.BlockRetriever { return &testRetriever{blocks: blocks, lastHeight: lastHeight} }},
+		{"range", 8, func() da.BlockRetriever {
+			return &testRangeRetriever{testRetriever: &testRetriever{blocks: blocks, lastHeight: lastHeight}}
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			retriever := c.retriever()
+			m := &Manager{
+				conf:      config.BlockManagerConfig{DAPrefetchWindow: c.window},
+				retriever: retriever,
+				daHeight:  1,
+				blockInCh: make(chan newBlockEvent, len(expected)),
+				logger:    log.TestingLogger(),
+			}
+
+			var err error
+			for err == nil {
+				err = m.processNextDABlocks(context.Background())
+			}
+			assert.ErrorIs(err, errHeightFromFuture)
+			assert.Equal(uint64(lastHeight+1), atomic.LoadUint64(&m.daHeight))
+			if tr, ok := retriever.(*testRetriever); ok && c.window == 0 {
+				// height from the future is not retried
+				assert.Equal(uint64(lastHeight+2), atomic.LoadUint64(&tr.calls))
+			}
+
+			close(m.blockInCh)
+			var heights []uint64
+			var blockHeight uint64
+			for event := range m.blockInCh {
+				heights = append(heights, event.daHeight)
+				blockHeight++
+				assert.Equal(blockHeight, uint64(event.block.SignedHeader.Header.Height()))
+			}
+			assert.Equal(expected, heights)
+
+			if rr, ok := retriever.(*testRangeRetriever); ok {
+				assert.NotZero(rr.rangeCalls)
+			}
+		})
+	}
+}
+
+func TestRetrieveDAReorg(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+	logger := log.TestingLogger()
+
+	mockDALC := &mockda.DataAvailabilityLayerClient{}
+	daKV, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(mockDALC.Init(types.NamespaceID{}, []byte((10 * time.Millisecond).String()), daKV, logger))
+	require.NoError(mockDALC.Start())
+	defer func() { _ = mockDALC.Stop() }()
+
+	// every block is included at different DA height
+	blocks := make([]*types.Block, 3)
+	daHeights := make([]uint64, len(blocks))
+	for i := range blocks {
+		blocks[i] = &types.Block{}
+		blocks[i].SignedHeader.Header.BaseHeader.Height = uint64(i + 1)
+		blocks[i].SignedHeader.Header.AggregatorsHash = []byte{byte(i + 1)}
+		res := mockDALC.SubmitBlock(ctx, blocks[i])
+		require.Equal(da.StatusSuccess, res.Code, res.Message)
+		daHeights[i] = res.DAHeight
+		require.Eventually(func() bool {
+			return mockDALC.RetrieveBlocks(ctx, res.DAHeight).Code == da.StatusSuccess
+		}, time.Second, 5*time.Millisecond)
+	}
+
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	m := &Manager{
+		// nothing is confirmed until confirmation depth is lowered
+		conf:      config.BlockManagerConfig{DAConfirmationDepth: 1 << 20},
+		store:     store.New(ctx, kv),
+		retriever: mockDALC,
+		daHeight:  1,
+		blockInCh: make(chan newBlockEvent, 100),
+		logger:    logger,
+	}
+	reorgs := 0
+	retrieve := func() {
+		var err error
+		for err == nil || errors.Is(err, errDAReorg) {
+			err = m.processNextDABlocks(ctx)
+			if errors.Is(err, errDAReorg) {
+				reorgs++
+			}
+		}
+		require.ErrorIs(err, errHeightFromFuture)
+	}
+
+	retrieve()
+	assert.Empty(m.blockInCh)
+	assert.Greater(atomic.LoadUint64(&m.daHeight), daHeights[2])
+
+	// blocks 2 and 3 are moved to new DA height
+	require.NoError(mockDALC.Reorg(ctx, daHeights[1]))
+	m.conf.DAConfirmationDepth = 1
+
+	var events []newBlockEvent
+	require.Eventually(func() bool {
+		retrieve()
+		for len(m.blockInCh) > 0 {
+			events = append(events, <-m.blockInCh)
+		}
+		return len(events) >= len(blocks)
+	}, 2*time.Second, 10*time.Millisecond)
+
+	assert.Equal(1, reorgs)
+	require.Len(events, len(blocks))
+	assert.Equal(blocks[0].Hash(), events[0].block.Hash())
+	assert.Equal(daHeights[0], events[0].daHeight)
+	for i := 1; i < len(blocks); i++ {
+		assert.Equal(blocks[i].Hash(), events[i].block.Hash())
+		assert.Greater(events[i].daHeight, daHeights[2])
+	}
+}
+
+// partsRetriever serves parts of blobs split across DA heights, reassembled like by celestia DA layer client.
+type partsRetriever struct {
+	parts      map[uint64][][]byte
+	lastHeight uint64
+	assembler  *types.BlobAssembler
+}
+
+func (r *partsRetriever) RetrieveBlocks(_ context.Context, daHeight uint64) da.ResultRetrieveBlocks {
+	if daHeight > r.lastHeight {
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusHeightFromFuture, Message: "block not found"}}
+	}
+	var blocks []*types.Block
+	for _, part := range r.parts[daHeight] {
+		blob, err := r.assembler.Add(daHeight, part)
+		if err != nil || blob == nil {
+			continue
+		}
+		decoded, err := types.UnmarshalBlocks(blob)
+		if err != nil {
+			continue
+		}
+		blocks = append(blocks, decoded...)
+	}
+	return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: daHeight}, Blocks: blocks}
+}
+
+func TestRetrieveBlobAcrossDAHeights(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	block := &types.Block{Data: types.Data{Txs: types.Txs{make(types.Tx, 2000)}}}
+	block.SignedHeader.Header.BaseHeader.Height = 1
+	blob, err := block.MarshalBinary()
+	require.NoError(err)
+	parts, err := types.SplitBlob(blob, 1000)
+	require.NoError(err)
+	require.Len(parts, 3)
+
+	// every part is included at different DA height
+	retriever := &partsRetriever{parts: make(map[uint64][][]byte), lastHeight: 10, assembler: types.NewBlobAssembler(10)}
+	for i, part := range parts {
+		retriever.parts[uint64(i+1)] = [][]byte{part}
+	}
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	m := &Manager{
+		conf:      config.BlockManagerConfig{DAConfirmationDepth: 2},
+		store:     store.New(ctx, kv),
+		retriever: retriever,
+		daHeight:  1,
+		blockInCh: make(chan newBlockEvent, 10),
+		logger:    log.TestingLogger(),
+	}
+
+	// DA height of the last part is retrieved again for confirmation, with the same result
+	for err == nil {
+		err = m.processNextDABlocks(ctx)
+	}
+	require.ErrorIs(err, errHeightFromFuture)
+	require.Len(m.blockInCh, 1)
+	event := <-m.blockInCh
+	assert.Equal(uint64(len(parts)), event.daHeight)
+	assert.Equal(block.Hash(), event.block.Hash())
+}
+
+func TestRetrieveDAReorgOfAppliedBlocks(t *testing.T) {
+	ctx := context.Background()
+	blocks := make([]*types.Block, 4)
+	for i := range blocks {
+		blocks[i] = &types.Block{}
+		blocks[i].SignedHeader.Header.BaseHeader.Height = uint64(i + 1)
+		blocks[i].SignedHeader.Header.AggregatorsHash = []byte{byte(i + 1)}
+	}
+	conflicting := &types.Block{}
+	conflicting.SignedHeader.Header.BaseHeader.Height = 3
+	conflicting.SignedHeader.Header.AggregatorsHash = []byte{0xff}
+
+	cases := []struct {
+		name   string
+		reorg  map[uint64][]*types.Block
+		err    error
+		height uint64
+	}{
+		// applied blocks are still included, retrieval is rolled back to the first unconfirmed DA height
+		{"within confirmation depth", map[uint64][]*types.Block{3: {conflicting}}, errDAReorg, 3},
+		// block 2 was applied, it can't be reverted
+		{"past confirmation depth", map[uint64][]*types.Block{2: nil, 3: {conflicting}}, errDAInconsistency, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			retriever := &testRetriever{blocks: map[uint64][]*types.Block{}, lastHeight: 4}
+			for i, block := range blocks {
+				retriever.blocks[uint64(i+1)] = []*types.Block{block}
+			}
+			kv, _ := store.NewDefaultInMemoryKVStore()
+			m := &Manager{
+				conf:      config.BlockManagerConfig{DAConfirmationDepth: 2},
+				store:     store.New(ctx, kv),
+				retriever: retriever,
+				daHeight:  1,
+				blockInCh: make(chan newBlockEvent, 100),
+				statusMtx: new(sync.Mutex),
+				logger:    log.TestingLogger(),
+			}
+			var err error
+			for err == nil {
+				err = m.processNextDABlocks(ctx)
+			}
+			require.ErrorIs(err, errHeightFromFuture)
+
+			// blocks from confirmed DA heights are applied
+			require.Len(m.blockInCh, 2)
+			for len(m.blockInCh) > 0 {
+				event := <-m.blockInCh
+				m.saveDAInclusion(event.block, event.daHeight, nil, nil)
+				require.NoError(m.setDAIncludedHeight(uint64(event.block.SignedHeader.Header.Height())))
+			}
+
+			for daHeight, reorged := range c.reorg {
+				retriever.blocks[daHeight] = reorged
+			}
+			retriever.lastHeight = 5
+			err = m.processNextDABlocks(ctx)
+			assert.ErrorIs(err, c.err)
+			if c.height != 0 {
+				assert.Equal(c.height, atomic.LoadUint64(&m.daHeight))
+				assert.Empty(m.unconfirmed)
+			}
+		})
+	}
+}
+
+// sharedDALC allows multiple managers to use the same, already started mock DA layer via wrapping DA clients.
+type sharedDALC struct {
+	*mockda.DataAvailabilityLayerClient
+}
+
+func (sharedDALC) Init(types.NamespaceID, []byte, ds.Datastore, rollkitlog.Logger) error { return nil }
+func (sharedDALC) Start() error                                                          { return nil }
+func (sharedDALC) Stop() error                                                           { return nil }
+
+func newChaosDALC(t *testing.T, backend *mockda.DataAvailabilityLayerClient, conf chaos.Config) *chaos.DataAvailabilityLayerClient {
+	t.Helper()
+	dalc := chaos.NewDataAvailabilityLayerClient(func(string) da.DataAvailabilityLayerClient {
+		return sharedDALC{backend}
+	})
+	raw, err := json.Marshal(conf)
+	require.NoError(t, err)
+	require.NoError(t, dalc.Init(types.NamespaceID{}, raw, nil, log.TestingLogger()))
+	require.NoError(t, dalc.Start())
+	return dalc
+}
+
+func newTestManager(t *testing.T, key crypto.PrivKey, conf config.BlockManagerConfig, genesis *tmtypes.GenesisDoc, dalc da.DataAvailabilityLayerClient) *Manager {
+	t.Helper()
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	return newTestManagerWithKV(t, key, conf, genesis, dalc, kv)
+}
+
+// newTestManagerWithKV creates manager using given KV store, so node restarts can be simulated.
+func newTestManagerWithKV(t *testing.T, key crypto.PrivKey, conf config.BlockManagerConfig, genesis *tmtypes.GenesisDoc, dalc da.DataAvailabilityLayerClient, kv ds.TxnDatastore) *Manager {
+	t.Helper()
+	logger := log.TestingLogger()
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+	app.On("GetAppHash", mock.Anything).Return(abci.ResponseGetAppHash{})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(t, err)
+	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	m, err := NewManager(key, conf, genesis, store.New(context.Background(), kv), mpool, proxy.NewAppConnConsensus(client), dalc, nil, logger, make(chan struct{}))
+	require.NoError(t, err)
+	return m
+}
+
+func TestSyncWithFaultyDA(t *testing.T) {
+	const nBlocks = 10
+
+	cases := []struct {
+		name  string
+		conf  chaos.Config
+		depth uint64
+		fault func(f chaos.Faults) uint64
+	}{
+		{"latency", chaos.Config{Latency: 5 * time.Millisecond, LatencyJitter: 20 * time.Millisecond}, 0, nil},
+		{"errors", chaos.Config{ErrorRate: 0.3}, 0, func(f chaos.Faults) uint64 { return f.Errors }},
+		{"timeouts", chaos.Config{TimeoutRate: 0.3}, 0, func(f chaos.Faults) uint64 { return f.Timeouts }},
+		// retrieval with dropped blocks times out, and DA height is retrieved again
+		{"drops", chaos.Config{DropRate: 0.5}, 1, func(f chaos.Faults) uint64 { return f.Dropped }},
+		{"drops without confirmation", chaos.Config{DropRate: 0.5}, 0, func(f chaos.Faults) uint64 { return f.Dropped }},
+		{"duplicates", chaos.Config{DuplicateRate: 0.5}, 0, func(f chaos.Faults) uint64 { return f.Duplicated }},
+		{"reorder", chaos.Config{ReorderRate: 1}, 0, func(f chaos.Faults) uint64 { return f.Reordered }},
+		{"garbage", chaos.Config{GarbageRate: 0.5}, 0, func(f chaos.Faults) uint64 { return f.Garbage }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			mockDALC := &mockda.DataAvailabilityLayerClient{}
+			daKV, _ := store.NewDefaultInMemoryKVStore()
+			require.NoError(mockDALC.Init(types.NamespaceID{}, []byte((10 * time.Millisecond).String()), daKV, log.TestingLogger()))
+			require.NoError(mockDALC.Start())
+			defer func() { _ = mockDALC.Stop() }()
+
+			validatorKey := ed25519.GenPrivKey()
+			key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+			require.NoError(err)
+			genesis := &tmtypes.GenesisDoc{
+				ChainID:       "test",
+				InitialHeight: 1,
+				GenesisTime:   time.Now(),
+				Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+			}
+			conf := config.BlockManagerConfig{
+				BlockTime:           time.Second,
+				DABlockTime:         30 * time.Millisecond,
+				NamespaceID:         types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+				DAConfirmationDepth: c.depth,
+			}
+
+			aggDALC := newChaosDALC(t, mockDALC, c.conf)
+			aggregator := newTestManager(t, key, conf, genesis, aggDALC)
+			syncDALC := newChaosDALC(t, mockDALC, c.conf)
+			syncerKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+			syncer := newTestManager(t, syncerKey, conf, genesis, syncDALC)
+			go syncer.SyncLoop(ctx, cancel)
+			go syncer.RetrieveLoop(ctx, cancel)
+
+			// first half of blocks is submitted in a single batch, the rest in smaller batches
+			for i := 0; i < nBlocks; i++ {
+				if i == nBlocks/2 {
+					go aggregator.SubmissionLoop(ctx, cancel)
+				}
+				require.NoError(aggregator.publishBlock(ctx))
+				time.Sleep(10 * time.Millisecond)
+			}
+
+			require.Eventually(func() bool {
+				return syncer.store.Height() == nBlocks
+			}, 10*time.Second, 10*time.Millisecond, "synced %d of %d blocks", syncer.store.Height(), nBlocks)
+			assert.Zero(aggregator.pendingBlocks())
+			for h := uint64(1); h <= nBlocks; h++ {
+				expected, err := aggregator.store.LoadBlock(h)
+				require.NoError(err)
+				synced, err := syncer.store.LoadBlock(h)
+				require.NoError(err)
+				assert.Equal(expected.Hash(), synced.Hash())
+			}
+			if c.fault != nil {
+				assert.NotZero(c.fault(aggDALC.Faults()) + c.fault(syncDALC.Faults()))
+			}
+		})
+	}
+}
+
+func TestSyncVerifiesRetrievedBlocks(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:   time.Second,
+		DABlockTime: time.Second,
+		NamespaceID: types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+
+	aggregator := newTestManager(t, key, conf, genesis, getMockDALC(log.TestingLogger()))
+	syncerKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	syncer := newTestManager(t, syncerKey, conf, genesis, getMockDALC(log.TestingLogger()))
+	rejected := &reasonCounter{counts: make(map[string]int)}
+	conflicts := &reasonCounter{counts: make(map[string]int)}
+	syncer.SetMetrics(&Metrics{RejectedBlocks: rejected, ConflictingBlocks: conflicts})
+	headers := &testHeaderStore{headers: make(map[uint64]*types.SignedHeader)}
+	syncer.SetHeaderStore(headers)
+
+	for i := 0; i < 2; i++ {
+		require.NoError(aggregator.publishBlock(ctx))
+	}
+	b1, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+	b2, err := aggregator.store.LoadBlock(2)
+	require.NoError(err)
+
+	resign := func(block *types.Block, key crypto.PrivKey) {
+		headerBytes, err := block.SignedHeader.Header.MarshalBinary()
+		require.NoError(err)
+		sig, err := key.Sign(headerBytes)
+		require.NoError(err)
+		block.SignedHeader.Commit = types.Commit{Signatures: []types.Signature{sig}}
+	}
+
+	// invalid signature
+	forged, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+	forged.Data.Txs = types.Txs{[]byte("forged")}
+	forged.SignedHeader.DataHash = forged.Data.Hash()
+	resign(forged, syncerKey)
+
+	// valid signature, but signer is not the proposer
+	foreign, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+	foreignVal := getRandomValidatorSet()
+	foreignPriv := ed25519.GenPrivKey()
+	foreignVal.Proposer.PubKey = foreignPriv.PubKey()
+	foreignVal.Proposer.Address = foreignPriv.PubKey().Address()
+	foreignVal.Validators = []*tmtypes.Validator{foreignVal.Proposer}
+	foreign.SignedHeader.Validators = foreignVal
+	foreign.SignedHeader.AggregatorsHash = foreignVal.Hash()
+	foreign.SignedHeader.ProposerAddress = foreignVal.Proposer.Address
+	foreignKey, err := crypto.UnmarshalEd25519PrivateKey(foreignPriv.Bytes())
+	require.NoError(err)
+	resign(foreign, foreignKey)
+
+	// block signed by the proposer, conflicting with b2
+	conflicting, err := aggregator.store.LoadBlock(2)
+	require.NoError(err)
+	conflicting.SignedHeader.BaseHeader.Time = uint64(time.Now().Add(time.Hour).UnixNano())
+	resign(conflicting, key)
+
+	// unsigned based block, in rollup with proposer
+	based, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+	based.Data.Txs = types.Txs{[]byte("based")}
+	based.SignedHeader.DataHash = based.Data.Hash()
+	based.SignedHeader.ProposerAddress = nil
+	based.SignedHeader.Validators = nil
+	based.SignedHeader.Commit = types.Commit{}
+
+	go syncer.SyncLoop(ctx, cancel)
+	for _, b := range []*types.Block{forged, foreign, based, conflicting, b2, b2} {
+		syncer.blockInCh <- newBlockEvent{b, 1}
+	}
+	require.Eventually(func() bool {
+		return rejected.get(rejectInvalid) == 1 && rejected.get(rejectProposer) == 2 && conflicts.get("") == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Zero(syncer.store.Height())
+
+	// header synced from P2P network decides between candidates
+	headers.add(&b2.SignedHeader)
+	syncer.blockInCh <- newBlockEvent{b1, 1}
+	require.Eventually(func() bool {
+		return syncer.store.Height() == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(1, rejected.get(rejectHeaderMismatch))
+	synced, err := syncer.store.LoadBlock(2)
+	require.NoError(err)
+	assert.Equal(b2.Hash(), synced.Hash())
+}
+
+func TestSyncCacheEvictsInvalidCandidates(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:   time.Second,
+		DABlockTime: time.Second,
+		NamespaceID: types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+
+	aggregator := newTestManager(t, key, conf, genesis, getMockDALC(log.TestingLogger()))
+	syncerKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	syncer := newTestManager(t, syncerKey, conf, genesis, getMockDALC(log.TestingLogger()))
+	rejected := &reasonCounter{counts: make(map[string]int)}
+	syncer.SetMetrics(&Metrics{RejectedBlocks: rejected, ConflictingBlocks: discard.NewCounter()})
+
+	require.NoError(aggregator.publishBlock(ctx))
+	b1, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+
+	newBlock := func(key crypto.PrivKey, validators *tmtypes.ValidatorSet) *types.Block {
+		block, err := aggregator.store.LoadBlock(1)
+		require.NoError(err)
+		block.SignedHeader.AppHash = make([]byte, 32)
+		_, err = rand.Read(block.SignedHeader.AppHash)
+		require.NoError(err)
+		block.SignedHeader.Validators = validators
+		block.SignedHeader.AggregatorsHash = validators.Hash()
+		block.SignedHeader.ProposerAddress = validators.Proposer.Address
+		headerBytes, err := block.SignedHeader.Header.MarshalBinary()
+		require.NoError(err)
+		sig, err := key.Sign(headerBytes)
+		require.NoError(err)
+		block.SignedHeader.Commit = types.Commit{Signatures: []types.Signature{sig}}
+		return block
+	}
+
+	// blocks signed by keys from outside of validator set are rejected before they are cached
+	priv := ed25519.GenPrivKey()
+	foreignKey, err := crypto.UnmarshalEd25519PrivateKey(priv.Bytes())
+	require.NoError(err)
+	validators := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(priv.PubKey(), 1)})
+	reason, err := syncer.verifyBlock(ctx, newBlock(foreignKey, validators))
+	assert.ErrorIs(err, state.ErrNotProposer)
+	assert.Equal(rejectProposer, reason)
+
+	// cache full of blocks invalid for current state doesn't prevent the valid block from being applied
+	for i := 0; i < maxSyncCandidates; i++ {
+		syncer.addSyncCandidate(newBlock(key, b1.SignedHeader.Validators), 1)
+	}
+	assert.Len(syncer.syncCache[1], maxSyncCandidates)
+	go syncer.SyncLoop(ctx, cancel)
+	syncer.blockInCh <- newBlockEvent{b1, 1}
+	require.Eventually(func() bool {
+		return syncer.store.Height() == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Zero(rejected.get(rejectTooMany))
+	assert.Equal(maxSyncCandidates, rejected.get(rejectState))
+}
+
+// reasonCounter counts values added with different "reason" label values.
+type reasonCounter struct {
+	mtx    sync.Mutex
+	reason string
+	counts map[string]int
+	parent *reasonCounter
+}
+
+func (c *reasonCounter) With(labelValues ...string) metrics.Counter {
+	child := &reasonCounter{parent: c}
+	for i := 0; i+1 < len(labelValues); i += 2 {
+		if labelValues[i] == "reason" {
+			child.reason = labelValues[i+1]
+		}
+	}
+	return child
+}
+
+func (c *reasonCounter) Add(delta float64) {
+	root := c
+	if c.parent != nil {
+		root = c.parent
+	}
+	root.mtx.Lock()
+	defer root.mtx.Unlock()
+	root.counts[c.reason] += int(delta)
+}
+
+func (c *reasonCounter) get(reason string) int {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.counts[reason]
+}
+
+type testHeaderStore struct {
+	mtx     sync.Mutex
+	headers map[uint64]*types.SignedHeader
+}
+
+func (s *testHeaderStore) add(header *types.SignedHeader) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.headers[uint64(header.Height())] = header
+}
+
+func (s *testHeaderStore) HasAt(_ context.Context, height uint64) bool {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	_, ok := s.headers[height]
+	return ok
+}
+
+func (s *testHeaderStore) GetByHeight(_ context.Context, height uint64) (*types.SignedHeader, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	header, ok := s.headers[height]
+	if !ok {
+		return nil, errors.New("header not found")
+	}
+	return header, nil
+}
+
+func TestMigrateDataHash(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	logger := log.TestingLogger()
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	s := store.New(context.Background(), kv)
+
+	assert.Equal(uint64(0), migrateDataHash(s, types.State{}, 0, logger))
+	assert.Equal(uint64(7), migrateDataHash(s, types.State{}, 7, logger))
+
+	block := &types.Block{}
+	block.SignedHeader.Header.BaseHeader.Height = 5
+	block.SignedHeader.Header.AggregatorsHash = make([]byte, 32)
+	block.SignedHeader.Header.DataHash = block.Data.Hash()
+	require.NoError(s.SaveBlock(block, &types.Commit{}))
+	assert.Equal(uint64(0), migrateDataHash(s, types.State{LastBlockHeight: 5}, 0, logger))
+
+	// blocks stored before DataHash was introduced are accepted
+	block.SignedHeader.Header.DataHash = make(types.Hash, 32)
+	require.NoError(s.SaveBlock(block, &types.Commit{}))
+	assert.Equal(uint64(6), migrateDataHash(s, types.State{LastBlockHeight: 5}, 0, logger))
+	assert.Equal(uint64(6), migrateDataHash(s, types.State{LastBlockHeight: 5}, 3, logger))
+	assert.Equal(uint64(10), migrateDataHash(s, types.State{LastBlockHeight: 5}, 10, logger))
+}
+
+func TestBasedSequencing(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockDALC := &mockda.DataAvailabilityLayerClient{}
+	daKV, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(mockDALC.Init(types.NamespaceID{}, []byte((10 * time.Millisecond).String()), daKV, log.TestingLogger()))
+	require.NoError(mockDALC.Start())
+	defer func() { _ = mockDALC.Stop() }()
+
+	genesis := &tmtypes.GenesisDoc{ChainID: "test", InitialHeight: 1, GenesisTime: time.Now()}
+	conf := config.BlockManagerConfig{
+		DABlockTime: 20 * time.Millisecond,
+		NamespaceID: types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		Based:       true,
+	}
+
+	key1, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	m1 := newTestManager(t, key1, conf, genesis, sharedDALC{mockDALC})
+	go m1.BasedLoop(ctx)
+
+	batches := []types.Txs{{types.Tx("a"), types.Tx("b")}, {types.Tx("c")}, {types.Tx("d"), types.Tx("e"), types.Tx("f")}}
+	for _, txs := range batches {
+		res := mockDALC.SubmitTxs(ctx, txs)
+		require.Equal(da.StatusSuccess, res.Code, res.Message)
+		time.Sleep(30 * time.Millisecond)
+	}
+	require.Eventually(func() bool {
+		return m1.store.Height() == uint64(len(batches))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// node started later derives exactly the same blocks
+	key2, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	m2 := newTestManager(t, key2, conf, genesis, sharedDALC{mockDALC})
+	go m2.BasedLoop(ctx)
+	require.Eventually(func() bool {
+		return m2.store.Height() == uint64(len(batches))
+	}, 5*time.Second, 10*time.Millisecond)
+
+	for h := uint64(1); h <= uint64(len(batches)); h++ {
+		b1, err := m1.store.LoadBlock(h)
+		require.NoError(err)
+		b2, err := m2.store.LoadBlock(h)
+		require.NoError(err)
+		assert.Equal(b1.Hash(), b2.Hash())
+		assert.Equal(batches[h-1], b1.Data.Txs)
+		assert.True(b1.SignedHeader.Header.IsBased())
+		assert.NoError(b1.ValidateBasic())
+	}
+
+	// based sequencing requires chain without validators
+	validatorKey := ed25519.GenPrivKey()
+	genesis.Validators = []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}}
+	kv, _ := store.NewDefaultInMemoryKVStore()
+	_, err := NewManager(key1, conf, genesis, store.New(ctx, kv), nil, nil, sharedDALC{mockDALC}, nil, log.TestingLogger(), make(chan struct{}))
+	assert.Error(err)
+}
+
+func TestForcedInclusion(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockDALC := &mockda.DataAvailabilityLayerClient{}
+	daKV, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(mockDALC.Init(types.NamespaceID{}, []byte((10 * time.Millisecond).String()), daKV, log.TestingLogger()))
+	require.NoError(mockDALC.Start())
+	defer func() { _ = mockDALC.Stop() }()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:               time.Second,
+		DABlockTime:             20 * time.Millisecond,
+		NamespaceID:             types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		ForcedInclusionDeadline: 2,
+	}
+
+	// aggregator includes transactions posted to DA layer ahead of mempool transactions
+	aggregator := newTestManager(t, key, conf, genesis, sharedDALC{mockDALC})
+	go aggregator.RetrieveLoop(ctx, cancel)
+	go aggregator.SyncLoop(ctx, cancel)
+	forced := types.Txs{types.Tx("forced1"), types.Tx("forced2")}
+	res := mockDALC.SubmitTxs(ctx, forced)
+	require.Equal(da.StatusSuccess, res.Code, res.Message)
+	require.Eventually(func() bool {
+		return len(aggregator.pendingForcedTxs()) == len(forced)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(aggregator.publishBlock(ctx))
+	included, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+	assert.Equal(forced, included.Data.Txs)
+	assert.Empty(aggregator.pendingForcedTxs())
+
+	// block censoring queued transactions
+	censorConf := conf
+	censorConf.ForcedInclusionDeadline = 0
+	censor := newTestManager(t, key, censorConf, genesis, getMockDALC(log.TestingLogger()))
+	require.NoError(censor.publishBlock(ctx))
+	censored, err := censor.store.LoadBlock(1)
+	require.NoError(err)
+
+	syncerKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	syncerKV, _ := store.NewDefaultInMemoryKVStore()
+	syncer := newTestManagerWithKV(t, syncerKey, conf, genesis, getMockDALC(log.TestingLogger()), syncerKV)
+	require.NoError(syncer.forced.add(forced, 5))
+
+	// queue is restored after restart of any node
+	syncer = newTestManagerWithKV(t, syncerKey, conf, genesis, getMockDALC(log.TestingLogger()), syncerKV)
+	assert.Equal(forced, syncer.pendingForcedTxs())
+	rejected := &reasonCounter{counts: make(map[string]int)}
+	syncer.SetMetrics(&Metrics{RejectedBlocks: rejected, ConflictingBlocks: discard.NewCounter()})
+
+	// before the deadline, block doesn't have to include queued transactions
+	syncer.addSyncCandidate(censored, 7)
+	assert.NotNil(syncer.nextSyncCandidate(ctx, 1))
+	delete(syncer.syncCache, 1)
+
+	// after the deadline, block without queued transactions is rejected
+	syncer.addSyncCandidate(censored, 8)
+	assert.Nil(syncer.nextSyncCandidate(ctx, 1))
+	assert.Equal(1, rejected.get(rejectForced))
+
+	// block including queued transactions is applied, and transactions are removed from the queue
+	syncer.addSyncCandidate(included, 8)
+	require.NoError(syncer.trySyncNextBlock(ctx))
+	assert.Equal(uint64(1), syncer.store.Height())
+	assert.Empty(syncer.pendingForcedTxs())
+
+	// included transactions are not queued again, when DA height is retrieved again after restart
+	syncer = newTestManagerWithKV(t, syncerKey, conf, genesis, getMockDALC(log.TestingLogger()), syncerKV)
+	assert.Empty(syncer.pendingForcedTxs())
+	require.NoError(syncer.forced.add(forced, 5))
+	assert.Empty(syncer.pendingForcedTxs())
+}
+
+func TestForcedInclusionSlack(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:               time.Second,
+		NamespaceID:             types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		ForcedInclusionDeadline: 2,
+		ForcedInclusionSlack:    3,
+	}
+
+	// block is produced before the aggregator retrieved transactions posted to DA layer
+	aggregatorConf := conf
+	aggregatorConf.ForcedInclusionDeadline = 0
+	aggregator := newTestManager(t, key, aggregatorConf, genesis, getMockDALC(log.TestingLogger()))
+	require.NoError(aggregator.publishBlock(ctx))
+	block, err := aggregator.store.LoadBlock(1)
+	require.NoError(err)
+
+	syncerKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	syncer := newTestManager(t, syncerKey, conf, genesis, getMockDALC(log.TestingLogger()))
+	rejected := &reasonCounter{counts: make(map[string]int)}
+	syncer.SetMetrics(&Metrics{RejectedBlocks: rejected, ConflictingBlocks: discard.NewCounter()})
+	forced := types.Txs{types.Tx("forced")}
+	require.NoError(syncer.forced.add(forced, 5))
+
+	// block submitted after the deadline, but within the slack, is accepted
+	syncer.addSyncCandidate(block, 10)
+	assert.NotNil(syncer.nextSyncCandidate(ctx, 1))
+	delete(syncer.syncCache, 1)
+
+	// block submitted after the slack is rejected
+	syncer.addSyncCandidate(block, 11)
+	assert.Nil(syncer.nextSyncCandidate(ctx, 1))
+	assert.Equal(1, rejected.get(rejectForced))
+}
+
+func TestHotStandby(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockDALC := &mockda.DataAvailabilityLayerClient{}
+	daKV, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(mockDALC.Init(types.NamespaceID{}, []byte((10 * time.Millisecond).String()), daKV, log.TestingLogger()))
+	require.NoError(mockDALC.Start())
+	defer func() { _ = mockDALC.Stop() }()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:     time.Second,
+		DABlockTime:   20 * time.Millisecond,
+		NamespaceID:   types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		LeaseDuration: 200 * time.Millisecond,
+	}
+
+	// both aggregators use the same signing key
+	backend := lease.NewMemory()
+	primary := newTestManager(t, key, conf, genesis, sharedDALC{mockDALC})
+	primary.SetLease(backend, "primary")
+	standby := newTestManager(t, key, conf, genesis, sharedDALC{mockDALC})
+	standby.SetLease(backend, "standby")
+
+	require.NoError(primary.publishBlock(ctx))
+	require.NoError(primary.publishBlock(ctx))
+	require.Equal(uint64(2), primary.store.Height())
+	var blocks []*types.Block
+	for h := uint64(1); h <= 2; h++ {
+		block, err := primary.store.LoadBlock(h)
+		require.NoError(err)
+		blocks = append(blocks, block)
+	}
+	require.NoError(primary.submitBatch(ctx, blocks))
+
+	// standby doesn't produce blocks while primary holds the lease
+	require.NoError(standby.publishBlock(ctx))
+	assert.Equal(uint64(0), standby.store.Height())
+
+	// after primary stops renewing the lease, standby takes it over, but produces blocks only after syncing blocks
+	// signed by the primary
+	time.Sleep(conf.LeaseDuration)
+	require.NoError(standby.publishBlock(ctx))
+	assert.Equal(uint64(0), standby.store.Height())
+	go standby.RetrieveLoop(ctx, cancel)
+	go standby.SyncLoop(ctx, cancel)
+	require.Eventually(func() bool {
+		return standby.getLastState().LastBlockHeight == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(standby.publishBlock(ctx))
+	assert.Equal(uint64(3), standby.store.Height())
+
+	// primary can't produce conflicting block after losing the lease
+	require.NoError(primary.publishBlock(ctx))
+	assert.Equal(uint64(2), primary.store.Height())
+	l, err := backend.Acquire(ctx, "standby", conf.LeaseDuration)
+	require.NoError(err)
+	assert.Equal(uint64(3), l.Height)
+}
+
+// expiringLease runs afterSign once block at given height is recorded in the lease, before the block is published.
+type expiringLease struct {
+	lease.Backend
+	holder    string
+	height    uint64
+	afterSign func()
+}
+
+func (l *expiringLease) Sign(ctx context.Context, holder string, height uint64) error {
+	if err := l.Backend.Sign(ctx, holder, height); err != nil {
+		return err
+	}
+	if holder == l.holder && height == l.height {
+		l.afterSign()
+	}
+	return nil
+}
+
+func TestHotStandbyLeaseExpiry(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:     time.Second,
+		NamespaceID:   types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		LeaseDuration: 100 * time.Millisecond,
+	}
+
+	backend := &expiringLease{Backend: lease.NewMemory(), holder: "primary", height: 1}
+	primary := newTestManager(t, key, conf, genesis, getMockDALC(log.TestingLogger()))
+	primary.SetLease(backend, "primary")
+	standby := newTestManager(t, key, conf, genesis, getMockDALC(log.TestingLogger()))
+	standby.SetLease(backend, "standby")
+
+	// lease of the primary expires while block is produced; standby takes it over, but doesn't sign the same height
+	backend.afterSign = func() {
+		time.Sleep(conf.LeaseDuration)
+		require.NoError(standby.publishBlock(ctx))
+		assert.Equal(uint64(0), standby.store.Height())
+		assert.True(standby.leader)
+	}
+	require.NoError(primary.publishBlock(ctx))
+	assert.Equal(uint64(1), primary.store.Height())
+
+	l, err := backend.Acquire(ctx, "standby", conf.LeaseDuration)
+	require.NoError(err)
+	assert.Equal(uint64(1), l.Height)
+	assert.ErrorIs(backend.Sign(ctx, "primary", 2), lease.ErrNotHolder)
+}
+
+// crashingStore simulates crash of the node right after block is signed, before signed block is saved.
+type crashingStore struct {
+	store.Store
+}
+
+func (s *crashingStore) SaveBlock(block *types.Block, commit *types.Commit) error {
+	if len(commit.Signatures) > 0 {
+		return errors.New("crashed")
+	}
+	return s.Store.SaveBlock(block, commit)
+}
+
+func TestDoubleSignProtection(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	validatorKey := ed25519.GenPrivKey()
+	key, err := crypto.UnmarshalEd25519PrivateKey(validatorKey.Bytes())
+	require.NoError(err)
+	genesis := &tmtypes.GenesisDoc{
+		ChainID:       "test",
+		InitialHeight: 1,
+		GenesisTime:   time.Now(),
+		Validators:    []tmtypes.GenesisValidator{{PubKey: validatorKey.PubKey(), Power: 1}},
+	}
+	conf := config.BlockManagerConfig{
+		BlockTime:     time.Second,
+		NamespaceID:   types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		SignStateFile: filepath.Join(t.TempDir(), "sign_state.json"),
+	}
+	kv, _ := store.NewDefaultInMemoryKVStore()
+
+	// node crashes after signing the block, before it's committed
+	m := newTestManagerWithKV(t, key, conf, genesis, getMockDALC(log.TestingLogger()), kv)
+	block := m.executor.CreateBlock(1, &types.Commit{}, types.Hash{}, m.lastState, nil)
+	commit, err := m.getCommit(block.SignedHeader.Header)
+	require.NoError(err)
+	block.SignedHeader.Commit = *commit
+	block.SignedHeader.Validators = m.lastState.Validators
+	require.NoError(m.store.SaveBlock(block, commit))
+
+	// after restart, pending block is committed with the same signature
+	m = newTestManagerWithKV(t, key, conf, genesis, getMockDALC(log.TestingLogger()), kv)
+	require.NoError(m.publishBlock(ctx))
+	require.Equal(uint64(1), m.store.Height())
+	signed, err := m.store.LoadBlock(1)
+	require.NoError(err)
+	assert.Equal(block.SignedHeader.Header.Hash(), signed.SignedHeader.Header.Hash())
+	assert.Equal(*commit, signed.SignedHeader.Commit)
+
+	// after restart, next block is produced as usual
+	m = newTestManagerWithKV(t, key, conf, genesis, getMockDALC(log.TestingLogger()), kv)
+	require.NoError(m.publishBlock(ctx))
+	require.Equal(uint64(2), m.store.Height())
+
+	// node crashes after the header is signed by publishBlock, before signed block is saved
+	m = newTestManagerWithKV(t, key, conf, genesis, getMockDALC(log.TestingLogger()), kv)
+	m.store = &crashingStore{Store: m.store}
+	assert.Error(m.publishBlock(ctx))
+	signState := m.signer.State()
+	require.Equal(uint64(3), signState.Height)
+
+	// after restart, the same header is signed again, even though block time changed
+	time.Sleep(time.Millisecond)
+	m = newTestManagerWithKV(t, key, conf, genesis, getMockDALC(log.TestingLogger()), kv)
+	require.NoError(m.publishBlock(ctx))
+	require.Equal(uint64(3), m.store.Height())
+	signed3, err := m.store.LoadBlock(3)
+	require.NoError(err)
+	headerBytes, err := signed3.SignedHeader.Header.MarshalBinary()
+	require.NoError(err)
+	assert.Equal(signState.SignBytes, headerBytes)
+	assert.Equal(types.Signature(signState.Signature), signed3.SignedHeader.Commit.Signatures[0])
+
+	// if blocks are lost (for example, when store is wiped), rebuilt block can't be signed at the same height
+	emptyKV, _ := store.NewDefaultInMemoryKVStore()
+	m = newTestManagerWithKV(t, key, conf, genesis, getMockDALC(log.TestingLogger()), emptyKV)
+	err = m.publishBlock(ctx)
+	assert.ErrorIs(err, signer.ErrDoubleSign)
+	assert.Equal(uint64(0), m.store.Height())
+	// rebuilt block is saved only unsigned
+	unsigned, err := m.store.LoadBlock(1)
+	require.NoError(err)
+	assert.Empty(unsigned.SignedHeader.Commit.Signatures)
+	assert.ErrorIs(m.publishBlock(ctx), signer.ErrDoubleSign)
+	// header at lower height than the last signed one can't be signed
+	_, err = m.getCommit(signed.SignedHeader.Header)
+	assert.ErrorIs(err, signer.ErrDoubleSign)
+}
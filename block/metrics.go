@@ -0,0 +1,56 @@
+package block
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "block_manager"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Number of blocks retrieved from DA layer that were rejected, labeled with "reason".
+	RejectedBlocks metrics.Counter
+
+	// Number of blocks retrieved from DA layer conflicting with another candidate for the same height.
+	ConflictingBlocks metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		RejectedBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "rejected_blocks",
+			Help:      "Number of blocks retrieved from DA layer that were rejected.",
+		}, append(labels, "reason")).With(labelsAndValues...),
+
+		ConflictingBlocks: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "conflicting_blocks",
+			Help:      "Number of blocks retrieved from DA layer conflicting with another candidate for the same height.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		RejectedBlocks:    discard.NewCounter(),
+		ConflictingBlocks: discard.NewCounter(),
+	}
+}
@@ -0,0 +1,209 @@
+package block
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/rollkit/rollkit/state"
+	"github.com/rollkit/rollkit/types"
+)
+
+// maxSyncCandidates limits the number of conflicting blocks kept in sync cache for a single height.
+const maxSyncCandidates = 8
+
+// Reasons of rejection of blocks retrieved from DA layer, used as values of "reason" metric label.
+const (
+	rejectInvalid        = "invalid"
+	rejectProposer       = "proposer"
+	rejectHeaderMismatch = "header_mismatch"
+	rejectState          = "state"
+	rejectTooMany        = "too_many_candidates"
+	rejectForced         = "forced_inclusion"
+	rejectApply          = "apply"
+)
+
+var (
+	// errHeaderMismatch is returned when retrieved block doesn't match the header synced from P2P network.
+	errHeaderMismatch = errors.New("block doesn't match header synced from P2P network")
+	// errTooManyCandidates is returned when there are too many conflicting blocks for a single height.
+	errTooManyCandidates = errors.New("too many conflicting blocks for the same height")
+	// errApplyBlock is returned when block retrieved from DA layer passed validation, but can't be applied.
+	errApplyBlock = errors.New("failed to ApplyBlock")
+)
+
+// HeaderStore provides signed headers synced from P2P network by HeaderExchangeService.
+type HeaderStore interface {
+	// HasAt returns true if header at given height is available.
+	HasAt(ctx context.Context, height uint64) bool
+	// GetByHeight returns header at given height.
+	GetByHeight(ctx context.Context, height uint64) (*types.SignedHeader, error)
+}
+
+// SetHeaderStore sets store of headers synced from P2P network, used to verify blocks retrieved from DA layer.
+func (m *Manager) SetHeaderStore(headerStore HeaderStore) {
+	m.headerStore = headerStore
+}
+
+// SetMetrics sets metrics used by the manager. It has to be called before manager loops are started.
+func (m *Manager) SetMetrics(metrics *Metrics) {
+	m.metrics = metrics
+}
+
+// p2pHeader returns header synced from P2P network at given height, or nil if it's not available.
+func (m *Manager) p2pHeader(ctx context.Context, height uint64) *types.SignedHeader {
+	if m.headerStore == nil || !m.headerStore.HasAt(ctx, height) {
+		return nil
+	}
+	header, err := m.headerStore.GetByHeight(ctx, height)
+	if err != nil {
+		m.logger.Debug("failed to get header from header store", "height", height, "error", err)
+		return nil
+	}
+	return header
+}
+
+// verifyBlock checks that block retrieved from DA layer is valid, and that it matches the header synced from P2P
+// network, if it's available. Reason of rejection is returned along with the error.
+// Proposer rotates, so the proposer for block height is known only when block is applied. Before that, block has to
+// be signed by any of the validators, so blocks signed by unknown keys don't fill the sync cache.
+func (m *Manager) verifyBlock(ctx context.Context, block *types.Block) (string, error) {
+	// signature is verified against the proposer from validator set included in signed header
+	if err := block.ValidateBasic(); err != nil {
+		return rejectInvalid, err
+	}
+	if err := m.verifyValidator(block); err != nil {
+		return rejectProposer, err
+	}
+
+	header := m.p2pHeader(ctx, uint64(block.SignedHeader.Height()))
+	if header != nil && !bytes.Equal(header.Hash(), block.Hash()) {
+		return rejectHeaderMismatch, errHeaderMismatch
+	}
+	return "", nil
+}
+
+// verifyValidator checks that block is proposed and signed by one of the validators from the last state.
+// Unsigned based blocks are accepted only in based rollups, where there are no validators.
+func (m *Manager) verifyValidator(block *types.Block) error {
+	if m.conf.Based {
+		return nil
+	}
+	if block.SignedHeader.Header.IsBased() {
+		return fmt.Errorf("%w: based block in rollup with proposer", state.ErrNotProposer)
+	}
+	validators := m.getLastState().Validators
+	if validators == nil || validators.IsNilOrEmpty() {
+		return fmt.Errorf("%w: validator set is empty", state.ErrNotProposer)
+	}
+	_, validator := validators.GetByAddress(block.SignedHeader.ProposerAddress)
+	if validator == nil {
+		return fmt.Errorf("%w: %X is not a validator", state.ErrNotProposer, block.SignedHeader.ProposerAddress)
+	}
+	signer := block.SignedHeader.Validators.GetProposer()
+	if signer == nil || !signer.PubKey.Equals(validator.PubKey) {
+		return fmt.Errorf("%w: block signed by another validator", state.ErrNotProposer)
+	}
+	return nil
+}
+
+// syncCandidate is a block retrieved from DA layer, along with DA height it was retrieved from.
+type syncCandidate struct {
+	block    *types.Block
+	daHeight uint64
+}
+
+// addSyncCandidate adds block retrieved from DA layer to sync cache. Different blocks for the same height are kept
+// as separate candidates, in the order of retrieval.
+func (m *Manager) addSyncCandidate(block *types.Block, daHeight uint64) {
+	height := uint64(block.SignedHeader.Height())
+	candidates := m.syncCache[height]
+	for _, c := range candidates {
+		if bytes.Equal(c.block.Hash(), block.Hash()) && bytes.Equal(c.block.Data.Hash(), block.Data.Hash()) {
+			return
+		}
+	}
+	if len(candidates) >= maxSyncCandidates {
+		candidates = m.evictInvalidCandidates(height, candidates)
+	}
+	if len(candidates) >= maxSyncCandidates {
+		m.rejectBlock(block, rejectTooMany, errTooManyCandidates)
+		return
+	}
+	if len(candidates) > 0 {
+		m.logger.Info("conflicting block retrieved from DA layer", "height", height, "hash", block.Hash(),
+			"candidates", len(candidates)+1)
+		m.metrics.ConflictingBlocks.Add(1)
+	}
+	m.syncCache[height] = append(candidates, syncCandidate{block: block, daHeight: daHeight})
+}
+
+// evictInvalidCandidates removes candidates that are not valid for current state from sync cache. Only candidates for
+// the next height can be validated; candidates for other heights are returned unchanged.
+func (m *Manager) evictInvalidCandidates(height uint64, candidates []syncCandidate) []syncCandidate {
+	if height != uint64(m.getLastState().LastBlockHeight)+1 {
+		return candidates
+	}
+	valid := make([]syncCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if reason, err := m.validateState(c.block); err != nil {
+			m.rejectBlock(c.block, reason, err)
+			continue
+		}
+		valid = append(valid, c)
+	}
+	m.syncCache[height] = valid
+	return valid
+}
+
+// validateState checks that block can be applied on top of current state. Reason of rejection is returned along
+// with the error.
+func (m *Manager) validateState(block *types.Block) (string, error) {
+	err := m.executor.Validate(m.getLastState(), block)
+	if errors.Is(err, state.ErrNotProposer) {
+		return rejectProposer, err
+	}
+	if err != nil {
+		return rejectState, err
+	}
+	return "", nil
+}
+
+// nextSyncCandidate returns block from sync cache that can be applied at given height, or nil if there is none.
+// Candidates that don't match the header synced from P2P network, are not valid for current state or miss
+// transactions from forced inclusion queue are rejected and removed from sync cache.
+func (m *Manager) nextSyncCandidate(ctx context.Context, height uint64) *syncCandidate {
+	candidates := m.syncCache[height]
+	if len(candidates) == 0 {
+		return nil
+	}
+	// header could be synced after candidates were added
+	header := m.p2pHeader(ctx, height)
+	for len(candidates) > 0 {
+		c := candidates[0]
+		if header != nil && !bytes.Equal(header.Hash(), c.block.Hash()) {
+			m.rejectBlock(c.block, rejectHeaderMismatch, errHeaderMismatch)
+		} else if reason, err := m.validateState(c.block); err != nil {
+			m.rejectBlock(c.block, reason, err)
+		} else if err := m.checkForcedInclusion(c.block, c.daHeight); err != nil {
+			m.rejectBlock(c.block, rejectForced, err)
+		} else {
+			m.syncCache[height] = candidates
+			return &c
+		}
+		candidates = candidates[1:]
+	}
+	delete(m.syncCache, height)
+	return nil
+}
+
+func (m *Manager) rejectBlock(block *types.Block, reason string, err error) {
+	m.logger.Info("rejected block retrieved from DA layer",
+		"height", block.SignedHeader.Height(),
+		"hash", block.Hash(),
+		"reason", reason,
+		"error", err,
+	)
+	m.metrics.RejectedBlocks.With("reason", reason).Add(1)
+}
@@ -14,6 +14,7 @@ const (
 	flagAggregator     = "rollkit.aggregator"
 	flagDALayer        = "rollkit.da_layer"
 	flagDAConfig       = "rollkit.da_config"
+	flagDAPlugins      = "rollkit.da_plugins"
 	flagBlockTime      = "rollkit.block_time"
 	flagDABlockTime    = "rollkit.da_block_time"
 	flagDAStartHeight  = "rollkit.da_start_height"
@@ -22,6 +23,19 @@ const (
 	flagLight          = "rollkit.light"
 	flagTrustedHash    = "rollkit.trusted_hash"
 	flagLazyAggregator = "rollkit.lazy_aggregator"
+	flagDABatchSize    = "rollkit.da_batch_size"
+	flagDABatchBytes   = "rollkit.da_batch_bytes"
+	flagDABatchTimeout = "rollkit.da_batch_timeout"
+	flagMaxPending     = "rollkit.max_pending_blocks"
+	flagDAPrefetch     = "rollkit.da_prefetch_window"
+	flagDAConfirmation = "rollkit.da_confirmation_depth"
+	flagLegacyDataHash = "rollkit.legacy_data_hash_height"
+	flagBased          = "rollkit.based"
+	flagForcedDeadline = "rollkit.forced_inclusion_deadline"
+	flagForcedSlack    = "rollkit.forced_inclusion_slack"
+	flagLeaseBackend   = "rollkit.lease_backend"
+	flagLeaseDuration  = "rollkit.lease_duration"
+	flagSignStateFile  = "rollkit.sign_state_file"
 )
 
 // NodeConfig stores Rollkit node configuration.
@@ -31,14 +45,21 @@ type NodeConfig struct {
 	DBPath  string
 	P2P     P2PConfig
 	RPC     RPCConfig
+	// Instrumentation configures Prometheus metrics of the node
+	Instrumentation InstrumentationConfig
 	// parameters below are Rollkit specific and read from config
 	Aggregator         bool `mapstructure:"aggregator"`
 	BlockManagerConfig `mapstructure:",squash"`
 	DALayer            string `mapstructure:"da_layer"`
 	DAConfig           string `mapstructure:"da_config"`
-	Light              bool   `mapstructure:"light"`
-	HeaderConfig       `mapstructure:",squash"`
-	LazyAggregator     bool `mapstructure:"lazy_aggregator"`
+	// DAPlugins is a comma separated list of out-of-process DA clients, in name=command format.
+	DAPlugins      string `mapstructure:"da_plugins"`
+	Light          bool   `mapstructure:"light"`
+	HeaderConfig   `mapstructure:",squash"`
+	LazyAggregator bool `mapstructure:"lazy_aggregator"`
+	// LeaseBackend enables hot-standby aggregators sharing the same signing key; only the aggregator holding leader
+	// lease produces blocks. Backend is specified in "type:argument" format (for example "file:/mnt/shared/lease").
+	LeaseBackend string `mapstructure:"lease_backend"`
 }
 
 // HeaderConfig allows node to pass the initial trusted header hash to start the header exchange service
@@ -56,6 +77,45 @@ type BlockManagerConfig struct {
 	DAStartHeight uint64            `mapstructure:"da_start_height"`
 	NamespaceID   types.NamespaceID `mapstructure:"namespace_id"`
 	FraudProofs   bool              `mapstructure:"fraud_proofs"`
+	// DABatchSize is the maximum number of blocks posted to DA layer in a single submission.
+	// Values lower than 2 disable batching - every block is submitted separately.
+	DABatchSize uint64 `mapstructure:"da_batch_size"`
+	// DABatchBytes is the maximum total size of blocks in a single DA submission (0 means no limit).
+	DABatchBytes uint64 `mapstructure:"da_batch_bytes"`
+	// DABatchTimeout defines how long blocks can wait in a batch before the batch is submitted to DA layer.
+	DABatchTimeout time.Duration `mapstructure:"da_batch_timeout"`
+	// MaxPendingBlocks is the maximum number of produced blocks waiting for submission to DA layer.
+	// Block production is paused when this limit is reached (0 means no limit).
+	MaxPendingBlocks uint64 `mapstructure:"max_pending_blocks"`
+	// DAPrefetchWindow is the maximum number of DA heights retrieved concurrently while syncing.
+	// Values lower than 2 disable prefetching - DA heights are retrieved one by one.
+	DAPrefetchWindow uint64 `mapstructure:"da_prefetch_window"`
+	// DAConfirmationDepth is the number of DA heights that have to be built on top of DA height, before blocks
+	// retrieved from it are applied. Such DA height is checked for reorgs before blocks are applied (0 means that
+	// blocks are applied immediately, without checking for reorgs). Applied blocks can't be reverted, so node is halted
+	// if deeper reorg removes them from DA layer.
+	DAConfirmationDepth uint64 `mapstructure:"da_confirmation_depth"`
+	// LegacyDataHashHeight is the first height of a block that commits to block data with DataHash. Blocks created
+	// before, have DataHash set to zeros. It's required only for chains started before DataHash was introduced
+	// (0 means that all blocks have to commit to block data).
+	LegacyDataHashHeight uint64 `mapstructure:"legacy_data_hash_height"`
+	// Based enables based sequencing - blocks are derived from transactions posted directly to DA layer, in DA
+	// order, without an aggregator. All nodes of the chain have to use the same DAStartHeight.
+	Based bool `mapstructure:"based"`
+	// ForcedInclusionDeadline is the number of DA heights within which transactions posted directly to DA layer have
+	// to be included in a block by the aggregator. Blocks included in DA layer after the deadline, that don't contain
+	// such transactions, are rejected (0 disables forced inclusion).
+	ForcedInclusionDeadline uint64 `mapstructure:"forced_inclusion_deadline"`
+	// ForcedInclusionSlack is the number of DA heights added to ForcedInclusionDeadline when blocks are checked. Block
+	// could be produced before the aggregator retrieved the transactions (after its DAConfirmationDepth), and
+	// included in DA layer later, so slack has to cover both. It has to be the same for all nodes of the chain.
+	ForcedInclusionSlack uint64 `mapstructure:"forced_inclusion_slack"`
+	// LeaseDuration is the duration of leader lease, renewed by the aggregator every block. Standby aggregator takes
+	// over block production after the lease expires. It has to be longer than BlockTime.
+	LeaseDuration time.Duration `mapstructure:"lease_duration"`
+	// SignStateFile is the path of the file with the last-sign-state of the proposer key, protecting against double
+	// signing after restart (if empty, state is kept only in memory).
+	SignStateFile string `mapstructure:"sign_state_file"`
 }
 
 // GetViperConfig reads configuration parameters from Viper instance.
@@ -65,10 +125,24 @@ func (nc *NodeConfig) GetViperConfig(v *viper.Viper) error {
 	nc.Aggregator = v.GetBool(flagAggregator)
 	nc.DALayer = v.GetString(flagDALayer)
 	nc.DAConfig = v.GetString(flagDAConfig)
+	nc.DAPlugins = v.GetString(flagDAPlugins)
 	nc.DAStartHeight = v.GetUint64(flagDAStartHeight)
 	nc.DABlockTime = v.GetDuration(flagDABlockTime)
 	nc.BlockTime = v.GetDuration(flagBlockTime)
 	nc.LazyAggregator = v.GetBool(flagLazyAggregator)
+	nc.DABatchSize = v.GetUint64(flagDABatchSize)
+	nc.DABatchBytes = v.GetUint64(flagDABatchBytes)
+	nc.DABatchTimeout = v.GetDuration(flagDABatchTimeout)
+	nc.MaxPendingBlocks = v.GetUint64(flagMaxPending)
+	nc.DAPrefetchWindow = v.GetUint64(flagDAPrefetch)
+	nc.DAConfirmationDepth = v.GetUint64(flagDAConfirmation)
+	nc.LegacyDataHashHeight = v.GetUint64(flagLegacyDataHash)
+	nc.Based = v.GetBool(flagBased)
+	nc.ForcedInclusionDeadline = v.GetUint64(flagForcedDeadline)
+	nc.ForcedInclusionSlack = v.GetUint64(flagForcedSlack)
+	nc.LeaseBackend = v.GetString(flagLeaseBackend)
+	nc.LeaseDuration = v.GetDuration(flagLeaseDuration)
+	nc.SignStateFile = v.GetString(flagSignStateFile)
 	nsID := v.GetString(flagNamespaceID)
 	nc.FraudProofs = v.GetBool(flagFraudProofs)
 	nc.Light = v.GetBool(flagLight)
@@ -90,6 +164,7 @@ func AddFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(flagLazyAggregator, def.LazyAggregator, "wait for transactions, don't build empty blocks")
 	cmd.Flags().String(flagDALayer, def.DALayer, "Data Availability Layer Client name (mock or grpc")
 	cmd.Flags().String(flagDAConfig, def.DAConfig, "Data Availability Layer Client config")
+	cmd.Flags().String(flagDAPlugins, def.DAPlugins, "Data Availability Layer Client plugins, registered under given names (comma separated, name=command format)")
 	cmd.Flags().Duration(flagBlockTime, def.BlockTime, "block time (for aggregator mode)")
 	cmd.Flags().Duration(flagDABlockTime, def.DABlockTime, "DA chain block time (for syncing)")
 	cmd.Flags().Uint64(flagDAStartHeight, def.DAStartHeight, "starting DA block height (for syncing)")
@@ -97,4 +172,17 @@ func AddFlags(cmd *cobra.Command) {
 	cmd.Flags().Bool(flagFraudProofs, def.FraudProofs, "enable fraud proofs (experimental & insecure)")
 	cmd.Flags().Bool(flagLight, def.Light, "run light client")
 	cmd.Flags().String(flagTrustedHash, def.TrustedHash, "initial trusted hash to start the header exchange service")
+	cmd.Flags().Uint64(flagDABatchSize, def.DABatchSize, "maximum number of blocks submitted to DA layer at once (for aggregator mode)")
+	cmd.Flags().Uint64(flagDABatchBytes, def.DABatchBytes, "maximum size of blocks submitted to DA layer at once, 0 means no limit (for aggregator mode)")
+	cmd.Flags().Duration(flagDABatchTimeout, def.DABatchTimeout, "maximum time blocks can wait for batch submission to DA layer (for aggregator mode)")
+	cmd.Flags().Uint64(flagMaxPending, def.MaxPendingBlocks, "maximum number of blocks waiting for submission to DA layer, 0 means no limit (for aggregator mode)")
+	cmd.Flags().Uint64(flagDAPrefetch, def.DAPrefetchWindow, "maximum number of DA heights retrieved concurrently (for syncing)")
+	cmd.Flags().Uint64(flagDAConfirmation, def.DAConfirmationDepth, "number of DA blocks built on top of DA block, before rollup blocks are applied (for syncing)")
+	cmd.Flags().Uint64(flagLegacyDataHash, def.LegacyDataHashHeight, "first block height with DataHash committing to block data, only for chains started before DataHash was introduced")
+	cmd.Flags().Bool(flagBased, def.Based, "derive blocks from transactions posted directly to DA layer (based sequencing)")
+	cmd.Flags().Uint64(flagForcedDeadline, def.ForcedInclusionDeadline, "number of DA blocks within which transactions posted directly to DA layer have to be included, 0 disables forced inclusion")
+	cmd.Flags().Uint64(flagForcedSlack, def.ForcedInclusionSlack, "number of DA blocks added to forced inclusion deadline, covering DA confirmation depth of the aggregator and submission delay")
+	cmd.Flags().String(flagLeaseBackend, def.LeaseBackend, "leader lease backend for hot-standby aggregators, in type:argument format (for aggregator mode)")
+	cmd.Flags().Duration(flagLeaseDuration, def.LeaseDuration, "duration of leader lease, after which standby aggregator takes over (for aggregator mode)")
+	cmd.Flags().String(flagSignStateFile, def.SignStateFile, "path of the file with last-sign-state of the proposer key, defaults to file in data directory (for aggregator mode)")
 }
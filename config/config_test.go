@@ -24,9 +24,23 @@ func TestViperAndCobra(t *testing.T) {
 	assert.NoError(cmd.Flags().Set(flagAggregator, "true"))
 	assert.NoError(cmd.Flags().Set(flagDALayer, "foobar"))
 	assert.NoError(cmd.Flags().Set(flagDAConfig, `{"json":true}`))
+	assert.NoError(cmd.Flags().Set(flagDAPlugins, "foobar=/usr/bin/foobar --verbose"))
 	assert.NoError(cmd.Flags().Set(flagBlockTime, "1234s"))
 	assert.NoError(cmd.Flags().Set(flagNamespaceID, "0102030405060708"))
 	assert.NoError(cmd.Flags().Set(flagFraudProofs, "false"))
+	assert.NoError(cmd.Flags().Set(flagDABatchSize, "10"))
+	assert.NoError(cmd.Flags().Set(flagDABatchBytes, "2048"))
+	assert.NoError(cmd.Flags().Set(flagDABatchTimeout, "15s"))
+	assert.NoError(cmd.Flags().Set(flagMaxPending, "100"))
+	assert.NoError(cmd.Flags().Set(flagDAPrefetch, "16"))
+	assert.NoError(cmd.Flags().Set(flagDAConfirmation, "6"))
+	assert.NoError(cmd.Flags().Set(flagLegacyDataHash, "42"))
+	assert.NoError(cmd.Flags().Set(flagBased, "true"))
+	assert.NoError(cmd.Flags().Set(flagForcedDeadline, "12"))
+	assert.NoError(cmd.Flags().Set(flagForcedSlack, "4"))
+	assert.NoError(cmd.Flags().Set(flagLeaseBackend, "file:/tmp/lease"))
+	assert.NoError(cmd.Flags().Set(flagLeaseDuration, "15s"))
+	assert.NoError(cmd.Flags().Set(flagSignStateFile, "/tmp/sign_state.json"))
 
 	nc := DefaultNodeConfig
 	assert.NoError(nc.GetViperConfig(v))
@@ -34,7 +48,21 @@ func TestViperAndCobra(t *testing.T) {
 	assert.Equal(true, nc.Aggregator)
 	assert.Equal("foobar", nc.DALayer)
 	assert.Equal(`{"json":true}`, nc.DAConfig)
+	assert.Equal("foobar=/usr/bin/foobar --verbose", nc.DAPlugins)
 	assert.Equal(1234*time.Second, nc.BlockTime)
 	assert.Equal(types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8}, nc.NamespaceID)
 	assert.Equal(false, nc.FraudProofs)
+	assert.Equal(uint64(10), nc.DABatchSize)
+	assert.Equal(uint64(2048), nc.DABatchBytes)
+	assert.Equal(15*time.Second, nc.DABatchTimeout)
+	assert.Equal(uint64(100), nc.MaxPendingBlocks)
+	assert.Equal(uint64(16), nc.DAPrefetchWindow)
+	assert.Equal(uint64(6), nc.DAConfirmationDepth)
+	assert.Equal(uint64(42), nc.LegacyDataHashHeight)
+	assert.Equal(true, nc.Based)
+	assert.Equal(uint64(12), nc.ForcedInclusionDeadline)
+	assert.Equal(uint64(4), nc.ForcedInclusionSlack)
+	assert.Equal("file:/tmp/lease", nc.LeaseBackend)
+	assert.Equal(15*time.Second, nc.LeaseDuration)
+	assert.Equal("/tmp/sign_state.json", nc.SignStateFile)
 }
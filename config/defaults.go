@@ -21,9 +21,10 @@ var DefaultNodeConfig = NodeConfig{
 	Aggregator:     false,
 	LazyAggregator: false,
 	BlockManagerConfig: BlockManagerConfig{
-		BlockTime:   30 * time.Second,
-		NamespaceID: types.NamespaceID{},
-		FraudProofs: false,
+		BlockTime:            30 * time.Second,
+		NamespaceID:          types.NamespaceID{},
+		FraudProofs:          false,
+		ForcedInclusionSlack: 10,
 	},
 	DALayer:  "mock",
 	DAConfig: "",
@@ -2,11 +2,17 @@ package celestia
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
-	"github.com/gogo/protobuf/proto"
 	ds "github.com/ipfs/go-datastore"
 
 	"github.com/celestiaorg/go-cnc"
@@ -14,7 +20,6 @@ import (
 	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/log"
 	"github.com/rollkit/rollkit/types"
-	pb "github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
 // DataAvailabilityLayerClient use celestia-node public API.
@@ -23,30 +28,127 @@ type DataAvailabilityLayerClient struct {
 
 	namespaceID types.NamespaceID
 	config      Config
+	codec       types.CompressionCodec
 	logger      log.Logger
+	metrics     *Metrics
+
+	// assembler collects parts of blobs split across multiple PayForBlob transactions
+	assembler *types.BlobAssembler
+
+	// headerNamespaceID and dataNamespaceID are used instead of namespaceID, if signed headers and block data are
+	// posted separately
+	splitNamespaces   bool
+	headerNamespaceID types.NamespaceID
+	dataNamespaceID   types.NamespaceID
+	// pendingData keeps retrieved block data until it's joined with signed headers
+	pendingData *dataCache
+	// txsNamespaceID is used for transactions posted directly to DA layer
+	txsNamespaceID types.NamespaceID
+	// submittedData is block data posted to data namespace, for which headers were not posted yet
+	submittedData submittedData
+	// submittedParts tracks parts of split blob posted before submission of remaining parts failed
+	submittedParts submittedParts
+
+	feeMtx sync.Mutex
+	// feeMultiplier (in percents) is applied to base fee; it's increased by BumpFee and decreased after successful submissions
+	feeMultiplier int64
+	// lastBaseFee and lastFee are the fee of the last PayForBlob transaction, before and after applying feeMultiplier
+	lastBaseFee int64
+	lastFee     int64
 }
 
+// maxPendingBlobs is the number of incompletely retrieved blobs kept (and persisted).
+const maxPendingBlobs = 16
+
+const (
+	// feeMultiplierBase is the fee multiplier that leaves base fee unchanged.
+	feeMultiplierBase = 100
+	// feeBumpPercent is the fee increase applied by BumpFee.
+	feeBumpPercent = 50
+	// feeDecayPercent is the fee decrease applied after successful submission (down to base fee).
+	feeDecayPercent = 10
+)
+
+// Gas estimation parameters, matching celestia-app defaults.
+const (
+	// pfbGasFixedCost is the gas consumed by PayForBlob transaction, regardless of blob size.
+	pfbGasFixedCost = 75000
+	// gasPerBlobByte is the gas consumed per byte of shares occupied by blob.
+	gasPerBlobByte = 8
+	shareSize      = 512
+	// sharePayloadSize is the number of blob bytes stored in a single share.
+	sharePayloadSize = 478
+)
+
 var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
 var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.FeeBumper = &DataAvailabilityLayerClient{}
 
 // Config stores Celestia DALC configuration parameters.
 type Config struct {
-	BaseURL  string        `json:"base_url"`
-	Timeout  time.Duration `json:"timeout"`
-	Fee      int64         `json:"fee"`
-	GasLimit uint64        `json:"gas_limit"`
+	BaseURL string        `json:"base_url"`
+	Timeout time.Duration `json:"timeout"`
+	// Fee is the base fee of PayForBlob transaction. It's ignored if GasPrice is set.
+	Fee int64 `json:"fee"`
+	// GasPrice is the price of a single unit of gas. If set, base fee is computed from gas limit.
+	GasPrice float64 `json:"gas_price"`
+	// MaxFee caps the fee of a single PayForBlob transaction, when fee is increased by BumpFee. Zero means no limit.
+	MaxFee int64 `json:"max_fee"`
+	// GasLimit of PayForBlob transaction. Zero means that gas is estimated from blob size.
+	GasLimit uint64 `json:"gas_limit"`
+	// MaxBlobSize is the maximum size of a single blob. Larger blobs are split into parts,
+	// submitted in separate PayForBlob transactions. Zero means no limit.
+	MaxBlobSize int `json:"max_blob_size"`
+	// Compression is the name of codec used to compress blobs ("zstd" or "none"). Defaults to "zstd".
+	Compression string `json:"compression"`
+	// HeaderNamespaceID and DataNamespaceID (hex encoded) enable posting signed headers and block data to separate
+	// namespaces, so headers can be retrieved without block data. Both have to be set; namespace ID passed to Init is
+	// not used in this case.
+	HeaderNamespaceID string `json:"header_namespace_id"`
+	DataNamespaceID   string `json:"data_namespace_id"`
+	// DataLookback is the number of DA heights below header's DA height, searched for block data missing at header's
+	// DA height. Block data is posted before headers, so it can be included at lower DA height. Defaults to 8.
+	DataLookback uint64 `json:"data_lookback"`
+	// TxsNamespaceID (hex encoded) is the namespace of transactions posted directly to DA layer, like transactions
+	// in forced inclusion queue. Defaults to namespace ID passed to Init.
+	TxsNamespaceID string `json:"txs_namespace_id"`
 }
 
 // Init initializes DataAvailabilityLayerClient instance.
 func (c *DataAvailabilityLayerClient) Init(namespaceID types.NamespaceID, config []byte, kvStore ds.Datastore, logger log.Logger) error {
 	c.namespaceID = namespaceID
 	c.logger = logger
+	c.assembler = types.NewBlobAssembler(maxPendingBlobs)
+	if kvStore != nil {
+		// parts of blobs retrieved before restart are reassembled with parts retrieved after it
+		if err := c.assembler.SetStore(&partStore{kv: kvStore}); err != nil {
+			return fmt.Errorf("failed to restore blob parts: %w", err)
+		}
+	}
+	c.feeMultiplier = feeMultiplierBase
+	if c.metrics == nil {
+		c.metrics = NopMetrics()
+	}
 
 	if len(config) > 0 {
-		return json.Unmarshal(config, &c.config)
+		if err := json.Unmarshal(config, &c.config); err != nil {
+			return err
+		}
+	}
+	if c.config.Fee < 0 || c.config.GasPrice < 0 || c.config.MaxFee < 0 {
+		return fmt.Errorf("invalid fee configuration: fee %d, gas price %f, max fee %d", c.config.Fee, c.config.GasPrice, c.config.MaxFee)
+	}
+	if err := c.initNamespaces(); err != nil {
+		return err
 	}
 
-	return nil
+	if c.config.Compression == "" {
+		c.codec = types.CodecZstd
+		return nil
+	}
+	var err error
+	c.codec, err = types.ParseCompressionCodec(c.config.Compression)
+	return err
 }
 
 // Start prepares DataAvailabilityLayerClient to work.
@@ -63,8 +165,86 @@ func (c *DataAvailabilityLayerClient) Stop() error {
 	return nil
 }
 
+// SetMetrics sets metrics used by the client. It has to be called before Start.
+func (c *DataAvailabilityLayerClient) SetMetrics(metrics *Metrics) {
+	c.metrics = metrics
+}
+
+// BumpFee increases fee used for PayForBlob transactions by 50%, up to MaxFee.
+func (c *DataAvailabilityLayerClient) BumpFee() bool {
+	c.feeMtx.Lock()
+	defer c.feeMtx.Unlock()
+
+	multiplier := c.feeMultiplier * (100 + feeBumpPercent) / 100
+	fee := c.applyMultiplier(c.lastBaseFee, multiplier)
+	if fee <= c.lastFee {
+		c.logger.Info("can't bump PayForBlob fee", "fee", c.lastFee, "maxFee", c.config.MaxFee)
+		return false
+	}
+	c.feeMultiplier = multiplier
+	c.metrics.FeeBumps.Add(1)
+	c.logger.Info("bumped PayForBlob fee", "from", c.lastFee, "to", fee)
+	return true
+}
+
+// EstimateGas returns gas needed by PayForBlob transaction with blob of given size.
+func EstimateGas(blobSize int) uint64 {
+	shares := (blobSize + sharePayloadSize - 1) / sharePayloadSize
+	if shares == 0 {
+		shares = 1
+	}
+	return pfbGasFixedCost + uint64(shares)*shareSize*gasPerBlobByte
+}
+
+// txFee returns fee and gas limit for PayForBlob transaction with blob of given size.
+func (c *DataAvailabilityLayerClient) txFee(blobSize int) (int64, uint64) {
+	gasLimit := c.config.GasLimit
+	if gasLimit == 0 {
+		gasLimit = EstimateGas(blobSize)
+	}
+	baseFee := c.config.Fee
+	if c.config.GasPrice > 0 {
+		baseFee = int64(math.Ceil(c.config.GasPrice * float64(gasLimit)))
+	}
+
+	c.feeMtx.Lock()
+	defer c.feeMtx.Unlock()
+	c.lastBaseFee = baseFee
+	c.lastFee = c.applyMultiplier(baseFee, c.feeMultiplier)
+	return c.lastFee, gasLimit
+}
+
+// applyMultiplier returns base fee multiplied by multiplier (in percents), capped at MaxFee.
+func (c *DataAvailabilityLayerClient) applyMultiplier(baseFee int64, multiplier int64) int64 {
+	fee := math.Ceil(float64(baseFee) * float64(multiplier) / 100)
+	if c.config.MaxFee > 0 && fee > float64(c.config.MaxFee) {
+		return c.config.MaxFee
+	}
+	if fee >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(fee)
+}
+
+// feePaid records fee of successful PayForBlob transaction and lowers fee multiplier.
+func (c *DataAvailabilityLayerClient) feePaid(fee int64, gasLimit uint64) {
+	c.feeMtx.Lock()
+	c.feeMultiplier = c.feeMultiplier * (100 - feeDecayPercent) / 100
+	if c.feeMultiplier < feeMultiplierBase {
+		c.feeMultiplier = feeMultiplierBase
+	}
+	c.feeMtx.Unlock()
+
+	c.metrics.Fee.Set(float64(fee))
+	c.metrics.FeesPaid.Add(float64(fee))
+	c.metrics.GasLimit.Set(float64(gasLimit))
+}
+
 // SubmitBlock submits a block to DA layer.
 func (c *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	if c.splitNamespaces {
+		return c.submitSplit(ctx, []*types.Block{block})
+	}
 	blob, err := block.MarshalBinary()
 	if err != nil {
 		return da.ResultSubmitBlock{
@@ -75,8 +255,15 @@ func (c *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *ty
 		}
 	}
 
-	txResponse, err := c.client.SubmitPFB(ctx, c.namespaceID, blob, c.config.Fee, c.config.GasLimit)
+	return c.observeFee(c.submit(ctx, c.namespaceID, blob), 1)
+}
 
+// SubmitBlocks submits a batch of blocks to DA layer, using a single PayForBlob transaction.
+func (c *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	if c.splitNamespaces {
+		return c.submitSplit(ctx, blocks)
+	}
+	blob, err := types.MarshalBatch(blocks)
 	if err != nil {
 		return da.ResultSubmitBlock{
 			BaseResult: da.BaseResult{
@@ -86,39 +273,132 @@ func (c *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *ty
 		}
 	}
 
-	if txResponse.Code != 0 {
+	return c.observeFee(c.submit(ctx, c.namespaceID, blob), len(blocks))
+}
+
+// observeFee records fee per block of successful submission.
+func (c *DataAvailabilityLayerClient) observeFee(res da.ResultSubmitBlock, nBlocks int) da.ResultSubmitBlock {
+	if res.Code == da.StatusSuccess {
+		c.metrics.FeePerBlock.Observe(float64(res.Fee) / float64(nBlocks))
+	}
+	return res
+}
+
+// submit compresses and posts blob to Celestia. If the blob exceeds MaxBlobSize, it's split into parts, and every part is posted
+// in separate transaction. In this case, result of the last transaction is returned, as blob is available only
+// after all parts are included. Fee of all the transactions is reported in the result. If submission of a part fails,
+// only the missing parts are posted on retry.
+func (c *DataAvailabilityLayerClient) submit(ctx context.Context, namespaceID types.NamespaceID, blob []byte) da.ResultSubmitBlock {
+	blob, err := types.CompressBlob(blob, c.codec)
+	if err != nil {
+		return da.ResultSubmitBlock{
+			BaseResult: da.BaseResult{
+				Code:    da.StatusError,
+				Message: err.Error(),
+			},
+		}
+	}
+
+	if c.config.MaxBlobSize <= 0 || len(blob) <= c.config.MaxBlobSize {
+		return c.submitBlob(ctx, namespaceID, blob)
+	}
+
+	parts, err := types.SplitBlob(blob, c.config.MaxBlobSize)
+	if err != nil {
 		return da.ResultSubmitBlock{
 			BaseResult: da.BaseResult{
 				Code:    da.StatusError,
-				Message: fmt.Sprintf("Codespace: '%s', Code: %d, Message: %s", txResponse.Codespace, txResponse.Code, txResponse.RawLog),
+				Message: err.Error(),
+			},
+		}
+	}
+	// parts posted by previous (failed) submission of the same blob are not posted again
+	hash := sha256.Sum256(blob)
+	posted := c.submittedParts.get(namespaceID, hash)
+	c.logger.Debug("splitting blob", "size", len(blob), "parts", len(parts), "posted", posted)
+	var res da.ResultSubmitBlock
+	var fee uint64
+	for i := posted; i < len(parts); i++ {
+		res = c.submitBlob(ctx, namespaceID, parts[i])
+		fee += res.Fee
+		if res.Code != da.StatusSuccess {
+			res.Message = fmt.Sprintf("failed to submit part %d of %d: %s", i+1, len(parts), res.Message)
+			res.Fee = fee
+			return res
+		}
+		c.submittedParts.set(namespaceID, hash, i+1)
+	}
+	c.submittedParts.set(namespaceID, hash, 0)
+	res.Fee = fee
+	return res
+}
+
+func (c *DataAvailabilityLayerClient) submitBlob(ctx context.Context, namespaceID types.NamespaceID, blob []byte) da.ResultSubmitBlock {
+	fee, gasLimit := c.txFee(len(blob))
+	txResponse, err := c.client.SubmitPFB(ctx, namespaceID, blob, fee, gasLimit)
+
+	if err != nil {
+		return da.ResultSubmitBlock{
+			BaseResult: da.BaseResult{
+				Code:    errorCode(err),
+				Message: err.Error(),
+			},
+		}
+	}
+
+	if txResponse.Code != 0 {
+		return da.ResultSubmitBlock{
+			BaseResult: da.BaseResult{
+				Code:     txErrorCode(txResponse.Codespace, txResponse.Code),
+				Message:  fmt.Sprintf("Codespace: '%s', Code: %d, Message: %s", txResponse.Codespace, txResponse.Code, txResponse.RawLog),
+				DAHeight: uint64(txResponse.Height),
 			},
 		}
 	}
 
+	// commitment is the hash of PayForBlob transaction
+	txHash, err := hex.DecodeString(txResponse.TxHash)
+	if err != nil {
+		c.logger.Error("failed to decode tx hash", "txHash", txResponse.TxHash, "error", err)
+	}
+	c.feePaid(fee, gasLimit)
+
 	return da.ResultSubmitBlock{
 		BaseResult: da.BaseResult{
 			Code:     da.StatusSuccess,
 			Message:  "tx hash: " + txResponse.TxHash,
 			DAHeight: uint64(txResponse.Height),
 		},
+		Commitment: txHash,
+		Namespace:  namespaceID[:],
+		Fee:        uint64(fee),
 	}
 }
 
 // CheckBlockAvailability queries DA layer to check data availability of block at given height.
+// If headers and block data are posted separately, availability of headers is checked.
 func (c *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context, dataLayerHeight uint64) da.ResultCheckBlock {
-	shares, err := c.client.NamespacedShares(ctx, c.namespaceID, dataLayerHeight)
+	namespaceID := c.namespaceID
+	if c.splitNamespaces {
+		namespaceID = c.headerNamespaceID
+	}
+	shares, err := c.client.NamespacedShares(ctx, namespaceID, dataLayerHeight)
 	if err != nil {
 		return da.ResultCheckBlock{
 			BaseResult: da.BaseResult{
-				Code:    da.StatusError,
+				Code:    errorCode(err),
 				Message: err.Error(),
 			},
 		}
 	}
 
+	code := da.StatusSuccess
+	if len(shares) == 0 {
+		code = da.StatusNamespaceEmpty
+	}
 	return da.ResultCheckBlock{
 		BaseResult: da.BaseResult{
-			Code:     da.StatusSuccess,
+			Code:     code,
 			DAHeight: dataLayerHeight,
 		},
 		DataAvailable: len(shares) > 0,
@@ -127,34 +407,35 @@ func (c *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context
 
 // RetrieveBlocks gets a batch of blocks from DA layer.
 func (c *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveBlocks {
+	if c.splitNamespaces {
+		return c.retrieveSplit(ctx, dataLayerHeight)
+	}
 	data, err := c.client.NamespacedData(ctx, c.namespaceID, dataLayerHeight)
 	if err != nil {
 		return da.ResultRetrieveBlocks{
 			BaseResult: da.BaseResult{
-				Code:    da.StatusError,
+				Code:    errorCode(err),
 				Message: err.Error(),
 			},
 		}
 	}
+	if len(data) == 0 {
+		return da.ResultRetrieveBlocks{
+			BaseResult: da.BaseResult{
+				Code:     da.StatusNamespaceEmpty,
+				DAHeight: dataLayerHeight,
+			},
+		}
+	}
 
-	blocks := make([]*types.Block, len(data))
-	for i, msg := range data {
-		var block pb.Block
-		err = proto.Unmarshal(msg, &block)
+	var blocks []*types.Block
+	for i, msg := range c.decodeBlobs(dataLayerHeight, data, true) {
+		decoded, err := types.UnmarshalBlocks(msg)
 		if err != nil {
 			c.logger.Error("failed to unmarshal block", "daHeight", dataLayerHeight, "position", i, "error", err)
 			continue
 		}
-		blocks[i] = new(types.Block)
-		err := blocks[i].FromProto(&block)
-		if err != nil {
-			return da.ResultRetrieveBlocks{
-				BaseResult: da.BaseResult{
-					Code:    da.StatusError,
-					Message: err.Error(),
-				},
-			}
-		}
+		blocks = append(blocks, decoded...)
 	}
 
 	return da.ResultRetrieveBlocks{
@@ -165,3 +446,68 @@ func (c *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, dataLa
 		Blocks: blocks,
 	}
 }
+
+// decodeBlobs reassembles (if assemble is true) and decompresses retrieved blobs. Invalid blobs are skipped; parts
+// are skipped if assemble is false.
+func (c *DataAvailabilityLayerClient) decodeBlobs(dataLayerHeight uint64, data [][]byte, assemble bool) [][]byte {
+	var blobs [][]byte
+	for i, msg := range data {
+		var err error
+		if _, ok := types.DecodeBlockPart(msg); ok {
+			if !assemble {
+				continue
+			}
+			msg, err = c.assembler.Add(dataLayerHeight, msg)
+			if err != nil {
+				c.logger.Error("failed to add blob part", "daHeight", dataLayerHeight, "position", i, "error", err)
+				continue
+			}
+			if msg == nil {
+				// blob is incomplete, or it's returned at DA height of its last part
+				continue
+			}
+		}
+		msg, err = types.DecompressBlob(msg)
+		if err != nil {
+			c.logger.Error("failed to decompress blob", "daHeight", dataLayerHeight, "position", i, "error", err)
+			continue
+		}
+		blobs = append(blobs, msg)
+	}
+	return blobs
+}
+
+// Error codes returned by cosmos-sdk in transaction response (in "sdk" codespace).
+const (
+	sdkCodeInsufficientFee  = 13
+	sdkCodeTxInMempoolCache = 19
+	sdkCodeTxTooLarge       = 21
+)
+
+// txErrorCode translates failed transaction response into DA status code.
+func txErrorCode(codespace string, code uint32) da.StatusCode {
+	if codespace != "sdk" {
+		return da.StatusError
+	}
+	switch code {
+	case sdkCodeInsufficientFee:
+		return da.StatusInsufficientFee
+	case sdkCodeTxInMempoolCache:
+		// transaction is waiting in mempool, it's not known if (and where) it's going to be included
+		return da.StatusTimeout
+	case sdkCodeTxTooLarge:
+		return da.StatusBlobTooBig
+	}
+	return da.StatusError
+}
+
+// errorCode translates celestia-node API error into DA status code.
+func errorCode(err error) da.StatusCode {
+	if errors.Is(err, context.DeadlineExceeded) || os.IsTimeout(err) {
+		return da.StatusTimeout
+	}
+	if strings.Contains(err.Error(), "from the future") {
+		return da.StatusHeightFromFuture
+	}
+	return da.StatusError
+}
@@ -0,0 +1,90 @@
+package celestia
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "da_celestia"
+)
+
+// Metrics contains metrics exposed by this package.
+type Metrics struct {
+	// Fee of the last successful PayForBlob transaction.
+	Fee metrics.Gauge
+
+	// Total fee paid for PayForBlob transactions.
+	FeesPaid metrics.Counter
+
+	// Histogram of fee paid per submitted block.
+	FeePerBlock metrics.Histogram
+
+	// Gas limit of the last successful PayForBlob transaction.
+	GasLimit metrics.Gauge
+
+	// Number of fee bumps after submissions rejected because of insufficient fee.
+	FeeBumps metrics.Counter
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		Fee: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "fee",
+			Help:      "Fee of the last successful PayForBlob transaction.",
+		}, labels).With(labelsAndValues...),
+
+		FeesPaid: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "fees_paid",
+			Help:      "Total fee paid for PayForBlob transactions.",
+		}, labels).With(labelsAndValues...),
+
+		FeePerBlock: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "fee_per_block",
+			Help:      "Fee paid per submitted block.",
+			Buckets:   stdprometheus.ExponentialBuckets(100, 2, 16),
+		}, labels).With(labelsAndValues...),
+
+		GasLimit: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "gas_limit",
+			Help:      "Gas limit of the last successful PayForBlob transaction.",
+		}, labels).With(labelsAndValues...),
+
+		FeeBumps: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "fee_bumps",
+			Help:      "Number of fee bumps after submissions rejected because of insufficient fee.",
+		}, labels).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		Fee:         discard.NewGauge(),
+		FeesPaid:    discard.NewCounter(),
+		FeePerBlock: discard.NewHistogram(),
+		GasLimit:    discard.NewGauge(),
+		FeeBumps:    discard.NewCounter(),
+	}
+}
@@ -2,59 +2,109 @@ package mock
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"math/rand"
 	"net"
 	"net/http"
 	"strconv"
+	"sync"
 	"time"
 
 	mux2 "github.com/gorilla/mux"
 
 	"github.com/celestiaorg/go-cnc"
 
-	"github.com/rollkit/rollkit/da"
-	mockda "github.com/rollkit/rollkit/da/mock"
 	"github.com/rollkit/rollkit/log"
-	"github.com/rollkit/rollkit/store"
-	"github.com/rollkit/rollkit/types"
 )
 
+// ErrHeightFromFuture is returned when requested DA height is not produced yet.
+// Message matches the error returned by celestia-node.
+var ErrHeightFromFuture = errors.New("header: given height is from the future")
+
+// BlobStore stores blobs submitted to the Server.
+type BlobStore interface {
+	// Submit stores blob in namespace, at current DA height. DA height is returned.
+	Submit(ctx context.Context, namespace []byte, blob []byte) (uint64, error)
+	// Retrieve returns all blobs submitted to namespace at given DA height.
+	// ErrHeightFromFuture is returned if DA height was not produced yet.
+	Retrieve(ctx context.Context, namespace []byte, daHeight uint64) ([][]byte, error)
+}
+
 // Server mocks celestia-node HTTP API.
+//
+// By default, submitted blobs are stored in-memory, as-is. Mocked DA height is increased every blockTime, and blobs
+// submitted at given DA height are available for retrieval after DA height is increased.
 type Server struct {
-	mock      *mockda.DataAvailabilityLayerClient
-	blockTime time.Duration
-	server    *http.Server
-	logger    log.Logger
+	blockTime   time.Duration
+	maxBlobSize int
+	minGasPrice float64
+	server      *http.Server
+	logger      log.Logger
+
+	store BlobStore
+	// mem is nil if external BlobStore is used
+	mem  *memStore
+	quit chan struct{}
+}
+
+// Option configures the Server.
+type Option func(*Server)
+
+// WithMaxBlobSize makes the Server reject blobs larger than size.
+func WithMaxBlobSize(size int) Option {
+	return func(s *Server) {
+		s.maxBlobSize = size
+	}
+}
+
+// WithMinGasPrice makes the Server reject transactions with fee lower than gas limit multiplied by price.
+func WithMinGasPrice(price float64) Option {
+	return func(s *Server) {
+		s.minGasPrice = price
+	}
+}
+
+// WithBlobStore makes the Server use given store instead of in-memory one.
+// Server doesn't produce DA heights in this case - it's the responsibility of the store.
+func WithBlobStore(store BlobStore) Option {
+	return func(s *Server) {
+		s.store = store
+		s.mem = nil
+	}
 }
 
 // NewServer creates new instance of Server.
-func NewServer(blockTime time.Duration, logger log.Logger) *Server {
-	return &Server{
-		mock:      new(mockda.DataAvailabilityLayerClient),
+func NewServer(blockTime time.Duration, logger log.Logger, options ...Option) *Server {
+	mem := &memStore{
+		daHeight: 1,
+		blobs:    make(map[uint64]map[string][][]byte),
+	}
+	s := &Server{
 		blockTime: blockTime,
 		logger:    logger,
+		store:     mem,
+		mem:       mem,
+		quit:      make(chan struct{}),
+	}
+	for _, option := range options {
+		option(s)
 	}
+	return s
 }
 
 // Start starts HTTP server with given listener.
 func (s *Server) Start(listener net.Listener) error {
-	kvStore, err := store.NewDefaultInMemoryKVStore()
-	if err != nil {
-		return err
-	}
-	err = s.mock.Init([8]byte{}, []byte(s.blockTime.String()), kvStore, s.logger)
-	if err != nil {
-		return err
-	}
-	err = s.mock.Start()
-	if err != nil {
-		return err
+	if s.mem != nil {
+		go s.produceBlocks()
 	}
 	go func() {
 		s.server = new(http.Server)
-		s.server.Handler = s.getHandler()
+		s.server.Handler = s.Handler()
 		err := s.server.Serve(listener)
 		s.logger.Debug("http server exited with", "error", err)
 	}()
@@ -63,18 +113,37 @@ func (s *Server) Start(listener net.Listener) error {
 
 // Stop shuts down the Server.
 func (s *Server) Stop() {
+	close(s.quit)
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
 	defer cancel()
 	_ = s.server.Shutdown(ctx)
 }
 
-func (s *Server) getHandler() http.Handler {
+func (s *Server) produceBlocks() {
+	ticker := time.NewTicker(s.blockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			s.mem.produce()
+		}
+	}
+}
+
+// Handler returns HTTP handler serving celestia-node endpoints used by celestia DA client.
+func (s *Server) Handler() http.Handler {
 	mux := mux2.NewRouter()
+	s.RegisterRoutes(mux)
+	return mux
+}
+
+// RegisterRoutes registers celestia-node endpoints in the router.
+func (s *Server) RegisterRoutes(mux *mux2.Router) {
 	mux.HandleFunc("/submit_pfb", s.submit).Methods(http.MethodPost)
 	mux.HandleFunc("/namespaced_shares/{namespace}/height/{height}", s.shares).Methods(http.MethodGet)
 	mux.HandleFunc("/namespaced_data/{namespace}/height/{height}", s.data).Methods(http.MethodGet)
-
-	return mux
 }
 
 func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
@@ -85,29 +154,39 @@ func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	block := types.Block{}
-	blockData, err := hex.DecodeString(req.Data)
-	if err != nil {
-		s.writeError(w, err)
-		return
-	}
-	err = block.UnmarshalBinary(blockData)
+	blob, err := hex.DecodeString(req.Data)
 	if err != nil {
 		s.writeError(w, err)
 		return
 	}
 
-	res := s.mock.SubmitBlock(r.Context(), &block)
-	code := 0
-	if res.Code != da.StatusSuccess {
-		code = 3
+	txResponse := cnc.TxResponse{GasWanted: int64(req.GasLimit)}
+	requiredFee := int64(math.Ceil(s.minGasPrice * float64(req.GasLimit)))
+	if s.maxBlobSize > 0 && len(blob) > s.maxBlobSize {
+		txResponse.Codespace = "sdk"
+		txResponse.Code = 21 // ErrTxTooLarge
+		txResponse.RawLog = fmt.Sprintf("blob size %d exceeds maximum blob size %d", len(blob), s.maxBlobSize)
+	} else if req.Fee < requiredFee {
+		txResponse.Codespace = "sdk"
+		txResponse.Code = 13 // ErrInsufficientFee
+		txResponse.RawLog = fmt.Sprintf("insufficient fees; got: %d required: %d", req.Fee, requiredFee)
+	} else {
+		namespace, err := hex.DecodeString(req.NamespaceID)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		daHeight, err := s.store.Submit(r.Context(), namespace, blob)
+		if err != nil {
+			s.writeError(w, err)
+			return
+		}
+		hash := sha256.Sum256(blob)
+		txResponse.Height = int64(daHeight)
+		txResponse.TxHash = hex.EncodeToString(hash[:])
 	}
 
-	resp, err := json.Marshal(cnc.TxResponse{
-		Height: int64(res.DAHeight),
-		Code:   uint32(code),
-		RawLog: res.Message,
-	})
+	resp, err := json.Marshal(txResponse)
 	if err != nil {
 		s.writeError(w, err)
 		return
@@ -117,30 +196,26 @@ func (s *Server) submit(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) shares(w http.ResponseWriter, r *http.Request) {
-	height, err := parseHeight(r)
+	namespace, height, err := parseNamespaceAndHeight(r)
 	if err != nil {
 		s.writeError(w, err)
 		return
 	}
 
-	res := s.mock.RetrieveBlocks(r.Context(), height)
-	if res.Code != da.StatusSuccess {
-		s.writeError(w, errors.New(res.Message))
+	blobs, err := s.store.Retrieve(r.Context(), namespace, height)
+	if err != nil {
+		s.writeError(w, err)
 		return
 	}
 
 	var nShares []NamespacedShare
-	for _, block := range res.Blocks {
-		blob, err := block.MarshalBinary()
-		if err != nil {
-			s.writeError(w, err)
-			return
-		}
+	for _, blob := range blobs {
 		delimited, err := marshalDelimited(blob)
 		if err != nil {
 			s.writeError(w, err)
+			return
 		}
-		nShares = appendToShares(nShares, []byte{1, 2, 3, 4, 5, 6, 7, 8}, delimited)
+		nShares = appendToShares(nShares, namespace, delimited)
 	}
 	shares := make([]Share, len(nShares))
 	for i := range nShares {
@@ -149,7 +224,7 @@ func (s *Server) shares(w http.ResponseWriter, r *http.Request) {
 
 	resp, err := json.Marshal(namespacedSharesResponse{
 		Shares: shares,
-		Height: res.DAHeight,
+		Height: height,
 	})
 	if err != nil {
 		s.writeError(w, err)
@@ -160,30 +235,21 @@ func (s *Server) shares(w http.ResponseWriter, r *http.Request) {
 }
 
 func (s *Server) data(w http.ResponseWriter, r *http.Request) {
-	height, err := parseHeight(r)
+	namespace, height, err := parseNamespaceAndHeight(r)
 	if err != nil {
 		s.writeError(w, err)
 		return
 	}
 
-	res := s.mock.RetrieveBlocks(r.Context(), height)
-	if res.Code != da.StatusSuccess {
-		s.writeError(w, errors.New(res.Message))
+	data, err := s.store.Retrieve(r.Context(), namespace, height)
+	if err != nil {
+		s.writeError(w, err)
 		return
 	}
 
-	data := make([][]byte, len(res.Blocks))
-	for i := range res.Blocks {
-		data[i], err = res.Blocks[i].MarshalBinary()
-		if err != nil {
-			s.writeError(w, err)
-			return
-		}
-	}
-
 	resp, err := json.Marshal(namespacedDataResponse{
 		Data:   data,
-		Height: res.DAHeight,
+		Height: height,
 	})
 	if err != nil {
 		s.writeError(w, err)
@@ -193,14 +259,18 @@ func (s *Server) data(w http.ResponseWriter, r *http.Request) {
 	s.writeResponse(w, resp)
 }
 
-func parseHeight(r *http.Request) (uint64, error) {
+func parseNamespaceAndHeight(r *http.Request) ([]byte, uint64, error) {
 	vars := mux2.Vars(r)
 
+	namespace, err := hex.DecodeString(vars["namespace"])
+	if err != nil {
+		return nil, 0, err
+	}
 	height, err := strconv.ParseUint(vars["height"], 10, 64)
 	if err != nil {
-		return 0, err
+		return nil, 0, err
 	}
-	return height, nil
+	return namespace, height, nil
 }
 
 func (s *Server) writeResponse(w http.ResponseWriter, payload []byte) {
@@ -224,3 +294,35 @@ func (s *Server) writeError(w http.ResponseWriter, err error) {
 		s.logger.Error("failed to write response", "error", werr)
 	}
 }
+
+// memStore keeps blobs in memory. DA height is increased by random value in every produced block.
+type memStore struct {
+	mtx      sync.RWMutex
+	daHeight uint64
+	blobs    map[uint64]map[string][][]byte
+}
+
+func (m *memStore) produce() {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.daHeight += rand.Uint64()%10 + 1 //nolint:gosec
+}
+
+func (m *memStore) Submit(_ context.Context, namespace []byte, blob []byte) (uint64, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.blobs[m.daHeight] == nil {
+		m.blobs[m.daHeight] = make(map[string][][]byte)
+	}
+	m.blobs[m.daHeight][string(namespace)] = append(m.blobs[m.daHeight][string(namespace)], blob)
+	return m.daHeight, nil
+}
+
+func (m *memStore) Retrieve(_ context.Context, namespace []byte, daHeight uint64) ([][]byte, error) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	if daHeight >= m.daHeight {
+		return nil, ErrHeightFromFuture
+	}
+	return m.blobs[daHeight][string(namespace)], nil
+}
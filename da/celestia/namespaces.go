@@ -0,0 +1,268 @@
+package celestia
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+const (
+	// maxPendingData is the number of retrieved block data kept in memory, until they are joined with headers.
+	maxPendingData = 256
+	// defaultDataLookback is the default value of Config.DataLookback.
+	defaultDataLookback = 8
+)
+
+var _ da.HeaderRetriever = &DataAvailabilityLayerClient{}
+
+// initNamespaces parses namespace of transactions and separate namespaces for headers and block data, if configured.
+func (c *DataAvailabilityLayerClient) initNamespaces() error {
+	c.txsNamespaceID = c.namespaceID
+	if c.config.TxsNamespaceID != "" {
+		txs, err := parseNamespaceID(c.config.TxsNamespaceID)
+		if err != nil {
+			return fmt.Errorf("invalid transactions namespace ID: %w", err)
+		}
+		c.txsNamespaceID = txs
+	}
+	if c.config.HeaderNamespaceID == "" && c.config.DataNamespaceID == "" {
+		return nil
+	}
+	header, err := parseNamespaceID(c.config.HeaderNamespaceID)
+	if err != nil {
+		return fmt.Errorf("invalid header namespace ID: %w", err)
+	}
+	data, err := parseNamespaceID(c.config.DataNamespaceID)
+	if err != nil {
+		return fmt.Errorf("invalid data namespace ID: %w", err)
+	}
+	if header == data {
+		return errors.New("header and data namespace IDs have to be different")
+	}
+	c.splitNamespaces = true
+	c.headerNamespaceID = header
+	c.dataNamespaceID = data
+	c.pendingData = newDataCache(maxPendingData)
+	if c.config.DataLookback == 0 {
+		c.config.DataLookback = defaultDataLookback
+	}
+	return nil
+}
+
+func parseNamespaceID(s string) (types.NamespaceID, error) {
+	var nID types.NamespaceID
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nID, err
+	}
+	if len(b) != len(nID) {
+		return nID, fmt.Errorf("expected %d bytes, got %d", len(nID), len(b))
+	}
+	copy(nID[:], b)
+	return nID, nil
+}
+
+// submittedData remembers block data blob posted to data namespace, so it's not posted (and paid for) again when only
+// header submission fails.
+type submittedData struct {
+	mtx      sync.Mutex
+	hash     [sha256.Size]byte
+	daHeight uint64
+	posted   bool
+}
+
+// get returns DA height of block data blob with given hash, if it was already posted.
+func (s *submittedData) get(hash [sha256.Size]byte) (uint64, bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.daHeight, s.posted && s.hash == hash
+}
+
+func (s *submittedData) set(hash [sha256.Size]byte, daHeight uint64) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.hash, s.daHeight, s.posted = hash, daHeight, true
+}
+
+func (s *submittedData) reset() {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.posted = false
+}
+
+// submitSplit posts block data and signed headers of blocks to separate namespaces. Headers commit to block data
+// with DataHash. Block data is posted first, so it's available once headers are included. Result of header submission
+// is returned, with fee of both submissions.
+//
+// If block data was posted, but headers were not, only headers are posted on retry. Block data is posted again only
+// if headers are included more than DataLookback DA heights after block data, as it couldn't be found by full nodes.
+func (c *DataAvailabilityLayerClient) submitSplit(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	headers := make([]*types.SignedHeader, len(blocks))
+	data := make([]*types.Data, len(blocks))
+	for i := range blocks {
+		headers[i] = &blocks[i].SignedHeader
+		data[i] = &blocks[i].Data
+	}
+	dataBlob, err := types.MarshalDataBatch(data)
+	if err != nil {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	headerBlob, err := types.MarshalHeaders(headers)
+	if err != nil {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+
+	dataHash := sha256.Sum256(dataBlob)
+	dataHeight, posted := c.submittedData.get(dataHash)
+	var dataFee uint64
+	if !posted {
+		dataRes := c.submit(ctx, c.dataNamespaceID, dataBlob)
+		if dataRes.Code != da.StatusSuccess {
+			dataRes.Message = "failed to submit block data: " + dataRes.Message
+			return dataRes
+		}
+		c.submittedData.set(dataHash, dataRes.DAHeight)
+		dataHeight, dataFee = dataRes.DAHeight, dataRes.Fee
+	}
+	res := c.submit(ctx, c.headerNamespaceID, headerBlob)
+	res.Fee += dataFee
+	if res.Code != da.StatusSuccess {
+		res.Message = "failed to submit headers: " + res.Message
+		return c.observeFee(res, len(blocks))
+	}
+	c.submittedData.reset()
+	if res.DAHeight > dataHeight+c.config.DataLookback {
+		res.Code = da.StatusError
+		res.Message = fmt.Sprintf("headers included at DA height %d, too far from block data at DA height %d",
+			res.DAHeight, dataHeight)
+	}
+	return c.observeFee(res, len(blocks))
+}
+
+// RetrieveHeaders gets a batch of signed headers from DA layer, without block data.
+//
+// Headers are available only if headers and block data are posted to separate namespaces.
+func (c *DataAvailabilityLayerClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveHeaders {
+	if !c.splitNamespaces {
+		return da.ResultRetrieveHeaders{BaseResult: da.BaseResult{
+			Code:    da.StatusError,
+			Message: "headers are not posted separately from block data",
+		}}
+	}
+	data, err := c.client.NamespacedData(ctx, c.headerNamespaceID, dataLayerHeight)
+	if err != nil {
+		return da.ResultRetrieveHeaders{BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()}}
+	}
+	if len(data) == 0 {
+		return da.ResultRetrieveHeaders{BaseResult: da.BaseResult{Code: da.StatusNamespaceEmpty, DAHeight: dataLayerHeight}}
+	}
+
+	var headers []*types.SignedHeader
+	for i, msg := range c.decodeBlobs(dataLayerHeight, data, true) {
+		decoded, err := types.UnmarshalHeaders(msg)
+		if err != nil {
+			c.logger.Error("failed to unmarshal headers", "daHeight", dataLayerHeight, "position", i, "error", err)
+			continue
+		}
+		headers = append(headers, decoded...)
+	}
+	return da.ResultRetrieveHeaders{
+		BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: dataLayerHeight},
+		Headers:    headers,
+	}
+}
+
+// retrieveSplit retrieves signed headers and block data posted to separate namespaces, and joins them into blocks.
+// Headers without matching block data are skipped.
+func (c *DataAvailabilityLayerClient) retrieveSplit(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveBlocks {
+	// block data is cached even if there are no headers at this DA height yet
+	if err := c.retrieveData(ctx, dataLayerHeight, true); err != nil {
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()}}
+	}
+	headersRes := c.RetrieveHeaders(ctx, dataLayerHeight)
+	if headersRes.Code != da.StatusSuccess {
+		return da.ResultRetrieveBlocks{BaseResult: headersRes.BaseResult}
+	}
+
+	blocks := make([]*types.Block, 0, len(headersRes.Headers))
+	lookback := uint64(0)
+	for _, header := range headersRes.Headers {
+		data := c.pendingData.get(header.DataHash)
+		for data == nil && lookback < c.config.DataLookback && lookback+1 < dataLayerHeight {
+			lookback++
+			if err := c.retrieveData(ctx, dataLayerHeight-lookback, false); err != nil {
+				c.logger.Error("failed to retrieve block data", "daHeight", dataLayerHeight-lookback, "error", err)
+			}
+			data = c.pendingData.get(header.DataHash)
+		}
+		if data == nil {
+			c.logger.Error("block data not found", "daHeight", dataLayerHeight, "height", header.Height(),
+				"dataHash", header.DataHash)
+			continue
+		}
+		blocks = append(blocks, &types.Block{SignedHeader: *header, Data: *data})
+	}
+	return da.ResultRetrieveBlocks{
+		BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: dataLayerHeight},
+		Blocks:     blocks,
+	}
+}
+
+// retrieveData retrieves block data from data namespace into pending data cache. Blob parts are skipped if assemble
+// is false, so parts are not added to the assembler more than once.
+func (c *DataAvailabilityLayerClient) retrieveData(ctx context.Context, dataLayerHeight uint64, assemble bool) error {
+	data, err := c.client.NamespacedData(ctx, c.dataNamespaceID, dataLayerHeight)
+	if err != nil {
+		return err
+	}
+	for i, msg := range c.decodeBlobs(dataLayerHeight, data, assemble) {
+		decoded, err := types.UnmarshalDataBatch(msg)
+		if err != nil {
+			c.logger.Error("failed to unmarshal block data", "daHeight", dataLayerHeight, "position", i, "error", err)
+			continue
+		}
+		for _, d := range decoded {
+			c.pendingData.add(d)
+		}
+	}
+	return nil
+}
+
+// dataCache keeps limited number of block data, indexed by hash. The oldest entries are evicted first.
+type dataCache struct {
+	mtx   sync.Mutex
+	max   int
+	data  map[string]*types.Data
+	order []string
+}
+
+func newDataCache(max int) *dataCache {
+	return &dataCache{max: max, data: make(map[string]*types.Data)}
+}
+
+func (c *dataCache) add(data *types.Data) {
+	key := string(data.Hash())
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, ok := c.data[key]; ok {
+		return
+	}
+	c.data[key] = data
+	c.order = append(c.order, key)
+	if len(c.order) > c.max {
+		delete(c.data, c.order[0])
+		c.order = c.order[1:]
+	}
+}
+
+func (c *dataCache) get(hash types.Hash) *types.Data {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	return c.data[string(hash)]
+}
@@ -0,0 +1,94 @@
+package celestia
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	dsq "github.com/ipfs/go-datastore/query"
+
+	"github.com/rollkit/rollkit/types"
+)
+
+// partsPrefix is the prefix of keys of blob parts, persisted until the blob is reassembled.
+const partsPrefix = "/parts"
+
+// partStore persists parts of incomplete blobs in DA client key-value store.
+type partStore struct {
+	kv ds.Datastore
+}
+
+var _ types.PartStore = &partStore{}
+
+// SavePart implements types.PartStore.
+func (s *partStore) SavePart(key []byte, encoded []byte) error {
+	hash := sha256.Sum256(encoded)
+	return s.kv.Put(context.Background(), blobKey(key).ChildString(hex.EncodeToString(hash[:])), encoded)
+}
+
+// DeleteParts implements types.PartStore.
+func (s *partStore) DeleteParts(key []byte) error {
+	ctx := context.Background()
+	results, err := s.kv.Query(ctx, dsq.Query{Prefix: blobKey(key).String(), KeysOnly: true})
+	if err != nil {
+		return err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if err := s.kv.Delete(ctx, ds.NewKey(e.Key)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadParts implements types.PartStore.
+func (s *partStore) LoadParts() ([][]byte, error) {
+	results, err := s.kv.Query(context.Background(), dsq.Query{Prefix: partsPrefix})
+	if err != nil {
+		return nil, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	parts := make([][]byte, 0, len(entries))
+	for _, e := range entries {
+		parts = append(parts, e.Value)
+	}
+	return parts, nil
+}
+
+func blobKey(key []byte) ds.Key {
+	return ds.NewKey(partsPrefix).Child(ds.NewKey(string(key)))
+}
+
+// submittedParts remembers how many parts of split blob were posted, so only missing parts are posted (and paid for)
+// when submission of one of the parts fails.
+type submittedParts struct {
+	mtx         sync.Mutex
+	namespaceID types.NamespaceID
+	hash        [sha256.Size]byte
+	posted      int
+}
+
+// get returns the number of parts of blob with given hash, already posted to given namespace.
+func (s *submittedParts) get(namespaceID types.NamespaceID, hash [sha256.Size]byte) int {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.namespaceID != namespaceID || s.hash != hash {
+		return 0
+	}
+	return s.posted
+}
+
+func (s *submittedParts) set(namespaceID types.NamespaceID, hash [sha256.Size]byte, posted int) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.namespaceID, s.hash, s.posted = namespaceID, hash, posted
+}
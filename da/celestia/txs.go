@@ -0,0 +1,47 @@
+package celestia
+
+import (
+	"context"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+)
+
+var _ da.TxSubmitter = &DataAvailabilityLayerClient{}
+var _ da.TxRetriever = &DataAvailabilityLayerClient{}
+
+// SubmitTxs posts a batch of transactions to DA layer, for based sequencing or forced inclusion.
+func (c *DataAvailabilityLayerClient) SubmitTxs(ctx context.Context, txs types.Txs) da.ResultSubmitBlock {
+	blob, err := types.MarshalTxBatch(txs)
+	if err != nil {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	return c.submit(ctx, c.txsNamespaceID, blob)
+}
+
+// RetrieveTxs gets transactions posted directly to DA layer, in order of blobs in the namespace. Blobs that are not
+// batches of transactions are skipped.
+func (c *DataAvailabilityLayerClient) RetrieveTxs(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveTxs {
+	data, err := c.client.NamespacedData(ctx, c.txsNamespaceID, dataLayerHeight)
+	if err != nil {
+		return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()}}
+	}
+	if len(data) == 0 {
+		return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: da.StatusNamespaceEmpty, DAHeight: dataLayerHeight}}
+	}
+
+	var txs types.Txs
+	for i, msg := range c.decodeBlobs(dataLayerHeight, data, true) {
+		decoded, err := types.UnmarshalTxBatch(msg)
+		if err != nil {
+			c.logger.Debug("skipping blob that is not a batch of transactions", "daHeight", dataLayerHeight,
+				"position", i, "error", err)
+			continue
+		}
+		txs = append(txs, decoded...)
+	}
+	return da.ResultRetrieveTxs{
+		BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: dataLayerHeight},
+		Txs:        txs,
+	}
+}
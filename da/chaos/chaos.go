@@ -0,0 +1,429 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrSubscriptionNotSupported is returned when backend is not able to notify about new DA heights.
+var ErrSubscriptionNotSupported = errors.New("backend doesn't support DA height subscriptions")
+
+// Config stores chaos DA client configuration parameters.
+// All rates are probabilities (from 0 to 1).
+type Config struct {
+	// Backend is the DA client that requests are passed to.
+	Backend da.ClientConfig `json:"backend"`
+	// Seed initializes random number generator, so faults can be reproduced. Zero means random seed.
+	Seed int64 `json:"seed"`
+
+	// Latency is added to every request.
+	Latency time.Duration `json:"latency"`
+	// LatencyJitter is the upper bound of random delay added to every request on top of Latency.
+	LatencyJitter time.Duration `json:"latency_jitter"`
+	// ErrorRate is the probability that request fails with StatusError, without reaching the backend.
+	ErrorRate float64 `json:"error_rate"`
+	// TimeoutRate is the probability that request fails with StatusTimeout. Submissions are passed to the backend
+	// before timing out, so blocks may be included in DA layer even if submission failed.
+	TimeoutRate float64 `json:"timeout_rate"`
+
+	// DropRate is the probability that a block is missing from the first retrieval of DA height, as if DA node served
+	// the height before receiving all the data. Such retrieval fails with StatusTimeout, as DA node would time out
+	// waiting for the data. Subsequent retrievals of the same DA height return all blocks.
+	DropRate float64 `json:"drop_rate"`
+	// DuplicateRate is the probability that a retrieved block is returned twice.
+	DuplicateRate float64 `json:"duplicate_rate"`
+	// ReorderRate is the probability that blocks retrieved from DA height are shuffled.
+	ReorderRate float64 `json:"reorder_rate"`
+	// GarbageRate is the probability that a retrieved block is followed by a garbage block from a foreign sender.
+	// Garbage blocks impersonate retrieved blocks (they have the same height and chain ID), but are signed by a random
+	// key and contain random data.
+	GarbageRate float64 `json:"garbage_rate"`
+}
+
+// DefaultConfig defines default values for chaos DA client configuration.
+// By default, mock DA client is used as a backend and no faults are injected.
+var DefaultConfig = Config{
+	Backend: da.ClientConfig{Name: "mock"},
+}
+
+// Faults contains numbers of faults injected by the client.
+type Faults struct {
+	Errors     uint64
+	Timeouts   uint64
+	Dropped    uint64
+	Duplicated uint64
+	Reordered  uint64
+	Garbage    uint64
+}
+
+// DataAvailabilityLayerClient injects faults into requests to the backend DA client.
+//
+// Latency, errors and timeouts are random for every request. Duplicates, reordering and garbage are decided per DA
+// height (using configured seed), so every retrieval of the same DA height returns the same blocks in the same order.
+// Drops are transient - only the first retrieval of DA height is affected, and it times out.
+//
+// Client is intended only for testing resilience of rollup nodes.
+type DataAvailabilityLayerClient struct {
+	getClient da.GetClientFunc
+
+	config  Config
+	seed    int64
+	backend da.DataAvailabilityLayerClient
+	logger  log.Logger
+
+	mtx       sync.Mutex
+	rng       *rand.Rand
+	retrieved map[uint64]struct{}
+
+	faults Faults
+}
+
+var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
+var _ da.Wrapper = &DataAvailabilityLayerClient{}
+var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.BlockRangeRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeaderRetriever = &DataAvailabilityLayerClient{}
+var _ da.TxRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeightSubscriber = &DataAvailabilityLayerClient{}
+var _ da.FeeBumper = &DataAvailabilityLayerClient{}
+
+// NewDataAvailabilityLayerClient creates chaos DA client, that uses getClient to instantiate the backend.
+func NewDataAvailabilityLayerClient(getClient da.GetClientFunc) *DataAvailabilityLayerClient {
+	return &DataAvailabilityLayerClient{getClient: getClient}
+}
+
+// Init parses configuration and initializes the backend.
+func (c *DataAvailabilityLayerClient) Init(namespaceID types.NamespaceID, config []byte, kvStore ds.Datastore, logger log.Logger) error {
+	c.logger = logger
+	c.config = DefaultConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &c.config); err != nil {
+			return err
+		}
+	}
+	if err := c.config.validate(); err != nil {
+		return err
+	}
+
+	c.seed = c.config.Seed
+	if c.seed == 0 {
+		c.seed = time.Now().UnixNano()
+	}
+	c.rng = rand.New(rand.NewSource(c.seed)) //nolint:gosec
+	c.retrieved = make(map[uint64]struct{})
+
+	c.backend = c.getClient(c.config.Backend.Name)
+	if c.backend == nil {
+		return fmt.Errorf("couldn't get data availability client named '%s'", c.config.Backend.Name)
+	}
+	if err := c.backend.Init(namespaceID, c.config.Backend.InitConfig(), kvStore, logger); err != nil {
+		return fmt.Errorf("failed to initialize data availability client '%s': %w", c.config.Backend.Name, err)
+	}
+	return nil
+}
+
+func (conf Config) validate() error {
+	rates := map[string]float64{
+		"error":     conf.ErrorRate,
+		"timeout":   conf.TimeoutRate,
+		"drop":      conf.DropRate,
+		"duplicate": conf.DuplicateRate,
+		"reorder":   conf.ReorderRate,
+		"garbage":   conf.GarbageRate,
+	}
+	for name, rate := range rates {
+		if rate < 0 || rate > 1 {
+			return fmt.Errorf("invalid %s rate: %v", name, rate)
+		}
+	}
+	if conf.ErrorRate+conf.TimeoutRate > 1 {
+		return fmt.Errorf("sum of error and timeout rates exceeds 1: %v", conf.ErrorRate+conf.TimeoutRate)
+	}
+	if conf.Latency < 0 || conf.LatencyJitter < 0 {
+		return fmt.Errorf("invalid latency: %v (jitter: %v)", conf.Latency, conf.LatencyJitter)
+	}
+	return nil
+}
+
+// Unwrap returns the backend.
+func (c *DataAvailabilityLayerClient) Unwrap() []da.DataAvailabilityLayerClient {
+	return []da.DataAvailabilityLayerClient{c.backend}
+}
+
+// Start starts the backend.
+func (c *DataAvailabilityLayerClient) Start() error {
+	c.logger.Info("starting chaos Data Availability Layer Client", "backend", c.config.Backend.Name, "seed", c.seed)
+	return c.backend.Start()
+}
+
+// Stop stops the backend.
+func (c *DataAvailabilityLayerClient) Stop() error {
+	c.logger.Info("stopping chaos Data Availability Layer Client")
+	return c.backend.Stop()
+}
+
+// Faults returns numbers of faults injected so far.
+func (c *DataAvailabilityLayerClient) Faults() Faults {
+	return Faults{
+		Errors:     atomic.LoadUint64(&c.faults.Errors),
+		Timeouts:   atomic.LoadUint64(&c.faults.Timeouts),
+		Dropped:    atomic.LoadUint64(&c.faults.Dropped),
+		Duplicated: atomic.LoadUint64(&c.faults.Duplicated),
+		Reordered:  atomic.LoadUint64(&c.faults.Reordered),
+		Garbage:    atomic.LoadUint64(&c.faults.Garbage),
+	}
+}
+
+// BumpFee bumps fee of the backend, if it pays fees for submissions.
+func (c *DataAvailabilityLayerClient) BumpFee() bool {
+	if bumper, ok := c.backend.(da.FeeBumper); ok {
+		return bumper.BumpFee()
+	}
+	return false
+}
+
+// SubmitBlock submits the block to the backend.
+func (c *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	return c.submit(ctx, func() da.ResultSubmitBlock {
+		return c.backend.SubmitBlock(ctx, block)
+	})
+}
+
+// SubmitBlocks submits the blocks to the backend.
+func (c *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	return c.submit(ctx, func() da.ResultSubmitBlock {
+		return c.backend.SubmitBlocks(ctx, blocks)
+	})
+}
+
+func (c *DataAvailabilityLayerClient) submit(ctx context.Context, submit func() da.ResultSubmitBlock) da.ResultSubmitBlock {
+	code := c.fault(ctx)
+	if code == da.StatusError {
+		return da.ResultSubmitBlock{BaseResult: injected(code)}
+	}
+	res := submit()
+	if code == da.StatusTimeout {
+		// submission reached the backend, but response was lost
+		return da.ResultSubmitBlock{BaseResult: injected(code)}
+	}
+	return res
+}
+
+// CheckBlockAvailability checks data availability using the backend.
+func (c *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context, dataLayerHeight uint64) da.ResultCheckBlock {
+	if code := c.fault(ctx); code != da.StatusSuccess {
+		return da.ResultCheckBlock{BaseResult: injected(code)}
+	}
+	return c.backend.CheckBlockAvailability(ctx, dataLayerHeight)
+}
+
+// RetrieveBlocks retrieves blocks from the backend, and corrupts the result.
+func (c *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveBlocks {
+	retriever, ok := c.backend.(da.BlockRetriever)
+	if !ok {
+		return da.ResultRetrieveBlocks{BaseResult: da.NotSupported("block retrieval", c.config.Backend.Name)}
+	}
+	if code := c.fault(ctx); code != da.StatusSuccess {
+		return da.ResultRetrieveBlocks{BaseResult: injected(code)}
+	}
+	return c.corruptResult(dataLayerHeight, retriever.RetrieveBlocks(ctx, dataLayerHeight))
+}
+
+// RetrieveBlocksRange retrieves blocks from range of DA heights from the backend, and corrupts the results.
+func (c *DataAvailabilityLayerClient) RetrieveBlocksRange(ctx context.Context, from, to uint64) []da.ResultRetrieveBlocks {
+	retriever, ok := c.backend.(da.BlockRangeRetriever)
+	if !ok {
+		res := da.ResultRetrieveBlocks{BaseResult: da.NotSupported("range retrieval", c.config.Backend.Name)}
+		res.DAHeight = from
+		return []da.ResultRetrieveBlocks{res}
+	}
+	if code := c.fault(ctx); code != da.StatusSuccess {
+		res := da.ResultRetrieveBlocks{BaseResult: injected(code)}
+		res.DAHeight = from
+		return []da.ResultRetrieveBlocks{res}
+	}
+	results := retriever.RetrieveBlocksRange(ctx, from, to)
+	for i := range results {
+		results[i] = c.corruptResult(results[i].DAHeight, results[i])
+	}
+	return results
+}
+
+// corruptResult corrupts blocks retrieved successfully from DA height.
+func (c *DataAvailabilityLayerClient) corruptResult(daHeight uint64, res da.ResultRetrieveBlocks) da.ResultRetrieveBlocks {
+	if res.Code != da.StatusSuccess {
+		return res
+	}
+	res.Blocks = c.corrupt(daHeight, res.Blocks)
+	if c.firstRetrieval(daHeight) && c.drop(res.Blocks) {
+		res.Blocks = nil
+		res.BaseResult = injected(da.StatusTimeout)
+		res.DAHeight = daHeight
+		return res
+	}
+	if len(res.Blocks) == 0 {
+		res.Code = da.StatusNamespaceEmpty
+	}
+	return res
+}
+
+// RetrieveHeaders retrieves signed headers from the backend. Only latency, errors and timeouts are injected.
+func (c *DataAvailabilityLayerClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveHeaders {
+	retriever, ok := c.backend.(da.HeaderRetriever)
+	if !ok {
+		return da.ResultRetrieveHeaders{BaseResult: da.NotSupported("header retrieval", c.config.Backend.Name)}
+	}
+	if code := c.fault(ctx); code != da.StatusSuccess {
+		return da.ResultRetrieveHeaders{BaseResult: injected(code)}
+	}
+	return retriever.RetrieveHeaders(ctx, dataLayerHeight)
+}
+
+// RetrieveTxs retrieves transactions from the backend. Only latency, errors and timeouts are injected.
+func (c *DataAvailabilityLayerClient) RetrieveTxs(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveTxs {
+	retriever, ok := c.backend.(da.TxRetriever)
+	if !ok {
+		return da.ResultRetrieveTxs{BaseResult: da.NotSupported("transaction retrieval", c.config.Backend.Name)}
+	}
+	if code := c.fault(ctx); code != da.StatusSuccess {
+		return da.ResultRetrieveTxs{BaseResult: injected(code)}
+	}
+	return retriever.RetrieveTxs(ctx, dataLayerHeight)
+}
+
+// SubscribeHeights subscribes to new DA heights using the backend.
+func (c *DataAvailabilityLayerClient) SubscribeHeights(ctx context.Context) (<-chan uint64, error) {
+	subscriber, ok := c.backend.(da.HeightSubscriber)
+	if !ok {
+		return nil, ErrSubscriptionNotSupported
+	}
+	return subscriber.SubscribeHeights(ctx)
+}
+
+// fault delays the request and decides if it should fail.
+// StatusSuccess is returned if request should be passed to the backend without failing.
+func (c *DataAvailabilityLayerClient) fault(ctx context.Context) da.StatusCode {
+	c.mtx.Lock()
+	delay := c.config.Latency
+	if c.config.LatencyJitter > 0 {
+		delay += time.Duration(c.rng.Int63n(int64(c.config.LatencyJitter)))
+	}
+	p := c.rng.Float64()
+	c.mtx.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return da.StatusTimeout
+		}
+	}
+
+	switch {
+	case p < c.config.ErrorRate:
+		atomic.AddUint64(&c.faults.Errors, 1)
+		return da.StatusError
+	case p < c.config.ErrorRate+c.config.TimeoutRate:
+		atomic.AddUint64(&c.faults.Timeouts, 1)
+		return da.StatusTimeout
+	}
+	return da.StatusSuccess
+}
+
+// corrupt duplicates, reorders and adds garbage to blocks retrieved from DA height.
+// Random number generator is seeded with DA height, so result is the same for every retrieval.
+func (c *DataAvailabilityLayerClient) corrupt(daHeight uint64, blocks []*types.Block) []*types.Block {
+	rng := rand.New(rand.NewSource(c.seed + int64(daHeight))) //nolint:gosec
+	corrupted := make([]*types.Block, 0, len(blocks))
+	for _, block := range blocks {
+		corrupted = append(corrupted, block)
+		if rng.Float64() < c.config.DuplicateRate {
+			atomic.AddUint64(&c.faults.Duplicated, 1)
+			corrupted = append(corrupted, block)
+		}
+		if rng.Float64() < c.config.GarbageRate {
+			atomic.AddUint64(&c.faults.Garbage, 1)
+			corrupted = append(corrupted, garbageBlock(rng, block))
+		}
+	}
+	if len(corrupted) > 1 && rng.Float64() < c.config.ReorderRate {
+		atomic.AddUint64(&c.faults.Reordered, 1)
+		rng.Shuffle(len(corrupted), func(i, j int) {
+			corrupted[i], corrupted[j] = corrupted[j], corrupted[i]
+		})
+	}
+	return corrupted
+}
+
+// drop decides which blocks are missing from the retrieval. It returns true if any block is dropped.
+func (c *DataAvailabilityLayerClient) drop(blocks []*types.Block) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	dropped := false
+	for range blocks {
+		if c.rng.Float64() < c.config.DropRate {
+			atomic.AddUint64(&c.faults.Dropped, 1)
+			dropped = true
+		}
+	}
+	return dropped
+}
+
+// firstRetrieval returns true if DA height is retrieved for the first time.
+func (c *DataAvailabilityLayerClient) firstRetrieval(daHeight uint64) bool {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	if _, ok := c.retrieved[daHeight]; ok {
+		return false
+	}
+	c.retrieved[daHeight] = struct{}{}
+	return true
+}
+
+// garbageBlock creates a block impersonating given block, signed by a random key.
+// Such block is valid on its own, but it's not signed by the rollup proposer.
+func garbageBlock(rng *rand.Rand, block *types.Block) *types.Block {
+	secret := make([]byte, 32)
+	_, _ = rng.Read(secret)
+	key := ed25519.GenPrivKeyFromSecret(secret)
+	validators := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(key.PubKey(), 1)})
+
+	garbage := &types.Block{}
+	garbage.SignedHeader.Header = block.SignedHeader.Header
+	garbage.SignedHeader.Header.ProposerAddress = key.PubKey().Address()
+	garbage.SignedHeader.Header.AggregatorsHash = validators.Hash()
+	garbage.SignedHeader.Header.AppHash = make([]byte, 32)
+	_, _ = rng.Read(garbage.SignedHeader.Header.AppHash)
+	garbage.SignedHeader.Validators = validators
+
+	tx := make(types.Tx, 64)
+	_, _ = rng.Read(tx)
+	garbage.Data.Txs = types.Txs{tx}
+	garbage.SignedHeader.Header.DataHash = garbage.Data.Hash()
+
+	headerBytes, err := garbage.SignedHeader.Header.MarshalBinary()
+	if err == nil {
+		if sig, err := key.Sign(headerBytes); err == nil {
+			garbage.SignedHeader.Commit.Signatures = []types.Signature{sig}
+		}
+	}
+	return garbage
+}
+
+func injected(code da.StatusCode) da.BaseResult {
+	return da.BaseResult{Code: code, Message: "chaos: injected " + code.String()}
+}
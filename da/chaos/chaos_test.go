@@ -0,0 +1,180 @@
+package chaos
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/log/test"
+	"github.com/rollkit/rollkit/types"
+)
+
+// testBackend returns the same blocks for every DA height.
+type testBackend struct {
+	blocks   []*types.Block
+	requests uint64
+}
+
+func (t *testBackend) Init(types.NamespaceID, []byte, ds.Datastore, log.Logger) error { return nil }
+func (t *testBackend) Start() error                                                   { return nil }
+func (t *testBackend) Stop() error                                                    { return nil }
+
+func (t *testBackend) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	return t.SubmitBlocks(ctx, []*types.Block{block})
+}
+
+func (t *testBackend) SubmitBlocks(context.Context, []*types.Block) da.ResultSubmitBlock {
+	atomic.AddUint64(&t.requests, 1)
+	return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: 1}}
+}
+
+func (t *testBackend) CheckBlockAvailability(context.Context, uint64) da.ResultCheckBlock {
+	atomic.AddUint64(&t.requests, 1)
+	return da.ResultCheckBlock{BaseResult: da.BaseResult{Code: da.StatusSuccess}, DataAvailable: true}
+}
+
+func (t *testBackend) RetrieveBlocks(_ context.Context, daHeight uint64) da.ResultRetrieveBlocks {
+	atomic.AddUint64(&t.requests, 1)
+	return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: daHeight}, Blocks: t.blocks}
+}
+
+func newTestClient(t *testing.T, conf Config) (*DataAvailabilityLayerClient, *testBackend) {
+	t.Helper()
+	backend := &testBackend{}
+	for i := 1; i <= 10; i++ {
+		block := &types.Block{}
+		block.SignedHeader.Header.BaseHeader.Height = uint64(i)
+		block.SignedHeader.Header.BaseHeader.ChainID = "test"
+		backend.blocks = append(backend.blocks, block)
+	}
+	client := NewDataAvailabilityLayerClient(func(string) da.DataAvailabilityLayerClient { return backend })
+	conf.Backend = da.ClientConfig{Name: "test"}
+	raw, err := json.Marshal(conf)
+	require.NoError(t, err)
+	require.NoError(t, client.Init(types.NamespaceID{}, raw, nil, test.NewLogger(t)))
+	require.NoError(t, client.Start())
+	return client, backend
+}
+
+func TestRequestFaults(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	client, backend := newTestClient(t, Config{ErrorRate: 1})
+	assert.Equal(da.StatusError, client.SubmitBlock(ctx, &types.Block{}).Code)
+	assert.Equal(da.StatusError, client.CheckBlockAvailability(ctx, 1).Code)
+	assert.Equal(da.StatusError, client.RetrieveBlocks(ctx, 1).Code)
+	// errors are injected before reaching the backend
+	assert.Zero(atomic.LoadUint64(&backend.requests))
+	assert.Equal(uint64(3), client.Faults().Errors)
+
+	client, backend = newTestClient(t, Config{TimeoutRate: 1})
+	assert.Equal(da.StatusTimeout, client.SubmitBlocks(ctx, []*types.Block{{}}).Code)
+	// submission reached the backend before timing out
+	assert.Equal(uint64(1), atomic.LoadUint64(&backend.requests))
+	assert.Equal(da.StatusTimeout, client.RetrieveBlocks(ctx, 1).Code)
+	assert.Equal(uint64(2), client.Faults().Timeouts)
+
+	client, _ = newTestClient(t, Config{Latency: 50 * time.Millisecond, LatencyJitter: 10 * time.Millisecond})
+	start := time.Now()
+	assert.Equal(da.StatusSuccess, client.SubmitBlock(ctx, &types.Block{}).Code)
+	assert.GreaterOrEqual(time.Since(start), 50*time.Millisecond)
+
+	// cancelled request times out without waiting
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	client, _ = newTestClient(t, Config{Latency: time.Hour})
+	assert.Equal(da.StatusTimeout, client.RetrieveBlocks(cancelled, 1).Code)
+}
+
+func TestRetrievalFaults(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("drop", func(t *testing.T) {
+		assert := assert.New(t)
+		client, backend := newTestClient(t, Config{DropRate: 1})
+
+		// retrieval with missing blocks times out
+		res := client.RetrieveBlocks(ctx, 1)
+		assert.Equal(da.StatusTimeout, res.Code)
+		assert.Empty(res.Blocks)
+		assert.Equal(uint64(len(backend.blocks)), client.Faults().Dropped)
+
+		// only the first retrieval is affected
+		res = client.RetrieveBlocks(ctx, 1)
+		assert.Equal(da.StatusSuccess, res.Code)
+		assert.Equal(backend.blocks, res.Blocks)
+	})
+
+	t.Run("duplicate", func(t *testing.T) {
+		assert := assert.New(t)
+		client, backend := newTestClient(t, Config{DuplicateRate: 1})
+
+		res := client.RetrieveBlocks(ctx, 1)
+		assert.Equal(da.StatusSuccess, res.Code)
+		require.Len(t, res.Blocks, 2*len(backend.blocks))
+		for i, block := range backend.blocks {
+			assert.Equal(block, res.Blocks[2*i])
+			assert.Equal(block, res.Blocks[2*i+1])
+		}
+	})
+
+	t.Run("reorder", func(t *testing.T) {
+		assert := assert.New(t)
+		client, backend := newTestClient(t, Config{ReorderRate: 1, Seed: 42})
+
+		res := client.RetrieveBlocks(ctx, 1)
+		assert.Equal(da.StatusSuccess, res.Code)
+		assert.ElementsMatch(backend.blocks, res.Blocks)
+		assert.NotEqual(backend.blocks, res.Blocks)
+
+		// order is the same for every retrieval of DA height
+		assert.Equal(res.Blocks, client.RetrieveBlocks(ctx, 1).Blocks)
+		assert.Equal(uint64(2), client.Faults().Reordered)
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		assert := assert.New(t)
+		client, backend := newTestClient(t, Config{GarbageRate: 1})
+
+		res := client.RetrieveBlocks(ctx, 1)
+		assert.Equal(da.StatusSuccess, res.Code)
+		require.Len(t, res.Blocks, 2*len(backend.blocks))
+		for i, block := range backend.blocks {
+			assert.Equal(block, res.Blocks[2*i])
+			garbage := res.Blocks[2*i+1]
+			assert.Equal(block.SignedHeader.Header.Height(), garbage.SignedHeader.Header.Height())
+			assert.Equal(block.SignedHeader.Header.ChainID(), garbage.SignedHeader.Header.ChainID())
+			assert.NotEqual(block.SignedHeader.Header.ProposerAddress, garbage.SignedHeader.Header.ProposerAddress)
+			// garbage is correctly signed by foreign sender
+			assert.NoError(garbage.ValidateBasic())
+		}
+		assert.Equal(res.Blocks, client.RetrieveBlocks(ctx, 1).Blocks)
+	})
+}
+
+func TestInvalidConfig(t *testing.T) {
+	configs := []Config{
+		{ErrorRate: -0.1},
+		{DropRate: 1.5},
+		{ErrorRate: 0.6, TimeoutRate: 0.6},
+		{Latency: -time.Second},
+	}
+	for _, conf := range configs {
+		client := NewDataAvailabilityLayerClient(func(string) da.DataAvailabilityLayerClient { return &testBackend{} })
+		raw, err := json.Marshal(conf)
+		require.NoError(t, err)
+		assert.Error(t, client.Init(types.NamespaceID{}, raw, nil, test.NewLogger(t)))
+	}
+
+	client := NewDataAvailabilityLayerClient(func(string) da.DataAvailabilityLayerClient { return nil })
+	assert.Error(t, client.Init(types.NamespaceID{}, nil, nil, test.NewLogger(t)))
+}
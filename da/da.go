@@ -2,6 +2,8 @@ package da
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 
 	ds "github.com/ipfs/go-datastore"
 
@@ -10,9 +12,8 @@ import (
 )
 
 // StatusCode is a type for DA layer return status.
-// TODO: define an enum of different non-happy-path cases
-// that might need to be handled by Rollkit independent of
-// the underlying DA chain.
+// Non-happy-path cases that need to be handled by Rollkit independent of the underlying DA chain
+// have dedicated status codes.
 type StatusCode uint64
 
 // Data Availability return codes.
@@ -21,8 +22,37 @@ const (
 	StatusSuccess
 	StatusTimeout
 	StatusError
+	// StatusHeightFromFuture is returned when requested DA height is not produced yet.
+	StatusHeightFromFuture
+	// StatusBlobTooBig is returned when submitted data exceeds DA layer size limit.
+	StatusBlobTooBig
+	// StatusInsufficientFee is returned when submission was rejected because of too low fee.
+	StatusInsufficientFee
+	// StatusAlreadyIncluded is returned when submitted data is already included in DA layer.
+	StatusAlreadyIncluded
+	// StatusNamespaceEmpty is returned when there is no data in the namespace at requested DA height.
+	StatusNamespaceEmpty
 )
 
+var statusNames = map[StatusCode]string{
+	StatusUnknown:          "unknown",
+	StatusSuccess:          "success",
+	StatusTimeout:          "timeout",
+	StatusError:            "error",
+	StatusHeightFromFuture: "height from future",
+	StatusBlobTooBig:       "blob too big",
+	StatusInsufficientFee:  "insufficient fee",
+	StatusAlreadyIncluded:  "already included",
+	StatusNamespaceEmpty:   "namespace empty",
+}
+
+func (s StatusCode) String() string {
+	if name, ok := statusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("StatusCode(%d)", uint64(s))
+}
+
 // BaseResult contains basic information returned by DA layer.
 type BaseResult struct {
 	// Code is to determine if the action succeeded.
@@ -36,9 +66,12 @@ type BaseResult struct {
 // ResultSubmitBlock contains information returned from DA layer after block submission.
 type ResultSubmitBlock struct {
 	BaseResult
-	// Not sure if this needs to be bubbled up to other
-	// parts of Rollkit.
-	// Hash hash.Hash
+	// Commitment is DA layer specific identifier of submitted data (e.g. blob commitment or transaction hash).
+	Commitment []byte
+	// Namespace is the DA layer namespace that data was submitted to.
+	Namespace []byte
+	// Fee is the fee paid for the submission, in DA layer native units. Zero if DA layer doesn't charge fees.
+	Fee uint64
 }
 
 // ResultCheckBlock contains information about block availability, returned from DA layer client.
@@ -57,6 +90,43 @@ type ResultRetrieveBlocks struct {
 	Blocks []*types.Block
 }
 
+// ResultRetrieveHeaders contains batch of signed headers returned from DA layer client.
+type ResultRetrieveHeaders struct {
+	BaseResult
+	// Headers are the signed headers retrieved from Data Availability Layer.
+	// If Code is not equal to StatusSuccess, it has to be nil.
+	Headers []*types.SignedHeader
+}
+
+// ResultRetrieveTxs contains transactions posted directly to DA layer, returned from DA layer client.
+type ResultRetrieveTxs struct {
+	BaseResult
+	// Txs are the transactions retrieved from Data Availability Layer, in DA order.
+	// If Code is not equal to StatusSuccess, it has to be nil.
+	Txs types.Txs
+}
+
+// ClientConfig identifies DA client by name in the registry, together with its configuration.
+// It's used by clients that wrap other DA clients.
+type ClientConfig struct {
+	// Name of the client in DA registry.
+	Name string `json:"name"`
+	// Config is passed to the client Init method. JSON strings are passed unquoted, other values as raw JSON.
+	Config json.RawMessage `json:"config"`
+}
+
+// InitConfig returns configuration in format expected by client Init method.
+func (c ClientConfig) InitConfig() []byte {
+	var str string
+	if err := json.Unmarshal(c.Config, &str); err == nil {
+		return []byte(str)
+	}
+	return c.Config
+}
+
+// GetClientFunc returns new instance of DA client identified by name, or nil if there is no such client.
+type GetClientFunc func(name string) DataAvailabilityLayerClient
+
 // DataAvailabilityLayerClient defines generic interface for DA layer block submission.
 // It also contains life-cycle methods.
 type DataAvailabilityLayerClient interface {
@@ -74,6 +144,11 @@ type DataAvailabilityLayerClient interface {
 	// triggers a state transition in the DA layer.
 	SubmitBlock(ctx context.Context, block *types.Block) ResultSubmitBlock
 
+	// SubmitBlocks submits the passed in blocks to the DA layer as a single submission.
+	// All blocks are expected to be included at the same DA height, so the
+	// cost of the submission is paid once for the whole batch.
+	SubmitBlocks(ctx context.Context, blocks []*types.Block) ResultSubmitBlock
+
 	// CheckBlockAvailability queries DA layer to check data availability of block corresponding at given height.
 	CheckBlockAvailability(ctx context.Context, dataLayerHeight uint64) ResultCheckBlock
 }
@@ -84,3 +159,78 @@ type BlockRetriever interface {
 	// RetrieveBlocks returns blocks at given data layer height from data availability layer.
 	RetrieveBlocks(ctx context.Context, dataLayerHeight uint64) ResultRetrieveBlocks
 }
+
+// HeaderRetriever is additional interface that can be implemented by Data Availability Layer Client that posts signed
+// headers separately from block data. It allows light clients to follow the chain without downloading block data.
+type HeaderRetriever interface {
+	// RetrieveHeaders returns signed headers at given data layer height from data availability layer.
+	RetrieveHeaders(ctx context.Context, dataLayerHeight uint64) ResultRetrieveHeaders
+}
+
+// TxSubmitter is additional interface that can be implemented by Data Availability Layer Client that is able to post
+// transactions directly to DA layer. It's used in based sequencing mode, where blocks are derived from such transactions.
+type TxSubmitter interface {
+	// SubmitTxs submits the passed in transactions to the DA layer as a single blob.
+	SubmitTxs(ctx context.Context, txs types.Txs) ResultSubmitBlock
+}
+
+// TxRetriever is additional interface that can be implemented by Data Availability Layer Client that is able to
+// retrieve transactions posted directly to DA layer. It's required for based sequencing mode.
+type TxRetriever interface {
+	// RetrieveTxs returns transactions at given data layer height from data availability layer, in DA order.
+	// Blobs that are not batches of transactions are skipped.
+	RetrieveTxs(ctx context.Context, dataLayerHeight uint64) ResultRetrieveTxs
+}
+
+// BlockRangeRetriever is additional interface that can be implemented by Data Availability Layer Client that is able to
+// retrieve blocks from multiple DA heights in a single call. It's used to speed up synchronization.
+type BlockRangeRetriever interface {
+	// RetrieveBlocksRange returns blocks from data layer heights in range [from, to], one result per height, in order.
+	// Returned slice can be shorter than requested range; every result has DAHeight set.
+	RetrieveBlocksRange(ctx context.Context, from, to uint64) []ResultRetrieveBlocks
+}
+
+// HeightSubscriber is additional interface that can be implemented by Data Availability Layer Client that is able to
+// notify about new DA blocks. It's used to retrieve blocks as soon as they are available, instead of polling DA layer.
+type HeightSubscriber interface {
+	// SubscribeHeights returns a channel receiving heights of newly produced DA blocks. Intermediate heights may be
+	// skipped. Channel is closed when ctx is done or subscription fails.
+	SubscribeHeights(ctx context.Context) (<-chan uint64, error)
+}
+
+// FeeBumper is additional interface that can be implemented by Data Availability Layer Client that pays fees for
+// submissions. BumpFee is called after submission was rejected with StatusInsufficientFee, before it's retried.
+type FeeBumper interface {
+	// BumpFee increases the fee used for subsequent submissions. It returns false if fee can't be increased any
+	// more (for example, because configured cap was reached).
+	BumpFee() bool
+}
+
+// Wrapper is implemented by Data Availability Layer Clients that wrap other clients (like multi, failover or chaos
+// clients). Wrappers implement all the additional interfaces and forward them to wrapped clients, so Implements has to
+// be used to check if additional interface is actually supported.
+type Wrapper interface {
+	// Unwrap returns wrapped clients. It can be called after Init.
+	Unwrap() []DataAvailabilityLayerClient
+}
+
+// Implements returns true if client supports additional interface T. Wrapper supports T only if all the wrapped
+// clients support it.
+func Implements[T any](client interface{}) bool {
+	if wrapper, ok := client.(Wrapper); ok {
+		wrapped := wrapper.Unwrap()
+		for _, c := range wrapped {
+			if !Implements[T](c) {
+				return false
+			}
+		}
+		return len(wrapped) > 0
+	}
+	_, ok := client.(T)
+	return ok
+}
+
+// NotSupported returns result of a request to additional interface that is not supported by named client.
+func NotSupported(feature string, name string) BaseResult {
+	return BaseResult{Code: StatusError, Message: fmt.Sprintf("%s not supported by %s", feature, name)}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"encoding/hex"
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	"github.com/rollkit/rollkit/da/devnet"
+	"github.com/rollkit/rollkit/store"
+)
+
+func main() {
+	conf := devnet.DefaultConfig
+	logger := tmlog.NewTMLogger(os.Stdout)
+
+	home := flag.String("home", ".", "directory where DA data is persisted")
+	grpcAddress := flag.String("grpc_address", "0.0.0.0:7980", "listening address of DALCService gRPC API")
+	httpAddress := flag.String("http_address", "0.0.0.0:26658", "listening address of celestia-node endpoints and inspection API")
+	namespace := flag.String("namespace_id", hex.EncodeToString(conf.NamespaceID[:]), "namespace (hex encoded) of blocks submitted using gRPC API")
+	flag.DurationVar(&conf.BlockTime, "block_time", conf.BlockTime, "time between DA heights")
+	flag.Parse()
+
+	nid, err := hex.DecodeString(*namespace)
+	if err != nil || len(nid) != len(conf.NamespaceID) {
+		log.Panicf("invalid namespace ID: %q", *namespace)
+	}
+	copy(conf.NamespaceID[:], nid)
+
+	kv, err := store.NewDefaultKVStore(*home, "data", "devnet")
+	if err != nil {
+		log.Panic(err)
+	}
+	defer func() {
+		if err := kv.Close(); err != nil {
+			log.Println("error while closing datastore:", err)
+		}
+	}()
+	blobStore, err := devnet.NewBlobStore(context.Background(), kv)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	grpcListener, err := net.Listen("tcp", *grpcAddress)
+	if err != nil {
+		log.Panic(err)
+	}
+	httpListener, err := net.Listen("tcp", *httpAddress)
+	if err != nil {
+		log.Panic(err)
+	}
+
+	srv := devnet.NewServer(conf, blobStore, logger)
+	if err := srv.Start(grpcListener, httpListener); err != nil {
+		log.Panic(err)
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	<-signals
+	srv.Stop()
+}
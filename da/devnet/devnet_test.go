@@ -0,0 +1,188 @@
+package devnet
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/da/celestia"
+	grpcda "github.com/rollkit/rollkit/da/grpc"
+	"github.com/rollkit/rollkit/log/test"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+const blockTime = 50 * time.Millisecond
+
+var testNamespaceID = types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8}
+
+type testServer struct {
+	*Server
+	grpcAddr *net.TCPAddr
+	httpAddr string
+}
+
+func startServer(t *testing.T, blobStore *BlobStore) *testServer {
+	t.Helper()
+	grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	srv := NewServer(Config{BlockTime: blockTime, NamespaceID: testNamespaceID}, blobStore, test.NewLogger(t))
+	require.NoError(t, srv.Start(grpcListener, httpListener))
+	return &testServer{
+		Server:   srv,
+		grpcAddr: grpcListener.Addr().(*net.TCPAddr),
+		httpAddr: "http://" + httpListener.Addr().String(),
+	}
+}
+
+func (s *testServer) grpcClient(t *testing.T) *grpcda.DataAvailabilityLayerClient {
+	t.Helper()
+	conf, _ := json.Marshal(grpcda.Config{Host: s.grpcAddr.IP.String(), Port: s.grpcAddr.Port})
+	dalc := &grpcda.DataAvailabilityLayerClient{}
+	require.NoError(t, dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(t, dalc.Start())
+	t.Cleanup(func() { _ = dalc.Stop() })
+	return dalc
+}
+
+func (s *testServer) celestiaClient(t *testing.T) *celestia.DataAvailabilityLayerClient {
+	t.Helper()
+	conf, _ := json.Marshal(celestia.Config{BaseURL: s.httpAddr, Timeout: 5 * time.Second, GasLimit: 3000000})
+	dalc := &celestia.DataAvailabilityLayerClient{}
+	require.NoError(t, dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(t, dalc.Start())
+	return dalc
+}
+
+func TestServer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	dir := t.TempDir()
+	kv, err := store.NewDefaultKVStore(dir, "data", "devnet")
+	require.NoError(err)
+	blobStore, err := NewBlobStore(ctx, kv)
+	require.NoError(err)
+	srv := startServer(t, blobStore)
+
+	grpcClient := srv.grpcClient(t)
+	celestiaClient := srv.celestiaClient(t)
+
+	// heights are produced one by one
+	subCtx, cancel := context.WithCancel(ctx)
+	heights, err := grpcClient.SubscribeHeights(subCtx)
+	require.NoError(err)
+	first := <-heights
+	for i := uint64(1); i <= 3; i++ {
+		assert.Equal(first+i, <-heights)
+	}
+	cancel()
+
+	// blocks submitted with one client are available to the other
+	b1 := getRandomBlock(1, 10)
+	res1 := grpcClient.SubmitBlock(ctx, b1)
+	require.Equal(da.StatusSuccess, res1.Code, res1.Message)
+	b2 := getRandomBlock(2, 10)
+	res2 := celestiaClient.SubmitBlock(ctx, b2)
+	require.Equal(da.StatusSuccess, res2.Code, res2.Message)
+	b3, b4 := getRandomBlock(3, 5), getRandomBlock(4, 0)
+	res3 := grpcClient.SubmitBlocks(ctx, []*types.Block{b3, b4})
+	require.Equal(da.StatusSuccess, res3.Code, res3.Message)
+
+	require.Eventually(func() bool {
+		return blobStore.Head() > res3.DAHeight
+	}, 10*blockTime, blockTime/5)
+
+	retrieveAll := func(retriever da.BlockRetriever) []*types.Block {
+		var retrieved []*types.Block
+		for h := res1.DAHeight; h <= res3.DAHeight; h++ {
+			res := retriever.RetrieveBlocks(ctx, h)
+			require.Contains([]da.StatusCode{da.StatusSuccess, da.StatusNamespaceEmpty}, res.Code, res.Message)
+			retrieved = append(retrieved, res.Blocks...)
+		}
+		return retrieved
+	}
+	expected := []*types.Block{b1, b2, b3, b4}
+	assert.Equal(expected, retrieveAll(grpcClient))
+	assert.Equal(expected, retrieveAll(celestiaClient))
+	assert.Equal(da.StatusHeightFromFuture, grpcClient.RetrieveBlocks(ctx, blobStore.Head()+10).Code)
+
+	// inspection API lists blobs
+	var status Status
+	getJSON(t, srv.httpAddr+"/devnet/status", http.StatusOK, &status)
+	assert.GreaterOrEqual(status.Height, res3.DAHeight)
+	assert.Equal(hex.EncodeToString(testNamespaceID[:]), status.NamespaceID)
+
+	var heightInfo HeightInfo
+	getJSON(t, fmt.Sprintf("%s/devnet/heights/%d", srv.httpAddr, res1.DAHeight), http.StatusOK, &heightInfo)
+	require.Len(heightInfo.Namespaces, 1)
+	assert.Equal(hex.EncodeToString(testNamespaceID[:]), heightInfo.Namespaces[0].NamespaceID)
+	require.NotEmpty(heightInfo.Namespaces[0].Blobs)
+	assert.Empty(heightInfo.Namespaces[0].Blobs[0].Data)
+
+	var nsInfo NamespaceInfo
+	getJSON(t, fmt.Sprintf("%s/devnet/heights/%d/namespaces/%x", srv.httpAddr, res1.DAHeight, testNamespaceID), http.StatusOK, &nsInfo)
+	assert.Equal(heightInfo.Namespaces[0].Blobs[0].Hash, nsInfo.Blobs[0].Hash)
+	assert.NotEmpty(nsInfo.Blobs[0].Data)
+
+	getJSON(t, fmt.Sprintf("%s/devnet/heights/%d", srv.httpAddr, blobStore.Head()+10), http.StatusNotFound, nil)
+
+	// data and DA height are persisted
+	srv.Stop()
+	head := blobStore.Head()
+	require.NoError(kv.Close())
+
+	kv, err = store.NewDefaultKVStore(dir, "data", "devnet")
+	require.NoError(err)
+	defer func() { _ = kv.Close() }()
+	blobStore, err = NewBlobStore(ctx, kv)
+	require.NoError(err)
+	assert.Equal(head, blobStore.Head())
+
+	srv = startServer(t, blobStore)
+	defer srv.Stop()
+	assert.Equal(expected, retrieveAll(srv.grpcClient(t)))
+}
+
+func getJSON(t *testing.T, url string, expectedStatus int, result interface{}) {
+	t.Helper()
+	resp, err := http.Get(url) //nolint:gosec
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, expectedStatus, resp.StatusCode)
+	if result != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+}
+
+func getRandomBlock(height uint64, nTxs int) *types.Block {
+	block := &types.Block{}
+	block.SignedHeader.Header.BaseHeader.Height = height
+	block.SignedHeader.Header.AggregatorsHash = make([]byte, 32)
+	block.SignedHeader.Header.AppHash = getRandomBytes(32)
+	for i := 0; i < nTxs; i++ {
+		block.Data.Txs = append(block.Data.Txs, getRandomBytes(100))
+		block.Data.IntermediateStateRoots.RawRootsList = append(block.Data.IntermediateStateRoots.RawRootsList, getRandomBytes(32))
+	}
+	return block
+}
+
+func getRandomBytes(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data) //nolint:gosec
+	return data
+}
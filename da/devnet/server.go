@@ -0,0 +1,234 @@
+package devnet
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	mux2 "github.com/gorilla/mux"
+	"google.golang.org/grpc"
+
+	cmock "github.com/rollkit/rollkit/da/celestia/mock"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/pb/dalc"
+)
+
+// Config stores devnet DA server configuration parameters.
+type Config struct {
+	// BlockTime is the time between DA heights. Exactly one DA height is produced every BlockTime.
+	BlockTime time.Duration
+	// NamespaceID is used for blocks submitted using DALCService gRPC API, as its requests don't specify namespace.
+	NamespaceID types.NamespaceID
+}
+
+// DefaultConfig defines default values for devnet DA server configuration.
+var DefaultConfig = Config{
+	BlockTime: time.Second,
+}
+
+// Server is a standalone DA layer for local devnets.
+//
+// Server serves DALCService gRPC API (used by gRPC DA client) and celestia-node HTTP endpoints (used by celestia DA
+// client). Both APIs share the data, persisted in BlobStore. Inspection API, exposed over HTTP under /devnet, lists
+// blobs per DA height and namespace.
+type Server struct {
+	config Config
+	store  *BlobStore
+	logger log.Logger
+
+	grpcServer *grpc.Server
+	httpServer *http.Server
+	quit       chan struct{}
+}
+
+// NewServer creates new instance of Server.
+func NewServer(config Config, store *BlobStore, logger log.Logger) *Server {
+	return &Server{
+		config: config,
+		store:  store,
+		logger: logger,
+		quit:   make(chan struct{}),
+	}
+}
+
+// Start starts production of DA heights, gRPC server and HTTP server with given listeners.
+func (s *Server) Start(grpcListener, httpListener net.Listener) error {
+	if s.config.BlockTime <= 0 {
+		return fmt.Errorf("invalid block time: %v", s.config.BlockTime)
+	}
+	s.logger.Info("starting devnet DA server", "daHeight", s.store.Head(), "blockTime", s.config.BlockTime,
+		"grpc", grpcListener.Addr(), "http", httpListener.Addr())
+
+	s.grpcServer = grpc.NewServer()
+	dalc.RegisterDALCServiceServer(s.grpcServer, &dalcService{store: s.store, namespace: s.config.NamespaceID, logger: s.logger})
+	s.httpServer = &http.Server{Handler: s.Handler(), ReadHeaderTimeout: 10 * time.Second}
+
+	go s.produceHeights()
+	go func() {
+		err := s.grpcServer.Serve(grpcListener)
+		s.logger.Debug("gRPC server exited with", "error", err)
+	}()
+	go func() {
+		err := s.httpServer.Serve(httpListener)
+		s.logger.Debug("http server exited with", "error", err)
+	}()
+	return nil
+}
+
+// Stop shuts down the Server. Underlying datastore is not closed.
+func (s *Server) Stop() {
+	close(s.quit)
+	s.grpcServer.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+	defer cancel()
+	_ = s.httpServer.Shutdown(ctx)
+}
+
+func (s *Server) produceHeights() {
+	ticker := time.NewTicker(s.config.BlockTime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.quit:
+			return
+		case <-ticker.C:
+			height, err := s.store.Produce(context.Background())
+			if err != nil {
+				s.logger.Error("failed to produce DA height", "error", err)
+				continue
+			}
+			s.logger.Debug("produced DA height", "daHeight", height)
+		}
+	}
+}
+
+// Handler returns HTTP handler serving celestia-node endpoints and inspection API.
+func (s *Server) Handler() http.Handler {
+	mux := mux2.NewRouter()
+	cmock.NewServer(s.config.BlockTime, s.logger, cmock.WithBlobStore(s.store)).RegisterRoutes(mux)
+	mux.HandleFunc("/devnet/status", s.status).Methods(http.MethodGet)
+	mux.HandleFunc("/devnet/heights/{height}", s.height).Methods(http.MethodGet)
+	mux.HandleFunc("/devnet/heights/{height}/namespaces/{namespace}", s.namespace).Methods(http.MethodGet)
+	return mux
+}
+
+// Status describes current state of devnet DA layer.
+type Status struct {
+	// Height is the last produced DA height.
+	Height      uint64 `json:"height"`
+	BlockTime   string `json:"block_time"`
+	NamespaceID string `json:"namespace_id"`
+}
+
+// BlobInfo describes a single blob.
+type BlobInfo struct {
+	Index int    `json:"index"`
+	Size  int    `json:"size"`
+	Hash  string `json:"hash"`
+	// Data is returned only when blobs from a single namespace are listed.
+	Data []byte `json:"data,omitempty"`
+}
+
+// NamespaceInfo lists blobs submitted to a namespace at DA height.
+type NamespaceInfo struct {
+	NamespaceID string     `json:"namespace_id"`
+	Blobs       []BlobInfo `json:"blobs"`
+}
+
+// HeightInfo lists blobs submitted at DA height, grouped by namespace.
+type HeightInfo struct {
+	Height     uint64          `json:"height"`
+	Namespaces []NamespaceInfo `json:"namespaces"`
+}
+
+func (s *Server) status(w http.ResponseWriter, _ *http.Request) {
+	s.writeJSON(w, http.StatusOK, Status{
+		Height:      s.store.Head() - 1,
+		BlockTime:   s.config.BlockTime.String(),
+		NamespaceID: hex.EncodeToString(s.config.NamespaceID[:]),
+	})
+}
+
+func (s *Server) height(w http.ResponseWriter, r *http.Request) {
+	height, err := strconv.ParseUint(mux2.Vars(r)["height"], 10, 64)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	blobs, err := s.store.Blobs(r.Context(), height)
+	if err != nil {
+		s.writeStoreError(w, err)
+		return
+	}
+	info := HeightInfo{Height: height, Namespaces: make([]NamespaceInfo, len(blobs))}
+	for i := range blobs {
+		info.Namespaces[i] = namespaceInfo(blobs[i].Namespace, blobs[i].Blobs, false)
+	}
+	s.writeJSON(w, http.StatusOK, info)
+}
+
+func (s *Server) namespace(w http.ResponseWriter, r *http.Request) {
+	vars := mux2.Vars(r)
+	height, err := strconv.ParseUint(vars["height"], 10, 64)
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	namespace, err := hex.DecodeString(vars["namespace"])
+	if err != nil {
+		s.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	blobs, err := s.store.Retrieve(r.Context(), namespace, height)
+	if err != nil {
+		s.writeStoreError(w, err)
+		return
+	}
+	s.writeJSON(w, http.StatusOK, namespaceInfo(namespace, blobs, true))
+}
+
+func namespaceInfo(namespace []byte, blobs [][]byte, withData bool) NamespaceInfo {
+	info := NamespaceInfo{NamespaceID: hex.EncodeToString(namespace), Blobs: make([]BlobInfo, len(blobs))}
+	for i, blob := range blobs {
+		hash := sha256.Sum256(blob)
+		info.Blobs[i] = BlobInfo{Index: i, Size: len(blob), Hash: hex.EncodeToString(hash[:])}
+		if withData {
+			info.Blobs[i].Data = blob
+		}
+	}
+	return info
+}
+
+func (s *Server) writeStoreError(w http.ResponseWriter, err error) {
+	if errors.Is(err, cmock.ErrHeightFromFuture) {
+		s.writeError(w, http.StatusNotFound, err)
+		return
+	}
+	s.writeError(w, http.StatusInternalServerError, err)
+}
+
+func (s *Server) writeError(w http.ResponseWriter, status int, err error) {
+	s.writeJSON(w, status, err.Error())
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, payload interface{}) {
+	resp, err := json.Marshal(payload)
+	if err != nil {
+		s.logger.Error("failed to serialize response", "error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if _, err := w.Write(resp); err != nil {
+		s.logger.Error("failed to write response", "error", err)
+	}
+}
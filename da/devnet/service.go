@@ -0,0 +1,158 @@
+package devnet
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+
+	"github.com/rollkit/rollkit/da"
+	cmock "github.com/rollkit/rollkit/da/celestia/mock"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/pb/dalc"
+	"github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// dalcService implements DALCService gRPC API on top of BlobStore.
+//
+// Blocks are stored in the same format as used by celestia DA client, so nodes using gRPC and celestia DA clients
+// can share the data (if celestia client is configured with the same namespace).
+type dalcService struct {
+	store     *BlobStore
+	namespace types.NamespaceID
+	logger    log.Logger
+}
+
+var _ dalc.DALCServiceServer = &dalcService{}
+
+func (s *dalcService) SubmitBlock(ctx context.Context, request *dalc.SubmitBlockRequest) (*dalc.SubmitBlockResponse, error) {
+	var block types.Block
+	if err := block.FromProto(request.Block); err != nil {
+		return nil, err
+	}
+	blob, err := block.MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	result, commitment := s.submit(ctx, blob)
+	return &dalc.SubmitBlockResponse{
+		Result:     result,
+		Commitment: commitment,
+		Namespace:  s.namespace[:],
+	}, nil
+}
+
+func (s *dalcService) SubmitBlocks(ctx context.Context, request *dalc.SubmitBlocksRequest) (*dalc.SubmitBlocksResponse, error) {
+	blocks := make([]*types.Block, len(request.Blocks))
+	for i := range request.Blocks {
+		blocks[i] = new(types.Block)
+		if err := blocks[i].FromProto(request.Blocks[i]); err != nil {
+			return nil, err
+		}
+	}
+	blob, err := types.MarshalBatch(blocks)
+	if err != nil {
+		return nil, err
+	}
+	result, commitment := s.submit(ctx, blob)
+	return &dalc.SubmitBlocksResponse{
+		Result:     result,
+		Commitment: commitment,
+		Namespace:  s.namespace[:],
+	}, nil
+}
+
+func (s *dalcService) submit(ctx context.Context, blob []byte) (*dalc.DAResponse, []byte) {
+	blob, err := types.CompressBlob(blob, types.CodecZstd)
+	if err != nil {
+		return errorResponse(err), nil
+	}
+	daHeight, err := s.store.Submit(ctx, s.namespace[:], blob)
+	if err != nil {
+		return errorResponse(err), nil
+	}
+	commitment := sha256.Sum256(blob)
+	return &dalc.DAResponse{
+		Code:     dalc.StatusCode(da.StatusSuccess),
+		Message:  "OK",
+		DAHeight: daHeight,
+	}, commitment[:]
+}
+
+func (s *dalcService) CheckBlockAvailability(ctx context.Context, request *dalc.CheckBlockAvailabilityRequest) (*dalc.CheckBlockAvailabilityResponse, error) {
+	blobs, err := s.store.Retrieve(ctx, s.namespace[:], request.DAHeight)
+	if err != nil {
+		return &dalc.CheckBlockAvailabilityResponse{Result: errorResponse(err)}, nil
+	}
+	code := da.StatusSuccess
+	if len(blobs) == 0 {
+		code = da.StatusNamespaceEmpty
+	}
+	return &dalc.CheckBlockAvailabilityResponse{
+		Result:        &dalc.DAResponse{Code: dalc.StatusCode(code), DAHeight: request.DAHeight},
+		DataAvailable: len(blobs) > 0,
+	}, nil
+}
+
+func (s *dalcService) RetrieveBlocks(ctx context.Context, request *dalc.RetrieveBlocksRequest) (*dalc.RetrieveBlocksResponse, error) {
+	blobs, err := s.store.Retrieve(ctx, s.namespace[:], request.DAHeight)
+	if err != nil {
+		return &dalc.RetrieveBlocksResponse{Result: errorResponse(err)}, nil
+	}
+
+	var blocks []*rollkit.Block
+	for i, blob := range blobs {
+		if _, ok := types.DecodeBlockPart(blob); ok {
+			s.logger.Error("blobs split into parts are not supported", "daHeight", request.DAHeight, "position", i)
+			continue
+		}
+		blob, err = types.DecompressBlob(blob)
+		if err != nil {
+			s.logger.Error("failed to decompress blob", "daHeight", request.DAHeight, "position", i, "error", err)
+			continue
+		}
+		decoded, err := types.UnmarshalBlocks(blob)
+		if err != nil {
+			s.logger.Error("failed to unmarshal block", "daHeight", request.DAHeight, "position", i, "error", err)
+			continue
+		}
+		for _, block := range decoded {
+			bp, err := block.ToProto()
+			if err != nil {
+				return nil, err
+			}
+			blocks = append(blocks, bp)
+		}
+	}
+
+	code := da.StatusSuccess
+	if len(blocks) == 0 {
+		code = da.StatusNamespaceEmpty
+	}
+	return &dalc.RetrieveBlocksResponse{
+		Result: &dalc.DAResponse{Code: dalc.StatusCode(code), DAHeight: request.DAHeight},
+		Blocks: blocks,
+	}, nil
+}
+
+func (s *dalcService) SubscribeHeights(_ *dalc.SubscribeHeightsRequest, stream dalc.DALCService_SubscribeHeightsServer) error {
+	heights := s.store.SubscribeHeights(stream.Context())
+	// let the client know that subscription is established
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	for height := range heights {
+		if err := stream.Send(&dalc.SubscribeHeightsResponse{DAHeight: height}); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
+
+func errorResponse(err error) *dalc.DAResponse {
+	code := da.StatusError
+	if errors.Is(err, cmock.ErrHeightFromFuture) {
+		code = da.StatusHeightFromFuture
+	}
+	return &dalc.DAResponse{Code: dalc.StatusCode(code), Message: err.Error()}
+}
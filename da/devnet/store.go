@@ -0,0 +1,196 @@
+package devnet
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+
+	cmock "github.com/rollkit/rollkit/da/celestia/mock"
+	"github.com/rollkit/rollkit/store"
+)
+
+var headKey = ds.NewKey("/head")
+
+// NamespaceBlobs contains blobs submitted to a single namespace, in submission order.
+type NamespaceBlobs struct {
+	Namespace []byte
+	Blobs     [][]byte
+}
+
+// BlobStore persists blobs submitted to devnet DA layer, together with current DA height.
+//
+// Blobs are submitted at current DA height (head), and are available for retrieval once the next DA height is produced.
+type BlobStore struct {
+	kv ds.Datastore
+
+	mtx  sync.RWMutex
+	head uint64
+
+	subscribersMtx sync.Mutex
+	subscribers    map[chan uint64]struct{}
+}
+
+var _ cmock.BlobStore = &BlobStore{}
+
+// NewBlobStore creates BlobStore using given datastore. DA height is restored from the datastore, if it was persisted.
+func NewBlobStore(ctx context.Context, kv ds.Datastore) (*BlobStore, error) {
+	s := &BlobStore{
+		kv:          kv,
+		head:        1,
+		subscribers: make(map[chan uint64]struct{}),
+	}
+	value, err := kv.Get(ctx, headKey)
+	if errors.Is(err, ds.ErrNotFound) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(value) != 8 {
+		return nil, fmt.Errorf("invalid DA height in datastore: %x", value)
+	}
+	s.head = binary.BigEndian.Uint64(value)
+	return s, nil
+}
+
+// Head returns current DA height. All lower DA heights are available for retrieval.
+func (s *BlobStore) Head() uint64 {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	return s.head
+}
+
+// Produce finishes current DA height, so blobs submitted at this height become available for retrieval.
+// Produced DA height is returned.
+func (s *BlobStore) Produce(ctx context.Context) (uint64, error) {
+	s.mtx.Lock()
+	value := make([]byte, 8)
+	binary.BigEndian.PutUint64(value, s.head+1)
+	if err := s.kv.Put(ctx, headKey, value); err != nil {
+		s.mtx.Unlock()
+		return 0, err
+	}
+	produced := s.head
+	s.head++
+	s.mtx.Unlock()
+
+	s.subscribersMtx.Lock()
+	defer s.subscribersMtx.Unlock()
+	for heights := range s.subscribers {
+		select {
+		case heights <- produced:
+		default:
+		}
+	}
+	return produced, nil
+}
+
+// Submit stores blob in namespace at current DA height, and returns the DA height.
+func (s *BlobStore) Submit(ctx context.Context, namespace []byte, blob []byte) (uint64, error) {
+	if len(namespace) == 0 {
+		return 0, errors.New("empty namespace")
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	prefix := namespacePrefix(s.head, namespace)
+	results, err := store.PrefixEntries(ctx, s.kv, prefix)
+	if err != nil {
+		return 0, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return 0, err
+	}
+	if err := s.kv.Put(ctx, ds.NewKey(fmt.Sprintf("%s/%010d", prefix, len(entries))), blob); err != nil {
+		return 0, err
+	}
+	return s.head, nil
+}
+
+// Retrieve returns all blobs submitted to namespace at given DA height.
+func (s *BlobStore) Retrieve(ctx context.Context, namespace []byte, daHeight uint64) ([][]byte, error) {
+	if daHeight >= s.Head() {
+		return nil, cmock.ErrHeightFromFuture
+	}
+	results, err := store.PrefixEntries(ctx, s.kv, namespacePrefix(daHeight, namespace))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+	blobs := make([][]byte, len(entries))
+	for i := range entries {
+		blobs[i] = entries[i].Value
+	}
+	return blobs, nil
+}
+
+// Blobs returns all blobs submitted at given DA height, grouped by namespace. Namespaces are sorted.
+func (s *BlobStore) Blobs(ctx context.Context, daHeight uint64) ([]NamespaceBlobs, error) {
+	if daHeight >= s.Head() {
+		return nil, cmock.ErrHeightFromFuture
+	}
+	results, err := store.PrefixEntries(ctx, s.kv, heightPrefix(daHeight))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key < entries[j].Key })
+
+	var blobs []NamespaceBlobs
+	for _, entry := range entries {
+		// key format: /blobs/<DA height>/<namespace>/<index>
+		parts := strings.Split(strings.TrimPrefix(entry.Key, "/"), "/")
+		if len(parts) != 4 {
+			return nil, fmt.Errorf("invalid blob key: %s", entry.Key)
+		}
+		namespace, err := hex.DecodeString(parts[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid blob key: %s: %w", entry.Key, err)
+		}
+		if len(blobs) == 0 || string(blobs[len(blobs)-1].Namespace) != string(namespace) {
+			blobs = append(blobs, NamespaceBlobs{Namespace: namespace})
+		}
+		blobs[len(blobs)-1].Blobs = append(blobs[len(blobs)-1].Blobs, entry.Value)
+	}
+	return blobs, nil
+}
+
+// SubscribeHeights returns a channel receiving produced DA heights. Channel is closed when ctx is done.
+func (s *BlobStore) SubscribeHeights(ctx context.Context) <-chan uint64 {
+	// buffer is arbitrary; heights are dropped if subscriber is too slow
+	heights := make(chan uint64, 16)
+	s.subscribersMtx.Lock()
+	s.subscribers[heights] = struct{}{}
+	s.subscribersMtx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		s.subscribersMtx.Lock()
+		delete(s.subscribers, heights)
+		close(heights)
+		s.subscribersMtx.Unlock()
+	}()
+	return heights
+}
+
+func heightPrefix(daHeight uint64) string {
+	return fmt.Sprintf("/blobs/%020d", daHeight)
+}
+
+func namespacePrefix(daHeight uint64, namespace []byte) string {
+	return heightPrefix(daHeight) + "/" + hex.EncodeToString(namespace)
+}
@@ -0,0 +1,426 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	ktds "github.com/ipfs/go-datastore/keytransform"
+	"go.uber.org/multierr"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrNoBackends is returned when failover client is used without any DA backends configured.
+var ErrNoBackends = errors.New("no DA backends configured")
+
+// Config stores failover DA client configuration parameters.
+type Config struct {
+	// Backends is the ordered list of DA backends. The first one is the primary backend.
+	Backends []da.ClientConfig `json:"backends"`
+	// FailureThreshold is the number of consecutive failures after which backend is considered unhealthy.
+	FailureThreshold int `json:"failure_threshold"`
+	// Cooldown is the time after which unhealthy backend is tried again.
+	Cooldown time.Duration `json:"cooldown"`
+}
+
+// DefaultConfig defines default values for failover DA client configuration.
+var DefaultConfig = Config{
+	FailureThreshold: 3,
+	Cooldown:         30 * time.Second,
+}
+
+// ewmaWeight is the weight of the latest sample in error rate and latency moving averages.
+const ewmaWeight = 0.2
+
+// Health describes health of a single DA backend.
+type Health struct {
+	Name                string
+	Healthy             bool
+	ConsecutiveFailures int
+	// ErrorRate is exponentially weighted moving average of request failures (from 0 to 1).
+	ErrorRate float64
+	// Latency is exponentially weighted moving average of request latency.
+	Latency       time.Duration
+	UnhealthyTime time.Time
+}
+
+type backend struct {
+	da.DataAvailabilityLayerClient
+	health Health
+}
+
+// DataAvailabilityLayerClient sends all requests to a single, active DA backend.
+//
+// After FailureThreshold consecutive StatusError or StatusTimeout results, active backend is marked unhealthy, and
+// the request is retried with the next backend. Once Cooldown elapses, client fails back to the higher priority
+// backend. Backend that was failed back to is switched away from after a single failure.
+//
+// DA heights are passed to backends as-is, so all backends should share the height space (for example, multiple
+// endpoints of the same DA network).
+type DataAvailabilityLayerClient struct {
+	getClient da.GetClientFunc
+
+	config  Config
+	logger  log.Logger
+	metrics *Metrics
+
+	mtx      sync.Mutex
+	backends []*backend
+	active   int
+}
+
+var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
+var _ da.Wrapper = &DataAvailabilityLayerClient{}
+var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.BlockRangeRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeaderRetriever = &DataAvailabilityLayerClient{}
+var _ da.TxRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeightSubscriber = &DataAvailabilityLayerClient{}
+var _ da.FeeBumper = &DataAvailabilityLayerClient{}
+
+// NewDataAvailabilityLayerClient creates failover DA client, that uses getClient to instantiate backends.
+func NewDataAvailabilityLayerClient(getClient da.GetClientFunc) *DataAvailabilityLayerClient {
+	return &DataAvailabilityLayerClient{getClient: getClient, metrics: NopMetrics()}
+}
+
+// SetMetrics sets metrics used by the client. It has to be called before Start.
+func (f *DataAvailabilityLayerClient) SetMetrics(metrics *Metrics) {
+	f.metrics = metrics
+}
+
+// Init parses configuration and initializes all backends.
+// Every backend gets a separate, prefixed view of kvStore.
+func (f *DataAvailabilityLayerClient) Init(namespaceID types.NamespaceID, config []byte, kvStore ds.Datastore, logger log.Logger) error {
+	f.logger = logger
+	f.config = DefaultConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &f.config); err != nil {
+			return err
+		}
+	}
+	if f.config.FailureThreshold <= 0 {
+		return fmt.Errorf("invalid failure threshold: %d", f.config.FailureThreshold)
+	}
+
+	f.backends = make([]*backend, len(f.config.Backends))
+	for i, conf := range f.config.Backends {
+		dalc := f.getClient(conf.Name)
+		if dalc == nil {
+			return fmt.Errorf("couldn't get data availability client named '%s'", conf.Name)
+		}
+		var backendKV ds.Datastore
+		if kvStore != nil {
+			backendKV = ktds.Wrap(kvStore, ktds.PrefixTransform{Prefix: ds.NewKey(strconv.Itoa(i))})
+		}
+		if err := dalc.Init(namespaceID, conf.InitConfig(), backendKV, logger); err != nil {
+			return fmt.Errorf("failed to initialize data availability client '%s': %w", conf.Name, err)
+		}
+		f.backends[i] = &backend{
+			DataAvailabilityLayerClient: dalc,
+			health:                      Health{Name: conf.Name, Healthy: true},
+		}
+	}
+	return nil
+}
+
+// Unwrap returns all backends.
+func (f *DataAvailabilityLayerClient) Unwrap() []da.DataAvailabilityLayerClient {
+	clients := make([]da.DataAvailabilityLayerClient, len(f.backends))
+	for i := range f.backends {
+		clients[i] = f.backends[i].DataAvailabilityLayerClient
+	}
+	return clients
+}
+
+// Start starts all backends.
+func (f *DataAvailabilityLayerClient) Start() error {
+	f.logger.Info("starting failover Data Availability Layer Client", "backends", len(f.backends))
+	for i := range f.backends {
+		if err := f.backends[i].Start(); err != nil {
+			for j := 0; j < i; j++ {
+				_ = f.backends[j].Stop()
+			}
+			return fmt.Errorf("failed to start data availability client '%s': %w", f.backends[i].health.Name, err)
+		}
+		f.metrics.BackendHealthy.With("backend", f.backends[i].health.Name).Set(1)
+	}
+	f.metrics.ActiveBackend.Set(0)
+	return nil
+}
+
+// Stop stops all backends.
+func (f *DataAvailabilityLayerClient) Stop() error {
+	f.logger.Info("stopping failover Data Availability Layer Client")
+	var err error
+	for i := range f.backends {
+		err = multierr.Append(err, f.backends[i].Stop())
+	}
+	return err
+}
+
+// Health returns health of all backends, in configuration order.
+func (f *DataAvailabilityLayerClient) Health() []Health {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	health := make([]Health, len(f.backends))
+	for i := range f.backends {
+		health[i] = f.backends[i].health
+	}
+	return health
+}
+
+// Active returns index of the active backend.
+func (f *DataAvailabilityLayerClient) Active() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.active
+}
+
+// BumpFee bumps fee of the active backend, if it pays fees for submissions.
+func (f *DataAvailabilityLayerClient) BumpFee() bool {
+	if len(f.backends) == 0 {
+		return false
+	}
+	if bumper, ok := f.backends[f.Active()].DataAvailabilityLayerClient.(da.FeeBumper); ok {
+		return bumper.BumpFee()
+	}
+	return false
+}
+
+// SubmitBlock submits the block using active backend.
+func (f *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	var res da.ResultSubmitBlock
+	res.BaseResult = f.do(func(b *backend) da.BaseResult {
+		res = b.SubmitBlock(ctx, block)
+		return res.BaseResult
+	})
+	return res
+}
+
+// SubmitBlocks submits the blocks using active backend.
+func (f *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	var res da.ResultSubmitBlock
+	res.BaseResult = f.do(func(b *backend) da.BaseResult {
+		res = b.SubmitBlocks(ctx, blocks)
+		return res.BaseResult
+	})
+	return res
+}
+
+// CheckBlockAvailability checks data availability using active backend.
+func (f *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context, dataLayerHeight uint64) da.ResultCheckBlock {
+	var res da.ResultCheckBlock
+	res.BaseResult = f.do(func(b *backend) da.BaseResult {
+		res = b.CheckBlockAvailability(ctx, dataLayerHeight)
+		return res.BaseResult
+	})
+	return res
+}
+
+// RetrieveBlocks retrieves blocks using active backend.
+func (f *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveBlocks {
+	var res da.ResultRetrieveBlocks
+	res.BaseResult = f.do(func(b *backend) da.BaseResult {
+		retriever, ok := b.DataAvailabilityLayerClient.(da.BlockRetriever)
+		if !ok {
+			res = da.ResultRetrieveBlocks{BaseResult: da.NotSupported("block retrieval", b.health.Name)}
+			return res.BaseResult
+		}
+		res = retriever.RetrieveBlocks(ctx, dataLayerHeight)
+		return res.BaseResult
+	})
+	return res
+}
+
+// RetrieveBlocksRange retrieves blocks from range of DA heights using active backend. Failure of any height counts as
+// failure of the backend.
+func (f *DataAvailabilityLayerClient) RetrieveBlocksRange(ctx context.Context, from, to uint64) []da.ResultRetrieveBlocks {
+	var results []da.ResultRetrieveBlocks
+	f.do(func(b *backend) da.BaseResult {
+		retriever, ok := b.DataAvailabilityLayerClient.(da.BlockRangeRetriever)
+		if !ok {
+			res := da.ResultRetrieveBlocks{BaseResult: da.NotSupported("range retrieval", b.health.Name)}
+			res.DAHeight = from
+			results = []da.ResultRetrieveBlocks{res}
+			return res.BaseResult
+		}
+		results = retriever.RetrieveBlocksRange(ctx, from, to)
+		for _, res := range results {
+			if res.Code == da.StatusError || res.Code == da.StatusTimeout {
+				return res.BaseResult
+			}
+		}
+		return da.BaseResult{Code: da.StatusSuccess}
+	})
+	return results
+}
+
+// RetrieveHeaders retrieves signed headers using active backend.
+func (f *DataAvailabilityLayerClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveHeaders {
+	var res da.ResultRetrieveHeaders
+	res.BaseResult = f.do(func(b *backend) da.BaseResult {
+		retriever, ok := b.DataAvailabilityLayerClient.(da.HeaderRetriever)
+		if !ok {
+			res = da.ResultRetrieveHeaders{BaseResult: da.NotSupported("header retrieval", b.health.Name)}
+			return res.BaseResult
+		}
+		res = retriever.RetrieveHeaders(ctx, dataLayerHeight)
+		return res.BaseResult
+	})
+	return res
+}
+
+// RetrieveTxs retrieves transactions using active backend.
+func (f *DataAvailabilityLayerClient) RetrieveTxs(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveTxs {
+	var res da.ResultRetrieveTxs
+	res.BaseResult = f.do(func(b *backend) da.BaseResult {
+		retriever, ok := b.DataAvailabilityLayerClient.(da.TxRetriever)
+		if !ok {
+			res = da.ResultRetrieveTxs{BaseResult: da.NotSupported("transaction retrieval", b.health.Name)}
+			return res.BaseResult
+		}
+		res = retriever.RetrieveTxs(ctx, dataLayerHeight)
+		return res.BaseResult
+	})
+	return res
+}
+
+// SubscribeHeights subscribes to new DA heights using active backend. Failed subscription counts as failure of the
+// backend.
+func (f *DataAvailabilityLayerClient) SubscribeHeights(ctx context.Context) (<-chan uint64, error) {
+	var heights <-chan uint64
+	var err error
+	res := f.do(func(b *backend) da.BaseResult {
+		subscriber, ok := b.DataAvailabilityLayerClient.(da.HeightSubscriber)
+		if !ok {
+			res := da.NotSupported("height subscription", b.health.Name)
+			err = errors.New(res.Message)
+			return res
+		}
+		heights, err = subscriber.SubscribeHeights(ctx)
+		if err != nil {
+			return da.BaseResult{Code: da.StatusError, Message: err.Error()}
+		}
+		return da.BaseResult{Code: da.StatusSuccess}
+	})
+	if res.Code == da.StatusError && err == nil {
+		err = errors.New(res.Message)
+	}
+	return heights, err
+}
+
+// do executes request with active backend. If backend becomes unhealthy, request is retried with the next backend.
+func (f *DataAvailabilityLayerClient) do(request func(b *backend) da.BaseResult) da.BaseResult {
+	if len(f.backends) == 0 {
+		return da.BaseResult{Code: da.StatusError, Message: ErrNoBackends.Error()}
+	}
+
+	var res da.BaseResult
+	for attempt := 0; attempt < len(f.backends); attempt++ {
+		idx := f.selectBackend()
+		b := f.backends[idx]
+
+		start := time.Now()
+		res = request(b)
+		latency := time.Since(start)
+
+		if !f.record(idx, res, latency) {
+			return res
+		}
+	}
+	return res
+}
+
+// selectBackend fails back to higher priority backend if its cooldown elapsed, and returns the active backend.
+func (f *DataAvailabilityLayerClient) selectBackend() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	for i := 0; i < f.active; i++ {
+		b := f.backends[i]
+		if !b.health.Healthy && time.Since(b.health.UnhealthyTime) < f.config.Cooldown {
+			continue
+		}
+		if !b.health.Healthy {
+			// on probation: single failure makes it unhealthy again
+			b.health.ConsecutiveFailures = f.config.FailureThreshold - 1
+		}
+		f.switchTo(i, "cooldown elapsed")
+		break
+	}
+	return f.active
+}
+
+// record updates health of the backend. It returns true if request should be retried with another backend.
+func (f *DataAvailabilityLayerClient) record(idx int, res da.BaseResult, latency time.Duration) bool {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+
+	b := f.backends[idx]
+	f.metrics.BackendLatency.With("backend", b.health.Name).Observe(latency.Seconds())
+	b.health.Latency = time.Duration(ewmaWeight*float64(latency) + (1-ewmaWeight)*float64(b.health.Latency))
+
+	failed := res.Code == da.StatusError || res.Code == da.StatusTimeout
+	if !failed {
+		b.health.ErrorRate = (1 - ewmaWeight) * b.health.ErrorRate
+		b.health.ConsecutiveFailures = 0
+		if !b.health.Healthy {
+			b.health.Healthy = true
+			f.metrics.BackendHealthy.With("backend", b.health.Name).Set(1)
+		}
+		return false
+	}
+
+	f.metrics.BackendErrors.With("backend", b.health.Name).Add(1)
+	b.health.ErrorRate = ewmaWeight + (1-ewmaWeight)*b.health.ErrorRate
+	b.health.ConsecutiveFailures++
+	if b.health.ConsecutiveFailures < f.config.FailureThreshold {
+		return false
+	}
+
+	b.health.Healthy = false
+	b.health.UnhealthyTime = time.Now()
+	f.metrics.BackendHealthy.With("backend", b.health.Name).Set(0)
+	f.logger.Error("DA backend is unhealthy", "backend", b.health.Name, "index", idx,
+		"consecutiveFailures", b.health.ConsecutiveFailures, "errorRate", b.health.ErrorRate, "error", res.Message)
+
+	if idx != f.active {
+		return false
+	}
+	next := f.nextHealthy(idx)
+	if next == idx {
+		return false
+	}
+	f.switchTo(next, res.Message)
+	return true
+}
+
+// nextHealthy returns index of the first healthy backend following idx (wrapping around).
+// If there are no healthy backends, idx is returned.
+func (f *DataAvailabilityLayerClient) nextHealthy(idx int) int {
+	for i := 1; i < len(f.backends); i++ {
+		candidate := (idx + i) % len(f.backends)
+		if f.backends[candidate].health.Healthy {
+			return candidate
+		}
+	}
+	return idx
+}
+
+func (f *DataAvailabilityLayerClient) switchTo(idx int, reason string) {
+	f.logger.Info("switching DA backend",
+		"from", f.backends[f.active].health.Name, "fromIndex", f.active,
+		"to", f.backends[idx].health.Name, "toIndex", idx,
+		"reason", reason)
+	f.active = idx
+	f.metrics.Switches.Add(1)
+	f.metrics.ActiveBackend.Set(float64(idx))
+}
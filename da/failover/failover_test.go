@@ -0,0 +1,115 @@
+package failover
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	ds "github.com/ipfs/go-datastore"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/log/test"
+	"github.com/rollkit/rollkit/types"
+)
+
+// testBackend returns results with configured status code.
+type testBackend struct {
+	code     uint64
+	requests uint64
+}
+
+func (t *testBackend) Init(types.NamespaceID, []byte, ds.Datastore, log.Logger) error { return nil }
+func (t *testBackend) Start() error                                                   { return nil }
+func (t *testBackend) Stop() error                                                    { return nil }
+
+func (t *testBackend) result() da.BaseResult {
+	atomic.AddUint64(&t.requests, 1)
+	return da.BaseResult{Code: da.StatusCode(atomic.LoadUint64(&t.code))}
+}
+
+func (t *testBackend) setCode(code da.StatusCode) {
+	atomic.StoreUint64(&t.code, uint64(code))
+}
+
+func (t *testBackend) SubmitBlock(context.Context, *types.Block) da.ResultSubmitBlock {
+	return da.ResultSubmitBlock{BaseResult: t.result()}
+}
+
+func (t *testBackend) SubmitBlocks(context.Context, []*types.Block) da.ResultSubmitBlock {
+	return da.ResultSubmitBlock{BaseResult: t.result()}
+}
+
+func (t *testBackend) CheckBlockAvailability(context.Context, uint64) da.ResultCheckBlock {
+	return da.ResultCheckBlock{BaseResult: t.result()}
+}
+
+func TestFailover(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	backends := map[string]*testBackend{
+		"primary":   {code: uint64(da.StatusSuccess)},
+		"secondary": {code: uint64(da.StatusSuccess)},
+	}
+	client := NewDataAvailabilityLayerClient(func(name string) da.DataAvailabilityLayerClient {
+		return backends[name]
+	})
+
+	conf, err := json.Marshal(Config{
+		Backends:         []da.ClientConfig{{Name: "primary"}, {Name: "secondary"}},
+		FailureThreshold: 2,
+		Cooldown:         100 * time.Millisecond,
+	})
+	require.NoError(err)
+	require.NoError(client.Init(types.NamespaceID{}, conf, nil, test.NewLogger(t)))
+	require.NoError(client.Start())
+
+	block := &types.Block{}
+	assert.Equal(da.StatusSuccess, client.SubmitBlock(ctx, block).Code)
+	assert.Equal(0, client.Active())
+
+	// first failure is returned to the caller
+	backends["primary"].setCode(da.StatusTimeout)
+	assert.Equal(da.StatusTimeout, client.SubmitBlock(ctx, block).Code)
+	assert.Equal(0, client.Active())
+
+	// second failure makes primary unhealthy, and request is retried with secondary
+	assert.Equal(da.StatusSuccess, client.SubmitBlock(ctx, block).Code)
+	assert.Equal(1, client.Active())
+	health := client.Health()
+	assert.False(health[0].Healthy)
+	assert.Equal(2, health[0].ConsecutiveFailures)
+	assert.Greater(health[0].ErrorRate, 0.0)
+	assert.True(health[1].Healthy)
+	assert.Equal(uint64(1), atomic.LoadUint64(&backends["secondary"].requests))
+
+	// before cooldown, primary is not used
+	primaryRequests := atomic.LoadUint64(&backends["primary"].requests)
+	assert.Equal(da.StatusSuccess, client.CheckBlockAvailability(ctx, 1).Code)
+	assert.Equal(primaryRequests, atomic.LoadUint64(&backends["primary"].requests))
+
+	// after cooldown, client fails back, but still failing primary is abandoned after single failure
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(da.StatusSuccess, client.SubmitBlock(ctx, block).Code)
+	assert.Equal(primaryRequests+1, atomic.LoadUint64(&backends["primary"].requests))
+	assert.Equal(1, client.Active())
+
+	// after next cooldown, recovered primary is used again
+	backends["primary"].setCode(da.StatusSuccess)
+	time.Sleep(150 * time.Millisecond)
+	assert.Equal(da.StatusSuccess, client.SubmitBlock(ctx, block).Code)
+	assert.Equal(0, client.Active())
+	assert.True(client.Health()[0].Healthy)
+
+	// retrieval is not supported by test backends
+	assert.Equal(da.StatusError, client.RetrieveBlocks(ctx, 1).Code)
+
+	require.NoError(client.Stop())
+}
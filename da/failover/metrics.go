@@ -0,0 +1,91 @@
+package failover
+
+import (
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// MetricsSubsystem is a subsystem shared by all metrics exposed by this
+	// package.
+	MetricsSubsystem = "da_failover"
+)
+
+// Metrics contains metrics exposed by this package.
+// Metrics related to a single DA backend are labeled with "backend".
+type Metrics struct {
+	// Index of the active DA backend.
+	ActiveBackend metrics.Gauge
+
+	// Number of switches between DA backends.
+	Switches metrics.Counter
+
+	// Whether DA backend is considered healthy (1) or not (0).
+	BackendHealthy metrics.Gauge
+
+	// Number of failed requests to DA backend.
+	BackendErrors metrics.Counter
+
+	// Histogram of DA backend request latencies, in seconds.
+	BackendLatency metrics.Histogram
+}
+
+// PrometheusMetrics returns Metrics build using Prometheus client library.
+// Optionally, labels can be provided along with their values ("foo",
+// "fooValue").
+func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
+	labels := []string{}
+	for i := 0; i < len(labelsAndValues); i += 2 {
+		labels = append(labels, labelsAndValues[i])
+	}
+	return &Metrics{
+		ActiveBackend: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "active_backend",
+			Help:      "Index of the active DA backend.",
+		}, labels).With(labelsAndValues...),
+
+		Switches: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "switches",
+			Help:      "Number of switches between DA backends.",
+		}, labels).With(labelsAndValues...),
+
+		BackendHealthy: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "backend_healthy",
+			Help:      "Whether DA backend is considered healthy (1) or not (0).",
+		}, append(labels, "backend")).With(labelsAndValues...),
+
+		BackendErrors: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "backend_errors",
+			Help:      "Number of failed requests to DA backend.",
+		}, append(labels, "backend")).With(labelsAndValues...),
+
+		BackendLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "backend_latency_seconds",
+			Help:      "DA backend request latencies in seconds.",
+			Buckets:   stdprometheus.ExponentialBuckets(0.01, 2, 12),
+		}, append(labels, "backend")).With(labelsAndValues...),
+	}
+}
+
+// NopMetrics returns no-op Metrics.
+func NopMetrics() *Metrics {
+	return &Metrics{
+		ActiveBackend:  discard.NewGauge(),
+		Switches:       discard.NewCounter(),
+		BackendHealthy: discard.NewGauge(),
+		BackendErrors:  discard.NewCounter(),
+		BackendLatency: discard.NewHistogram(),
+	}
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"strconv"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	ds "github.com/ipfs/go-datastore"
 
@@ -13,6 +16,7 @@ import (
 	"github.com/rollkit/rollkit/log"
 	"github.com/rollkit/rollkit/types"
 	"github.com/rollkit/rollkit/types/pb/dalc"
+	"github.com/rollkit/rollkit/types/pb/rollkit"
 )
 
 // DataAvailabilityLayerClient is a generic client that proxies all DA requests via gRPC.
@@ -27,9 +31,18 @@ type DataAvailabilityLayerClient struct {
 
 // Config contains configuration options for DataAvailabilityLayerClient.
 type Config struct {
-	// TODO(tzdybal): add more options!
 	Host string `json:"host"`
 	Port int    `json:"port"`
+	// TLS enables TLS if set. Connection is insecure otherwise.
+	TLS *TLSConfig `json:"tls"`
+	// Token is sent as bearer token with every request. It requires TLS.
+	Token     string          `json:"token"`
+	Keepalive KeepaliveConfig `json:"keepalive"`
+	// Timeout limits duration of every request (0 means no timeout). Height subscriptions are not limited.
+	Timeout time.Duration `json:"timeout"`
+	// MaxRecvMsgSize and MaxSendMsgSize limit size of messages (0 means gRPC defaults).
+	MaxRecvMsgSize int `json:"max_recv_msg_size"`
+	MaxSendMsgSize int `json:"max_send_msg_size"`
 }
 
 // DefaultConfig defines default values for DataAvailabilityLayerClient configuration.
@@ -40,6 +53,18 @@ var DefaultConfig = Config{
 
 var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
 var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeightSubscriber = &DataAvailabilityLayerClient{}
+
+// NewClient creates DataAvailabilityLayerClient using established connection to gRPC server.
+//
+// Returned client doesn't need to be initialized nor started. Stop closes the connection.
+func NewClient(conn *grpc.ClientConn, logger log.Logger) *DataAvailabilityLayerClient {
+	return &DataAvailabilityLayerClient{
+		conn:   conn,
+		client: dalc.NewDALCServiceClient(conn),
+		logger: logger,
+	}
+}
 
 // Init sets the configuration options.
 func (d *DataAvailabilityLayerClient) Init(_ types.NamespaceID, config []byte, _ ds.Datastore, logger log.Logger) error {
@@ -53,11 +78,11 @@ func (d *DataAvailabilityLayerClient) Init(_ types.NamespaceID, config []byte, _
 
 // Start creates connection to gRPC server and instantiates gRPC client.
 func (d *DataAvailabilityLayerClient) Start() error {
-	d.logger.Info("starting GRPC DALC", "host", d.config.Host, "port", d.config.Port)
-	var err error
-	var opts []grpc.DialOption
-	// TODO(tzdybal): add more options
-	opts = append(opts, grpc.WithInsecure())
+	d.logger.Info("starting GRPC DALC", "host", d.config.Host, "port", d.config.Port, "tls", d.config.TLS != nil)
+	opts, err := d.config.dialOptions()
+	if err != nil {
+		return err
+	}
 	d.conn, err = grpc.Dial(d.config.Host+":"+strconv.Itoa(d.config.Port), opts...)
 	if err != nil {
 		return err
@@ -82,10 +107,12 @@ func (d *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *ty
 			BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()},
 		}
 	}
+	ctx, cancel := d.callContext(ctx)
+	defer cancel()
 	resp, err := d.client.SubmitBlock(ctx, &dalc.SubmitBlockRequest{Block: bp})
 	if err != nil {
 		return da.ResultSubmitBlock{
-			BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()},
+			BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()},
 		}
 	}
 	return da.ResultSubmitBlock{
@@ -94,14 +121,51 @@ func (d *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *ty
 			Message:  resp.Result.Message,
 			DAHeight: resp.Result.DAHeight,
 		},
+		Commitment: resp.Commitment,
+		Namespace:  resp.Namespace,
+		Fee:        resp.Fee,
+	}
+}
+
+// SubmitBlocks proxies SubmitBlocks request to gRPC server.
+func (d *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	bps := make([]*rollkit.Block, len(blocks))
+	for i := range blocks {
+		bp, err := blocks[i].ToProto()
+		if err != nil {
+			return da.ResultSubmitBlock{
+				BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()},
+			}
+		}
+		bps[i] = bp
+	}
+	ctx, cancel := d.callContext(ctx)
+	defer cancel()
+	resp, err := d.client.SubmitBlocks(ctx, &dalc.SubmitBlocksRequest{Blocks: bps})
+	if err != nil {
+		return da.ResultSubmitBlock{
+			BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()},
+		}
+	}
+	return da.ResultSubmitBlock{
+		BaseResult: da.BaseResult{
+			Code:     da.StatusCode(resp.Result.Code),
+			Message:  resp.Result.Message,
+			DAHeight: resp.Result.DAHeight,
+		},
+		Commitment: resp.Commitment,
+		Namespace:  resp.Namespace,
+		Fee:        resp.Fee,
 	}
 }
 
 // CheckBlockAvailability proxies CheckBlockAvailability request to gRPC server.
 func (d *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context, daHeight uint64) da.ResultCheckBlock {
+	ctx, cancel := d.callContext(ctx)
+	defer cancel()
 	resp, err := d.client.CheckBlockAvailability(ctx, &dalc.CheckBlockAvailabilityRequest{DAHeight: daHeight})
 	if err != nil {
-		return da.ResultCheckBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		return da.ResultCheckBlock{BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()}}
 	}
 	return da.ResultCheckBlock{
 		BaseResult:    da.BaseResult{Code: da.StatusCode(resp.Result.Code), Message: resp.Result.Message},
@@ -111,9 +175,11 @@ func (d *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context
 
 // RetrieveBlocks proxies RetrieveBlocks request to gRPC server.
 func (d *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, daHeight uint64) da.ResultRetrieveBlocks {
+	ctx, cancel := d.callContext(ctx)
+	defer cancel()
 	resp, err := d.client.RetrieveBlocks(ctx, &dalc.RetrieveBlocksRequest{DAHeight: daHeight})
 	if err != nil {
-		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: errorCode(err), Message: err.Error()}}
 	}
 
 	blocks := make([]*types.Block, len(resp.Blocks))
@@ -134,3 +200,51 @@ func (d *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, daHeig
 		Blocks: blocks,
 	}
 }
+
+// SubscribeHeights streams heights of new DA blocks from gRPC server.
+func (d *DataAvailabilityLayerClient) SubscribeHeights(ctx context.Context) (<-chan uint64, error) {
+	stream, err := d.client.SubscribeHeights(ctx, &dalc.SubscribeHeightsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	// server sends headers as soon as subscription is established
+	if _, err := stream.Header(); err != nil {
+		return nil, err
+	}
+
+	heights := make(chan uint64)
+	go func() {
+		defer close(heights)
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() == nil {
+					d.logger.Error("DA height subscription failed", "error", err)
+				}
+				return
+			}
+			select {
+			case heights <- resp.DAHeight:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return heights, nil
+}
+
+// callContext applies configured request timeout to ctx.
+func (d *DataAvailabilityLayerClient) callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if d.config.Timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, d.config.Timeout)
+}
+
+// errorCode translates gRPC error into DA status code.
+func errorCode(err error) da.StatusCode {
+	if status.Code(err) == codes.DeadlineExceeded {
+		return da.StatusTimeout
+	}
+	return da.StatusError
+}
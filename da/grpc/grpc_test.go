@@ -0,0 +1,258 @@
+package grpc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	"github.com/rollkit/rollkit/da"
+	grpcda "github.com/rollkit/rollkit/da/grpc"
+	"github.com/rollkit/rollkit/da/grpc/mockserv"
+	"github.com/rollkit/rollkit/log/test"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// certs contains paths to PEM files generated for a test.
+type certs struct {
+	caFile, serverCertFile, serverKeyFile, clientCertFile, clientKeyFile string
+	// rogue CA is not trusted by the server nor the client
+	rogueCAFile, rogueCertFile, rogueKeyFile string
+}
+
+func TestTLS(t *testing.T) {
+	c := generateCerts(t)
+	addr := startServer(t, grpcda.ServerConfig{
+		TLS: &grpcda.ServerTLSConfig{CertFile: c.serverCertFile, KeyFile: c.serverKeyFile},
+	})
+
+	assertWorks(t, dial(t, addr, grpcda.Config{TLS: &grpcda.TLSConfig{CAFile: c.caFile}}))
+	assertFails(t, dial(t, addr, grpcda.Config{}))
+	assertFails(t, dial(t, addr, grpcda.Config{TLS: &grpcda.TLSConfig{CAFile: c.rogueCAFile}}))
+	assertFails(t, dial(t, addr, grpcda.Config{TLS: &grpcda.TLSConfig{CAFile: c.caFile, ServerName: "example.com"}}))
+}
+
+func TestMutualTLS(t *testing.T) {
+	c := generateCerts(t)
+	addr := startServer(t, grpcda.ServerConfig{
+		TLS: &grpcda.ServerTLSConfig{CertFile: c.serverCertFile, KeyFile: c.serverKeyFile, ClientCAFile: c.caFile},
+	})
+
+	assertWorks(t, dial(t, addr, grpcda.Config{TLS: &grpcda.TLSConfig{
+		CAFile:   c.caFile,
+		CertFile: c.clientCertFile,
+		KeyFile:  c.clientKeyFile,
+	}}))
+	assertFails(t, dial(t, addr, grpcda.Config{TLS: &grpcda.TLSConfig{CAFile: c.caFile}}))
+	assertFails(t, dial(t, addr, grpcda.Config{TLS: &grpcda.TLSConfig{
+		CAFile:   c.caFile,
+		CertFile: c.rogueCertFile,
+		KeyFile:  c.rogueKeyFile,
+	}}))
+}
+
+func TestToken(t *testing.T) {
+	c := generateCerts(t)
+	addr := startServer(t, grpcda.ServerConfig{
+		TLS:   &grpcda.ServerTLSConfig{CertFile: c.serverCertFile, KeyFile: c.serverKeyFile},
+		Token: "secret",
+	})
+	tlsConf := &grpcda.TLSConfig{CAFile: c.caFile}
+
+	client := dial(t, addr, grpcda.Config{TLS: tlsConf, Token: "secret"})
+	assertWorks(t, client)
+	heights, err := client.SubscribeHeights(context.Background())
+	require.NoError(t, err)
+	<-heights
+
+	assertFails(t, dial(t, addr, grpcda.Config{TLS: tlsConf}))
+	client = dial(t, addr, grpcda.Config{TLS: tlsConf, Token: "wrong"})
+	assertFails(t, client)
+	heights, err = client.SubscribeHeights(context.Background())
+	if err == nil {
+		// subscription is rejected after it's opened
+		_, ok := <-heights
+		assert.False(t, ok)
+	}
+
+	// token is never sent over insecure connection
+	conf, _ := json.Marshal(grpcda.Config{Host: addr.IP.String(), Port: addr.Port, Token: "secret"})
+	dalc := &grpcda.DataAvailabilityLayerClient{}
+	require.NoError(t, dalc.Init(types.NamespaceID{}, conf, nil, test.NewLogger(t)))
+	assert.Error(t, dalc.Start())
+}
+
+func TestTimeoutAndKeepalive(t *testing.T) {
+	addr := startServer(t, grpcda.ServerConfig{
+		Keepalive: grpcda.ServerKeepaliveConfig{MinTime: 10 * time.Millisecond, PermitWithoutStream: true},
+	})
+
+	client := dial(t, addr, grpcda.Config{
+		Keepalive: grpcda.KeepaliveConfig{Time: 20 * time.Millisecond, Timeout: time.Second, PermitWithoutStream: true},
+		Timeout:   5 * time.Second,
+	})
+	assertWorks(t, client)
+	// connection survives keepalive pings
+	time.Sleep(100 * time.Millisecond)
+	assertWorks(t, client)
+
+	client = dial(t, addr, grpcda.Config{Timeout: time.Nanosecond})
+	res := client.SubmitBlock(context.Background(), getRandomBlock(1, 1))
+	assert.Equal(t, da.StatusTimeout, res.Code, res.Message)
+}
+
+func TestMessageSize(t *testing.T) {
+	addr := startServer(t, grpcda.ServerConfig{MaxRecvMsgSize: 4096})
+
+	client := dial(t, addr, grpcda.Config{})
+	assertWorks(t, client)
+	res := client.SubmitBlock(context.Background(), getRandomBlock(1, 100))
+	assert.Equal(t, da.StatusError, res.Code)
+
+	client = dial(t, addr, grpcda.Config{MaxSendMsgSize: 1024})
+	res = client.SubmitBlock(context.Background(), getRandomBlock(1, 20))
+	assert.Equal(t, da.StatusError, res.Code)
+}
+
+func startServer(t *testing.T, conf grpcda.ServerConfig) *net.TCPAddr {
+	t.Helper()
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(t, err)
+	srv, err := mockserv.GetServer(kv, conf, []byte("10ms"), tmlog.NewTMLogger(os.Stdout))
+	require.NoError(t, err)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() {
+		_ = srv.Serve(lis)
+	}()
+	t.Cleanup(srv.Stop)
+	return lis.Addr().(*net.TCPAddr)
+}
+
+func dial(t *testing.T, addr *net.TCPAddr, conf grpcda.Config) *grpcda.DataAvailabilityLayerClient {
+	t.Helper()
+	conf.Host = addr.IP.String()
+	conf.Port = addr.Port
+	rawConf, err := json.Marshal(conf)
+	require.NoError(t, err)
+	dalc := &grpcda.DataAvailabilityLayerClient{}
+	require.NoError(t, dalc.Init(types.NamespaceID{}, rawConf, nil, test.NewLogger(t)))
+	require.NoError(t, dalc.Start())
+	t.Cleanup(func() { _ = dalc.Stop() })
+	return dalc
+}
+
+func assertWorks(t *testing.T, dalc *grpcda.DataAvailabilityLayerClient) {
+	t.Helper()
+	res := dalc.SubmitBlock(context.Background(), getRandomBlock(1, 1))
+	assert.Equal(t, da.StatusSuccess, res.Code, res.Message)
+}
+
+func assertFails(t *testing.T, dalc *grpcda.DataAvailabilityLayerClient) {
+	t.Helper()
+	res := dalc.SubmitBlock(context.Background(), getRandomBlock(1, 1))
+	assert.Equal(t, da.StatusError, res.Code, res.Message)
+}
+
+func generateCerts(t *testing.T) certs {
+	t.Helper()
+	dir := t.TempDir()
+	ca, caKey := generateCA(t)
+	rogueCA, rogueCAKey := generateCA(t)
+	c := certs{
+		caFile:         writePEM(t, dir, "ca.pem", "CERTIFICATE", ca.Raw),
+		serverCertFile: filepath.Join(dir, "server.pem"),
+		serverKeyFile:  filepath.Join(dir, "server-key.pem"),
+		clientCertFile: filepath.Join(dir, "client.pem"),
+		clientKeyFile:  filepath.Join(dir, "client-key.pem"),
+		rogueCAFile:    writePEM(t, dir, "rogue-ca.pem", "CERTIFICATE", rogueCA.Raw),
+		rogueCertFile:  filepath.Join(dir, "rogue.pem"),
+		rogueKeyFile:   filepath.Join(dir, "rogue-key.pem"),
+	}
+	issueCert(t, ca, caKey, x509.ExtKeyUsageServerAuth, c.serverCertFile, c.serverKeyFile)
+	issueCert(t, ca, caKey, x509.ExtKeyUsageClientAuth, c.clientCertFile, c.clientKeyFile)
+	issueCert(t, rogueCA, rogueCAKey, x509.ExtKeyUsageClientAuth, c.rogueCertFile, c.rogueKeyFile)
+	return c
+}
+
+func generateCA(t *testing.T) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "rollkit test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func issueCert(t *testing.T, ca *x509.Certificate, caKey *ecdsa.PrivateKey, usage x509.ExtKeyUsage, certFile, keyFile string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+	writePEM(t, filepath.Dir(certFile), filepath.Base(certFile), "CERTIFICATE", der)
+	writePEM(t, filepath.Dir(keyFile), filepath.Base(keyFile), "EC PRIVATE KEY", keyDER)
+}
+
+func writePEM(t *testing.T, dir, name, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600))
+	return path
+}
+
+func getRandomBlock(height uint64, nTxs int) *types.Block {
+	block := &types.Block{}
+	block.SignedHeader.Header.BaseHeader.Height = height
+	block.SignedHeader.Header.AggregatorsHash = make([]byte, 32)
+	block.SignedHeader.Header.AppHash = getRandomBytes(32)
+	for i := 0; i < nTxs; i++ {
+		block.Data.Txs = append(block.Data.Txs, getRandomBytes(100))
+		block.Data.IntermediateStateRoots.RawRootsList = append(block.Data.IntermediateStateRoots.RawRootsList, getRandomBytes(32))
+	}
+	return block
+}
+
+func getRandomBytes(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data)
+	return data
+}
@@ -16,11 +16,24 @@ import (
 
 func main() {
 	conf := grpcda.DefaultConfig
+	var serverConf grpcda.ServerConfig
+	var tlsConf grpcda.ServerTLSConfig
 	logger := tmlog.NewTMLogger(os.Stdout)
 
 	flag.IntVar(&conf.Port, "port", conf.Port, "listening port")
 	flag.StringVar(&conf.Host, "host", "0.0.0.0", "listening address")
+	flag.StringVar(&tlsConf.CertFile, "tls_cert", "", "path to server certificate (enables TLS)")
+	flag.StringVar(&tlsConf.KeyFile, "tls_key", "", "path to server key")
+	flag.StringVar(&tlsConf.ClientCAFile, "tls_client_ca", "", "path to CA certificates used to verify client certificates (enables mutual TLS)")
+	flag.StringVar(&serverConf.Token, "token", "", "bearer token required from clients")
+	flag.DurationVar(&serverConf.Keepalive.MinTime, "keepalive_min_time", 0, "minimum time between client keepalive pings")
+	flag.BoolVar(&serverConf.Keepalive.PermitWithoutStream, "keepalive_permit_without_stream", false, "allow client keepalive pings without active RPCs")
+	flag.IntVar(&serverConf.MaxRecvMsgSize, "max_recv_msg_size", 0, "maximum size of received messages (0 means gRPC default)")
+	flag.IntVar(&serverConf.MaxSendMsgSize, "max_send_msg_size", 0, "maximum size of sent messages (0 means gRPC default)")
 	flag.Parse()
+	if tlsConf.CertFile != "" || tlsConf.KeyFile != "" {
+		serverConf.TLS = &tlsConf
+	}
 
 	kv, err := store.NewDefaultKVStore(".", "db", "rollkit")
 	if err != nil {
@@ -31,7 +44,10 @@ func main() {
 		log.Panic(err)
 	}
 	log.Println("Listening on:", lis.Addr())
-	srv := mockserv.GetServer(kv, conf, nil, logger)
+	srv, err := mockserv.GetServer(kv, serverConf, nil, logger)
+	if err != nil {
+		log.Panic(err)
+	}
 	if err := srv.Serve(lis); err != nil {
 		log.Println("error while serving:", err)
 	}
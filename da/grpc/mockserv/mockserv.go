@@ -2,6 +2,7 @@ package mockserv
 
 import (
 	"context"
+	"fmt"
 
 	ds "github.com/ipfs/go-datastore"
 	tmlog "github.com/tendermint/tendermint/libs/log"
@@ -15,21 +16,25 @@ import (
 )
 
 // GetServer creates and returns gRPC server instance.
-func GetServer(kv ds.Datastore, conf grpcda.Config, mockConfig []byte, logger tmlog.Logger) *grpc.Server {
-	srv := grpc.NewServer()
+//
+// Server options (TLS, authentication, keepalive, message size limits) are configured with conf.
+func GetServer(kv ds.Datastore, conf grpcda.ServerConfig, mockConfig []byte, logger tmlog.Logger) (*grpc.Server, error) {
+	opts, err := conf.ServerOptions()
+	if err != nil {
+		return nil, err
+	}
+	srv := grpc.NewServer(opts...)
 	mockImpl := &mockImpl{}
-	err := mockImpl.mock.Init([8]byte{}, mockConfig, kv, logger)
+	err = mockImpl.mock.Init([8]byte{}, mockConfig, kv, logger)
 	if err != nil {
-		logger.Error("failed to initialize mock DALC", "error", err)
-		panic(err)
+		return nil, fmt.Errorf("failed to initialize mock DALC: %w", err)
 	}
 	err = mockImpl.mock.Start()
 	if err != nil {
-		logger.Error("failed to start mock DALC", "error", err)
-		panic(err)
+		return nil, fmt.Errorf("failed to start mock DALC: %w", err)
 	}
 	dalc.RegisterDALCServiceServer(srv, mockImpl)
-	return srv
+	return srv, nil
 }
 
 type mockImpl struct {
@@ -49,6 +54,32 @@ func (m *mockImpl) SubmitBlock(ctx context.Context, request *dalc.SubmitBlockReq
 			Message:  resp.Message,
 			DAHeight: resp.DAHeight,
 		},
+		Commitment: resp.Commitment,
+		Namespace:  resp.Namespace,
+		Fee:        resp.Fee,
+	}, nil
+}
+
+func (m *mockImpl) SubmitBlocks(ctx context.Context, request *dalc.SubmitBlocksRequest) (*dalc.SubmitBlocksResponse, error) {
+	blocks := make([]*types.Block, len(request.Blocks))
+	for i := range request.Blocks {
+		var b types.Block
+		err := b.FromProto(request.Blocks[i])
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = &b
+	}
+	resp := m.mock.SubmitBlocks(ctx, blocks)
+	return &dalc.SubmitBlocksResponse{
+		Result: &dalc.DAResponse{
+			Code:     dalc.StatusCode(resp.Code),
+			Message:  resp.Message,
+			DAHeight: resp.DAHeight,
+		},
+		Commitment: resp.Commitment,
+		Namespace:  resp.Namespace,
+		Fee:        resp.Fee,
 	}, nil
 }
 
@@ -81,3 +112,20 @@ func (m *mockImpl) RetrieveBlocks(ctx context.Context, request *dalc.RetrieveBlo
 		Blocks: blocks,
 	}, nil
 }
+
+func (m *mockImpl) SubscribeHeights(_ *dalc.SubscribeHeightsRequest, stream dalc.DALCService_SubscribeHeightsServer) error {
+	heights, err := m.mock.SubscribeHeights(stream.Context())
+	if err != nil {
+		return err
+	}
+	// let the client know that subscription is established
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	for height := range heights {
+		if err := stream.Send(&dalc.SubscribeHeightsResponse{DAHeight: height}); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
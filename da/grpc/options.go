@@ -0,0 +1,245 @@
+package grpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const authorizationHeader = "authorization"
+
+// TLSConfig contains TLS options of gRPC client.
+type TLSConfig struct {
+	// CAFile is the path to PEM encoded CA certificates used to verify server certificate.
+	// If empty, system certificate pool is used.
+	CAFile string `json:"ca_file"`
+	// CertFile and KeyFile are paths to PEM encoded client certificate and key, used for mutual TLS.
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// ServerName overrides the host name used to verify server certificate.
+	ServerName string `json:"server_name"`
+}
+
+// KeepaliveConfig contains keepalive options of gRPC client.
+type KeepaliveConfig struct {
+	// Time after which client pings the server if there is no activity (0 disables keepalive pings).
+	Time time.Duration `json:"time"`
+	// Timeout for ping acknowledgement, after which connection is closed.
+	Timeout time.Duration `json:"timeout"`
+	// PermitWithoutStream allows pings when there are no active RPCs.
+	PermitWithoutStream bool `json:"permit_without_stream"`
+}
+
+// ServerTLSConfig contains TLS options of gRPC server.
+type ServerTLSConfig struct {
+	// CertFile and KeyFile are paths to PEM encoded server certificate and key.
+	CertFile string `json:"cert_file"`
+	KeyFile  string `json:"key_file"`
+	// ClientCAFile is the path to PEM encoded CA certificates. If set, clients are required to present certificates
+	// signed by one of them (mutual TLS).
+	ClientCAFile string `json:"client_ca_file"`
+}
+
+// ServerKeepaliveConfig contains keepalive options of gRPC server.
+type ServerKeepaliveConfig struct {
+	// Time after which server pings the client if there is no activity (0 means gRPC default).
+	Time time.Duration `json:"time"`
+	// Timeout for ping acknowledgement, after which connection is closed (0 means gRPC default).
+	Timeout time.Duration `json:"timeout"`
+	// MinTime is the minimum time clients should wait between pings (0 means gRPC default).
+	MinTime time.Duration `json:"min_time"`
+	// PermitWithoutStream allows client pings when there are no active RPCs.
+	PermitWithoutStream bool `json:"permit_without_stream"`
+}
+
+// ServerConfig contains options of gRPC server exposing DALCService API, matching options of the client.
+type ServerConfig struct {
+	// TLS enables TLS if set.
+	TLS *ServerTLSConfig `json:"tls"`
+	// Token is the bearer token required from clients (empty means no authentication).
+	Token     string                `json:"token"`
+	Keepalive ServerKeepaliveConfig `json:"keepalive"`
+	// MaxRecvMsgSize and MaxSendMsgSize limit size of messages (0 means gRPC defaults).
+	MaxRecvMsgSize int `json:"max_recv_msg_size"`
+	MaxSendMsgSize int `json:"max_send_msg_size"`
+}
+
+// dialOptions translates client configuration into gRPC dial options.
+func (c Config) dialOptions() ([]grpc.DialOption, error) {
+	if c.Token != "" && c.TLS == nil {
+		return nil, errors.New("bearer token requires TLS")
+	}
+	var opts []grpc.DialOption
+	if c.TLS != nil {
+		tlsConfig, err := c.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+	if c.Token != "" {
+		opts = append(opts, grpc.WithPerRPCCredentials(tokenCredentials(c.Token)))
+	}
+	if c.Keepalive.Time > 0 {
+		opts = append(opts, grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                c.Keepalive.Time,
+			Timeout:             c.Keepalive.Timeout,
+			PermitWithoutStream: c.Keepalive.PermitWithoutStream,
+		}))
+	}
+	var callOpts []grpc.CallOption
+	if c.MaxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(c.MaxSendMsgSize))
+	}
+	if len(callOpts) > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(callOpts...))
+	}
+	return opts, nil
+}
+
+func (c TLSConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: c.ServerName,
+	}
+	if c.CAFile != "" {
+		pool, err := loadCertPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// ServerOptions translates server configuration into gRPC server options.
+func (c ServerConfig) ServerOptions() ([]grpc.ServerOption, error) {
+	var opts []grpc.ServerOption
+	if c.TLS != nil {
+		tlsConfig, err := c.TLS.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+	if c.Token != "" {
+		auth := tokenAuthenticator(c.Token)
+		opts = append(opts, grpc.UnaryInterceptor(auth.unary), grpc.StreamInterceptor(auth.stream))
+	}
+	opts = append(opts,
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			Time:    c.Keepalive.Time,
+			Timeout: c.Keepalive.Timeout,
+		}),
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             c.Keepalive.MinTime,
+			PermitWithoutStream: c.Keepalive.PermitWithoutStream,
+		}),
+	)
+	if c.MaxRecvMsgSize > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(c.MaxRecvMsgSize))
+	}
+	if c.MaxSendMsgSize > 0 {
+		opts = append(opts, grpc.MaxSendMsgSize(c.MaxSendMsgSize))
+	}
+	return opts, nil
+}
+
+func (c ServerTLSConfig) tlsConfig() (*tls.Config, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, errors.New("server certificate and key are required for TLS")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+	if c.ClientCAFile != "" {
+		pool, err := loadCertPool(c.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return tlsConfig, nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(file) //nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CA certificates: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("no valid CA certificates in %s", file)
+	}
+	return pool, nil
+}
+
+// tokenCredentials attaches bearer token to every request.
+type tokenCredentials string
+
+var _ credentials.PerRPCCredentials = tokenCredentials("")
+
+func (t tokenCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{authorizationHeader: "Bearer " + string(t)}, nil
+}
+
+func (t tokenCredentials) RequireTransportSecurity() bool {
+	return true
+}
+
+// tokenAuthenticator rejects requests without valid bearer token.
+type tokenAuthenticator string
+
+func (t tokenAuthenticator) authenticate(ctx context.Context) error {
+	md, _ := metadata.FromIncomingContext(ctx)
+	for _, value := range md.Get(authorizationHeader) {
+		if subtle.ConstantTimeCompare([]byte(value), []byte("Bearer "+string(t))) == 1 {
+			return nil
+		}
+	}
+	return status.Error(codes.Unauthenticated, "invalid or missing bearer token")
+}
+
+func (t tokenAuthenticator) unary(ctx context.Context, req interface{}, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	if err := t.authenticate(ctx); err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (t tokenAuthenticator) stream(srv interface{}, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := t.authenticate(ss.Context()); err != nil {
+		return err
+	}
+	return handler(srv, ss)
+}
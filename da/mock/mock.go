@@ -2,8 +2,12 @@ package mock
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"math/rand"
+	"strconv"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -18,10 +22,17 @@ import (
 // DataAvailabilityLayerClient is intended only for usage in tests.
 // It does actually ensures DA - it stores data in-memory.
 type DataAvailabilityLayerClient struct {
-	logger   log.Logger
-	dalcKV   ds.Datastore
-	daHeight uint64
-	config   config
+	logger      log.Logger
+	dalcKV      ds.Datastore
+	daHeight    uint64
+	config      config
+	namespaceID types.NamespaceID
+
+	subscribersMtx sync.Mutex
+	subscribers    map[chan uint64]struct{}
+
+	// txsMtx guards appending of transaction batches to DA heights
+	txsMtx sync.Mutex
 }
 
 const defaultBlockTime = 3 * time.Second
@@ -32,12 +43,18 @@ type config struct {
 
 var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
 var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.BlockRangeRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeightSubscriber = &DataAvailabilityLayerClient{}
+var _ da.TxSubmitter = &DataAvailabilityLayerClient{}
+var _ da.TxRetriever = &DataAvailabilityLayerClient{}
 
 // Init is called once to allow DA client to read configuration and initialize resources.
-func (m *DataAvailabilityLayerClient) Init(_ types.NamespaceID, config []byte, dalcKV ds.Datastore, logger log.Logger) error {
+func (m *DataAvailabilityLayerClient) Init(namespaceID types.NamespaceID, config []byte, dalcKV ds.Datastore, logger log.Logger) error {
 	m.logger = logger
+	m.namespaceID = namespaceID
 	m.dalcKV = dalcKV
 	m.daHeight = 1
+	m.subscribers = make(map[chan uint64]struct{})
 	if len(config) > 0 {
 		var err error
 		m.config.BlockTime, err = time.ParseDuration(string(config))
@@ -72,23 +89,39 @@ func (m *DataAvailabilityLayerClient) Stop() error {
 // This should create a transaction which (potentially)
 // triggers a state transition in the DA layer.
 func (m *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	return m.SubmitBlocks(ctx, []*types.Block{block})
+}
+
+// SubmitBlocks submits the passed in blocks to the DA layer.
+// All blocks are stored at the same DA height.
+func (m *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
 	daHeight := atomic.LoadUint64(&m.daHeight)
-	m.logger.Debug("Submitting block to DA layer!", "height", block.SignedHeader.Header.Height(), "dataLayerHeight", daHeight)
 
-	hash := block.SignedHeader.Header.Hash()
-	blob, err := block.MarshalBinary()
-	if err != nil {
-		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
-	}
+	// commitment is a hash of all submitted blocks
+	commitment := sha256.New()
+	for _, block := range blocks {
+		m.logger.Debug("Submitting block to DA layer!", "height", block.SignedHeader.Header.Height(), "dataLayerHeight", daHeight)
 
-	err = m.dalcKV.Put(ctx, getKey(daHeight, uint64(block.SignedHeader.Header.Height())), hash[:])
-	if err != nil {
-		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
-	}
+		hash := block.SignedHeader.Header.Hash()
+		blob, err := block.MarshalBinary()
+		if err != nil {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		blob, err = types.CompressBlob(blob, types.CodecZstd)
+		if err != nil {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		commitment.Write(blob)
 
-	err = m.dalcKV.Put(ctx, ds.NewKey(hex.EncodeToString(hash[:])), blob)
-	if err != nil {
-		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		err = m.dalcKV.Put(ctx, getKey(daHeight, uint64(block.SignedHeader.Header.Height())), hash[:])
+		if err != nil {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
+
+		err = m.dalcKV.Put(ctx, ds.NewKey(hex.EncodeToString(hash[:])), blob)
+		if err != nil {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
 	}
 
 	return da.ResultSubmitBlock{
@@ -97,6 +130,8 @@ func (m *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *ty
 			Message:  "OK",
 			DAHeight: daHeight,
 		},
+		Commitment: commitment.Sum(nil),
+		Namespace:  m.namespaceID[:],
 	}
 }
 
@@ -109,7 +144,7 @@ func (m *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context
 // RetrieveBlocks returns block at given height from data availability layer.
 func (m *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, daHeight uint64) da.ResultRetrieveBlocks {
 	if daHeight >= atomic.LoadUint64(&m.daHeight) {
-		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusError, Message: "block not found"}}
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusHeightFromFuture, Message: "block not found"}}
 	}
 
 	results, err := store.PrefixEntries(ctx, m.dalcKV, getPrefix(daHeight))
@@ -124,6 +159,11 @@ func (m *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, daHeig
 			return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
 		}
 
+		blob, err = types.DecompressBlob(blob)
+		if err != nil {
+			return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
+
 		block := &types.Block{}
 		err = block.UnmarshalBinary(blob)
 		if err != nil {
@@ -132,7 +172,138 @@ func (m *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, daHeig
 		blocks = append(blocks, block)
 	}
 
-	return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusSuccess}, Blocks: blocks}
+	if len(blocks) == 0 {
+		return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusNamespaceEmpty, DAHeight: daHeight}}
+	}
+	return da.ResultRetrieveBlocks{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: daHeight}, Blocks: blocks}
+}
+
+// SubmitTxs submits the passed in transactions to the DA layer. Transactions submitted to the same DA height are
+// retrieved in order of submission.
+func (m *DataAvailabilityLayerClient) SubmitTxs(ctx context.Context, txs types.Txs) da.ResultSubmitBlock {
+	blob, err := types.MarshalTxBatch(txs)
+	if err != nil {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+
+	m.txsMtx.Lock()
+	defer m.txsMtx.Unlock()
+	daHeight := atomic.LoadUint64(&m.daHeight)
+	key := getTxsKey(daHeight)
+	stored, err := m.dalcKV.Get(ctx, key)
+	if err != nil && !errors.Is(err, ds.ErrNotFound) {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	// batches from the same DA height are merged, in order of submission
+	if len(stored) > 0 {
+		prev, err := types.UnmarshalTxBatch(stored)
+		if err != nil {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
+		if stored, err = types.MarshalTxBatch(append(prev, txs...)); err != nil {
+			return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+		}
+	} else {
+		stored = blob
+	}
+	if err := m.dalcKV.Put(ctx, key, stored); err != nil {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+
+	commitment := sha256.Sum256(blob)
+	return da.ResultSubmitBlock{
+		BaseResult: da.BaseResult{
+			Code:     da.StatusSuccess,
+			Message:  "OK",
+			DAHeight: daHeight,
+		},
+		Commitment: commitment[:],
+		Namespace:  m.namespaceID[:],
+	}
+}
+
+// RetrieveTxs returns transactions submitted at given height to data availability layer.
+func (m *DataAvailabilityLayerClient) RetrieveTxs(ctx context.Context, daHeight uint64) da.ResultRetrieveTxs {
+	if daHeight >= atomic.LoadUint64(&m.daHeight) {
+		return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: da.StatusHeightFromFuture, Message: "block not found"}}
+	}
+
+	blob, err := m.dalcKV.Get(ctx, getTxsKey(daHeight))
+	if errors.Is(err, ds.ErrNotFound) {
+		return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: da.StatusNamespaceEmpty, DAHeight: daHeight}}
+	}
+	if err != nil {
+		return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	txs, err := types.UnmarshalTxBatch(blob)
+	if err != nil {
+		return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: da.StatusError, Message: err.Error()}}
+	}
+	return da.ResultRetrieveTxs{BaseResult: da.BaseResult{Code: da.StatusSuccess, DAHeight: daHeight}, Txs: txs}
+}
+
+// RetrieveBlocksRange returns blocks from range of heights from data availability layer.
+// Retrieval stops at first height that can't be retrieved.
+func (m *DataAvailabilityLayerClient) RetrieveBlocksRange(ctx context.Context, from, to uint64) []da.ResultRetrieveBlocks {
+	var results []da.ResultRetrieveBlocks
+	for daHeight := from; daHeight <= to; daHeight++ {
+		res := m.RetrieveBlocks(ctx, daHeight)
+		res.DAHeight = daHeight
+		results = append(results, res)
+		if res.Code != da.StatusSuccess && res.Code != da.StatusNamespaceEmpty {
+			break
+		}
+	}
+	return results
+}
+
+// SubscribeHeights returns a channel receiving heights of new DA blocks, as they are produced.
+func (m *DataAvailabilityLayerClient) SubscribeHeights(ctx context.Context) (<-chan uint64, error) {
+	// buffer is arbitrary; heights are dropped if subscriber is too slow
+	heights := make(chan uint64, 16)
+	m.subscribersMtx.Lock()
+	m.subscribers[heights] = struct{}{}
+	m.subscribersMtx.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		m.subscribersMtx.Lock()
+		delete(m.subscribers, heights)
+		close(heights)
+		m.subscribersMtx.Unlock()
+	}()
+	return heights, nil
+}
+
+// Reorg simulates DA layer reorganization. Blocks are removed from DA heights starting at fromHeight, and included
+// again at current DA height (so they are available for retrieval after next DA block is produced).
+func (m *DataAvailabilityLayerClient) Reorg(ctx context.Context, fromHeight uint64) error {
+	head := atomic.LoadUint64(&m.daHeight)
+	m.logger.Debug("Simulating DA reorg", "fromHeight", fromHeight, "toHeight", head)
+	for daHeight := fromHeight; daHeight < head; daHeight++ {
+		results, err := store.PrefixEntries(ctx, m.dalcKV, getPrefix(daHeight))
+		if err != nil {
+			return err
+		}
+		entries, err := results.Rest()
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			key := ds.NewKey(entry.Key)
+			height, err := strconv.ParseUint(key.BaseNamespace(), 10, 64)
+			if err != nil {
+				return err
+			}
+			if err := m.dalcKV.Delete(ctx, key); err != nil {
+				return err
+			}
+			if err := m.dalcKV.Put(ctx, getKey(head, height), entry.Value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
 }
 
 func getPrefix(daHeight uint64) string {
@@ -143,7 +314,22 @@ func getKey(daHeight uint64, height uint64) ds.Key {
 	return ds.NewKey(store.GenerateKey([]interface{}{daHeight, height}))
 }
 
+// getTxsKey returns key of transactions submitted at given DA height. It's disjoint with keys of blocks.
+func getTxsKey(daHeight uint64) ds.Key {
+	return ds.NewKey(store.GenerateKey([]interface{}{"txs", daHeight}))
+}
+
 func (m *DataAvailabilityLayerClient) updateDAHeight() {
 	blockStep := rand.Uint64()%10 + 1 //nolint:gosec
-	atomic.AddUint64(&m.daHeight, blockStep)
+	// all heights lower than daHeight are available for retrieval
+	produced := atomic.AddUint64(&m.daHeight, blockStep) - 1
+
+	m.subscribersMtx.Lock()
+	defer m.subscribersMtx.Unlock()
+	for heights := range m.subscribers {
+		select {
+		case heights <- produced:
+		default:
+		}
+	}
 }
@@ -0,0 +1,423 @@
+package multi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	ds "github.com/ipfs/go-datastore"
+	ktds "github.com/ipfs/go-datastore/keytransform"
+	"go.uber.org/multierr"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+// ErrNoClients is returned when multi DA client is used without any underlying clients configured.
+var ErrNoClients = errors.New("no underlying data availability layer clients configured")
+
+// Config stores multi DA client configuration parameters.
+type Config struct {
+	// Clients are the underlying DA clients. Order of clients determines order of retrieval attempts.
+	Clients []da.ClientConfig `json:"clients"`
+	// Quorum is the number of clients that have to accept the submission. Zero means all clients.
+	Quorum int `json:"quorum"`
+}
+
+type client struct {
+	name string
+	da.DataAvailabilityLayerClient
+}
+
+// DataAvailabilityLayerClient posts blocks to multiple DA layers.
+//
+// Submission succeeds when at least Quorum clients accept the blocks. DA height, commitment and namespace of the
+// successful submission are taken from the first (in configuration order) client that accepted the blocks; fee is the
+// total fee paid to all DA layers. If quorum is not reached, clients that accepted the blocks are remembered, so the
+// blocks are resubmitted only to the remaining clients.
+// Retrieval falls through the clients in configuration order, only if retrieval from the client failed.
+//
+// DA heights are used as-is for every client, so retrieval fall-through is meaningful only for clients sharing the
+// height space (for example, multiple endpoints of the same DA network). For unrelated DA layers, heights of the
+// first client are authoritative.
+type DataAvailabilityLayerClient struct {
+	getClient da.GetClientFunc
+
+	config  Config
+	clients []client
+	logger  log.Logger
+
+	mtx sync.Mutex
+	// accepted contains results of clients that accepted the batch, for batches that didn't reach quorum yet
+	accepted      map[string]map[int]da.ResultSubmitBlock
+	acceptedOrder []string
+}
+
+// maxTrackedBatches is the number of batches that didn't reach quorum, for which accepting clients are remembered.
+const maxTrackedBatches = 64
+
+var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
+var _ da.Wrapper = &DataAvailabilityLayerClient{}
+var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.BlockRangeRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeaderRetriever = &DataAvailabilityLayerClient{}
+var _ da.TxRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeightSubscriber = &DataAvailabilityLayerClient{}
+var _ da.FeeBumper = &DataAvailabilityLayerClient{}
+
+// NewDataAvailabilityLayerClient creates multi DA client, that uses getClient to instantiate underlying clients.
+func NewDataAvailabilityLayerClient(getClient da.GetClientFunc) *DataAvailabilityLayerClient {
+	return &DataAvailabilityLayerClient{getClient: getClient}
+}
+
+// Init parses configuration and initializes all underlying clients.
+// Every client gets a separate, prefixed view of kvStore.
+func (m *DataAvailabilityLayerClient) Init(namespaceID types.NamespaceID, config []byte, kvStore ds.Datastore, logger log.Logger) error {
+	m.logger = logger
+	m.accepted = make(map[string]map[int]da.ResultSubmitBlock)
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &m.config); err != nil {
+			return err
+		}
+	}
+	if m.config.Quorum == 0 {
+		m.config.Quorum = len(m.config.Clients)
+	}
+	if m.config.Quorum < 0 || m.config.Quorum > len(m.config.Clients) {
+		return fmt.Errorf("invalid quorum %d for %d clients", m.config.Quorum, len(m.config.Clients))
+	}
+
+	m.clients = make([]client, len(m.config.Clients))
+	for i, conf := range m.config.Clients {
+		dalc := m.getClient(conf.Name)
+		if dalc == nil {
+			return fmt.Errorf("couldn't get data availability client named '%s'", conf.Name)
+		}
+		var clientKV ds.Datastore
+		if kvStore != nil {
+			clientKV = ktds.Wrap(kvStore, ktds.PrefixTransform{Prefix: ds.NewKey(strconv.Itoa(i))})
+		}
+		err := dalc.Init(namespaceID, conf.InitConfig(), clientKV, logger)
+		if err != nil {
+			return fmt.Errorf("failed to initialize data availability client '%s': %w", conf.Name, err)
+		}
+		m.clients[i] = client{name: conf.Name, DataAvailabilityLayerClient: dalc}
+	}
+	return nil
+}
+
+// Unwrap returns underlying clients.
+func (m *DataAvailabilityLayerClient) Unwrap() []da.DataAvailabilityLayerClient {
+	clients := make([]da.DataAvailabilityLayerClient, len(m.clients))
+	for i := range m.clients {
+		clients[i] = m.clients[i].DataAvailabilityLayerClient
+	}
+	return clients
+}
+
+// Start starts all underlying clients.
+func (m *DataAvailabilityLayerClient) Start() error {
+	m.logger.Info("starting multi Data Availability Layer Client", "clients", len(m.clients), "quorum", m.config.Quorum)
+	for i := range m.clients {
+		if err := m.clients[i].Start(); err != nil {
+			for j := 0; j < i; j++ {
+				_ = m.clients[j].Stop()
+			}
+			return fmt.Errorf("failed to start data availability client '%s': %w", m.clients[i].name, err)
+		}
+	}
+	return nil
+}
+
+// Stop stops all underlying clients.
+func (m *DataAvailabilityLayerClient) Stop() error {
+	m.logger.Info("stopping multi Data Availability Layer Client")
+	var err error
+	for i := range m.clients {
+		err = multierr.Append(err, m.clients[i].Stop())
+	}
+	return err
+}
+
+// SubmitBlock submits the block to all underlying clients.
+func (m *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	return m.submit(batchKey([]*types.Block{block}), func(c client) da.ResultSubmitBlock {
+		return c.SubmitBlock(ctx, block)
+	})
+}
+
+// SubmitBlocks submits the blocks to all underlying clients.
+func (m *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	return m.submit(batchKey(blocks), func(c client) da.ResultSubmitBlock {
+		return c.SubmitBlocks(ctx, blocks)
+	})
+}
+
+// batchKey identifies submitted blocks.
+func batchKey(blocks []*types.Block) string {
+	h := sha256.New()
+	for _, b := range blocks {
+		h.Write(b.Hash())
+	}
+	return string(h.Sum(nil))
+}
+
+func (m *DataAvailabilityLayerClient) submit(key string, submitFn func(c client) da.ResultSubmitBlock) da.ResultSubmitBlock {
+	if len(m.clients) == 0 {
+		return da.ResultSubmitBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: ErrNoClients.Error()}}
+	}
+
+	// clients that already accepted the blocks are not paid again
+	m.mtx.Lock()
+	previous := m.accepted[key]
+	m.mtx.Unlock()
+
+	results := make([]da.ResultSubmitBlock, len(m.clients))
+	submitted := make([]bool, len(m.clients))
+	var wg sync.WaitGroup
+	for i := range m.clients {
+		if res, ok := previous[i]; ok {
+			results[i] = res
+			continue
+		}
+		submitted[i] = true
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i] = submitFn(m.clients[i])
+		}(i)
+	}
+	wg.Wait()
+
+	var accepted []int
+	var failures []string
+	// failure code is passed to the caller only if all clients failed for the same reason
+	failureCode := da.StatusUnknown
+	for i, res := range results {
+		if res.Code == da.StatusSuccess || res.Code == da.StatusAlreadyIncluded {
+			accepted = append(accepted, i)
+			continue
+		}
+		m.logger.Debug("submission failed", "client", m.clients[i].name, "index", i, "code", res.Code, "error", res.Message)
+		failures = append(failures, fmt.Sprintf("%s: %s", m.clients[i].name, res.Message))
+		if failureCode == da.StatusUnknown {
+			failureCode = res.Code
+		} else if failureCode != res.Code {
+			failureCode = da.StatusError
+		}
+	}
+	// fee is paid to every DA layer the blocks were submitted to
+	var fee uint64
+	for i, r := range results {
+		if submitted[i] {
+			fee += r.Fee
+		}
+	}
+
+	if len(accepted) < m.config.Quorum {
+		m.rememberAccepted(key, accepted, results)
+		if failureCode == da.StatusUnknown {
+			failureCode = da.StatusError
+		}
+		return da.ResultSubmitBlock{
+			BaseResult: da.BaseResult{
+				Code:    failureCode,
+				Message: fmt.Sprintf("quorum not reached (%d of %d accepted): %s", len(accepted), m.config.Quorum, strings.Join(failures, "; ")),
+			},
+			Fee: fee,
+		}
+	}
+	m.forgetAccepted(key)
+
+	res := results[accepted[0]]
+	res.Message = fmt.Sprintf("accepted by %d of %d clients", len(accepted), len(m.clients))
+	res.Fee = fee
+	return res
+}
+
+// rememberAccepted records results of clients that accepted the batch, that didn't reach quorum.
+func (m *DataAvailabilityLayerClient) rememberAccepted(key string, accepted []int, results []da.ResultSubmitBlock) {
+	if len(accepted) == 0 {
+		return
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.accepted[key]; !ok {
+		m.acceptedOrder = append(m.acceptedOrder, key)
+		if len(m.acceptedOrder) > maxTrackedBatches {
+			delete(m.accepted, m.acceptedOrder[0])
+			m.acceptedOrder = m.acceptedOrder[1:]
+		}
+	}
+	byClient := make(map[int]da.ResultSubmitBlock, len(accepted))
+	for _, i := range accepted {
+		byClient[i] = results[i]
+	}
+	m.accepted[key] = byClient
+}
+
+func (m *DataAvailabilityLayerClient) forgetAccepted(key string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if _, ok := m.accepted[key]; !ok {
+		return
+	}
+	delete(m.accepted, key)
+	for i := range m.acceptedOrder {
+		if m.acceptedOrder[i] == key {
+			m.acceptedOrder = append(m.acceptedOrder[:i], m.acceptedOrder[i+1:]...)
+			break
+		}
+	}
+}
+
+// BumpFee bumps fees of all underlying clients that pay fees for submissions.
+// It returns true if fee of any of the clients was increased.
+func (m *DataAvailabilityLayerClient) BumpFee() bool {
+	bumped := false
+	for i := range m.clients {
+		if bumper, ok := m.clients[i].DataAvailabilityLayerClient.(da.FeeBumper); ok {
+			bumped = bumper.BumpFee() || bumped
+		}
+	}
+	return bumped
+}
+
+// CheckBlockAvailability checks data availability in underlying clients, in order, until data is available.
+// If data is not available in any of the clients, first successful (possibly StatusNamespaceEmpty) result is returned.
+func (m *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context, dataLayerHeight uint64) da.ResultCheckBlock {
+	var successful *da.ResultCheckBlock
+	res := da.ResultCheckBlock{BaseResult: da.BaseResult{Code: da.StatusError, Message: ErrNoClients.Error()}}
+	for i := range m.clients {
+		res = m.clients[i].CheckBlockAvailability(ctx, dataLayerHeight)
+		if res.Code != da.StatusSuccess && res.Code != da.StatusNamespaceEmpty {
+			continue
+		}
+		if res.DataAvailable {
+			return res
+		}
+		if successful == nil {
+			first := res
+			successful = &first
+		}
+	}
+	if successful != nil {
+		return *successful
+	}
+	return res
+}
+
+// RetrieveBlocks retrieves blocks from underlying clients, in order, until retrieval succeeds.
+// Retrieval falls through to the next client only on errors; successful (possibly empty) result, and
+// StatusHeightFromFuture are returned as-is, so DA heights are never skipped because of lagging client.
+func (m *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveBlocks {
+	return m.retrieveBlocks(ctx, 0, dataLayerHeight)
+}
+
+func (m *DataAvailabilityLayerClient) retrieveBlocks(ctx context.Context, first int, dataLayerHeight uint64) da.ResultRetrieveBlocks {
+	var res da.ResultRetrieveBlocks
+	res.BaseResult = m.retrieve(first, dataLayerHeight, "block retrieval", func(c client) (da.BaseResult, bool) {
+		retriever, ok := c.DataAvailabilityLayerClient.(da.BlockRetriever)
+		if ok {
+			res = retriever.RetrieveBlocks(ctx, dataLayerHeight)
+		}
+		return res.BaseResult, ok
+	})
+	return res
+}
+
+// RetrieveBlocksRange retrieves blocks from range of DA heights from the first client. Heights that failed, are
+// retrieved from the remaining clients.
+func (m *DataAvailabilityLayerClient) RetrieveBlocksRange(ctx context.Context, from, to uint64) []da.ResultRetrieveBlocks {
+	if len(m.clients) == 0 {
+		return []da.ResultRetrieveBlocks{{BaseResult: da.BaseResult{Code: da.StatusError, Message: ErrNoClients.Error(), DAHeight: from}}}
+	}
+	retriever, ok := m.clients[0].DataAvailabilityLayerClient.(da.BlockRangeRetriever)
+	if !ok {
+		res := da.ResultRetrieveBlocks{BaseResult: da.NotSupported("range retrieval", m.clients[0].name)}
+		res.DAHeight = from
+		return []da.ResultRetrieveBlocks{res}
+	}
+	results := retriever.RetrieveBlocksRange(ctx, from, to)
+	for i := range results {
+		if failed(results[i].Code) && len(m.clients) > 1 {
+			daHeight := results[i].DAHeight
+			results[i] = m.retrieveBlocks(ctx, 1, daHeight)
+			results[i].DAHeight = daHeight
+		}
+	}
+	return results
+}
+
+// RetrieveHeaders retrieves signed headers from underlying clients, in order, until retrieval succeeds.
+func (m *DataAvailabilityLayerClient) RetrieveHeaders(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveHeaders {
+	var res da.ResultRetrieveHeaders
+	res.BaseResult = m.retrieve(0, dataLayerHeight, "header retrieval", func(c client) (da.BaseResult, bool) {
+		retriever, ok := c.DataAvailabilityLayerClient.(da.HeaderRetriever)
+		if ok {
+			res = retriever.RetrieveHeaders(ctx, dataLayerHeight)
+		}
+		return res.BaseResult, ok
+	})
+	return res
+}
+
+// RetrieveTxs retrieves transactions from underlying clients, in order, until retrieval succeeds.
+func (m *DataAvailabilityLayerClient) RetrieveTxs(ctx context.Context, dataLayerHeight uint64) da.ResultRetrieveTxs {
+	var res da.ResultRetrieveTxs
+	res.BaseResult = m.retrieve(0, dataLayerHeight, "transaction retrieval", func(c client) (da.BaseResult, bool) {
+		retriever, ok := c.DataAvailabilityLayerClient.(da.TxRetriever)
+		if ok {
+			res = retriever.RetrieveTxs(ctx, dataLayerHeight)
+		}
+		return res.BaseResult, ok
+	})
+	return res
+}
+
+// SubscribeHeights subscribes to new DA heights of underlying clients, in order, until subscription succeeds.
+func (m *DataAvailabilityLayerClient) SubscribeHeights(ctx context.Context) (<-chan uint64, error) {
+	err := ErrNoClients
+	for i := range m.clients {
+		subscriber, ok := m.clients[i].DataAvailabilityLayerClient.(da.HeightSubscriber)
+		if !ok {
+			return nil, errors.New(da.NotSupported("height subscription", m.clients[i].name).Message)
+		}
+		var heights <-chan uint64
+		heights, err = subscriber.SubscribeHeights(ctx)
+		if err == nil {
+			return heights, nil
+		}
+		m.logger.Debug("subscription failed", "client", m.clients[i].name, "index", i, "error", err)
+	}
+	return nil, err
+}
+
+// retrieve executes request with clients, starting from client with index first, until request doesn't fail.
+// Request returns false if client doesn't support it.
+func (m *DataAvailabilityLayerClient) retrieve(first int, dataLayerHeight uint64, feature string, request func(c client) (da.BaseResult, bool)) da.BaseResult {
+	res := da.BaseResult{Code: da.StatusError, Message: ErrNoClients.Error()}
+	for i := first; i < len(m.clients); i++ {
+		var ok bool
+		res, ok = request(m.clients[i])
+		if !ok {
+			res = da.NotSupported(feature, m.clients[i].name)
+		}
+		if !failed(res.Code) {
+			return res
+		}
+		m.logger.Debug("retrieval failed", "client", m.clients[i].name, "index", i, "daHeight", dataLayerHeight, "error", res.Message)
+	}
+	return res
+}
+
+// failed returns true if request failed, and can be retried with another client.
+func failed(code da.StatusCode) bool {
+	return code != da.StatusSuccess && code != da.StatusNamespaceEmpty && code != da.StatusHeightFromFuture
+}
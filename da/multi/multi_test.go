@@ -0,0 +1,147 @@
+package multi
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"testing"
+
+	ds "github.com/ipfs/go-datastore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/log/test"
+	"github.com/rollkit/rollkit/types"
+)
+
+// testClient returns results with configured status code.
+type testClient struct {
+	code        uint64
+	submissions uint64
+}
+
+func (t *testClient) Init(types.NamespaceID, []byte, ds.Datastore, log.Logger) error { return nil }
+func (t *testClient) Start() error                                                   { return nil }
+func (t *testClient) Stop() error                                                    { return nil }
+
+func (t *testClient) result() da.BaseResult {
+	return da.BaseResult{Code: da.StatusCode(atomic.LoadUint64(&t.code)), DAHeight: 1}
+}
+
+func (t *testClient) setCode(code da.StatusCode) {
+	atomic.StoreUint64(&t.code, uint64(code))
+}
+
+func (t *testClient) SubmitBlock(context.Context, *types.Block) da.ResultSubmitBlock {
+	atomic.AddUint64(&t.submissions, 1)
+	return da.ResultSubmitBlock{BaseResult: t.result(), Fee: 10}
+}
+
+func (t *testClient) SubmitBlocks(context.Context, []*types.Block) da.ResultSubmitBlock {
+	atomic.AddUint64(&t.submissions, 1)
+	return da.ResultSubmitBlock{BaseResult: t.result(), Fee: 10}
+}
+
+func (t *testClient) CheckBlockAvailability(context.Context, uint64) da.ResultCheckBlock {
+	return da.ResultCheckBlock{BaseResult: t.result()}
+}
+
+func (t *testClient) RetrieveBlocks(context.Context, uint64) da.ResultRetrieveBlocks {
+	res := da.ResultRetrieveBlocks{BaseResult: t.result()}
+	if res.Code == da.StatusSuccess {
+		res.Blocks = []*types.Block{{}}
+	}
+	return res
+}
+
+// testTxClient additionally supports transaction retrieval.
+type testTxClient struct {
+	testClient
+}
+
+func (t *testTxClient) RetrieveTxs(context.Context, uint64) da.ResultRetrieveTxs {
+	return da.ResultRetrieveTxs{BaseResult: t.result()}
+}
+
+func newTestClient(t *testing.T, clients map[string]da.DataAvailabilityLayerClient, names []string, quorum int) *DataAvailabilityLayerClient {
+	t.Helper()
+	config := Config{Quorum: quorum}
+	for _, name := range names {
+		config.Clients = append(config.Clients, da.ClientConfig{Name: name})
+	}
+	conf, err := json.Marshal(config)
+	require.NoError(t, err)
+	m := NewDataAvailabilityLayerClient(func(name string) da.DataAvailabilityLayerClient { return clients[name] })
+	require.NoError(t, m.Init(types.NamespaceID{}, conf, nil, test.NewLogger(t)))
+	return m
+}
+
+func TestResubmitOnlyToFailedClients(t *testing.T) {
+	assert := assert.New(t)
+	ctx := context.Background()
+
+	first := &testClient{code: uint64(da.StatusSuccess)}
+	second := &testClient{code: uint64(da.StatusError)}
+	m := newTestClient(t, map[string]da.DataAvailabilityLayerClient{"first": first, "second": second}, []string{"first", "second"}, 2)
+
+	block := &types.Block{}
+	res := m.SubmitBlock(ctx, block)
+	assert.Equal(da.StatusError, res.Code)
+	assert.Equal(uint64(20), res.Fee)
+
+	// first client accepted the block, so it's not submitted again
+	second.setCode(da.StatusSuccess)
+	res = m.SubmitBlock(ctx, block)
+	assert.Equal(da.StatusSuccess, res.Code, res.Message)
+	assert.Equal(uint64(10), res.Fee)
+	assert.Equal(uint64(1), atomic.LoadUint64(&first.submissions))
+	assert.Equal(uint64(2), atomic.LoadUint64(&second.submissions))
+
+	// after quorum is reached, blocks are forgotten
+	res = m.SubmitBlock(ctx, block)
+	assert.Equal(da.StatusSuccess, res.Code, res.Message)
+	assert.Equal(uint64(2), atomic.LoadUint64(&first.submissions))
+}
+
+func TestRetrievalFallThrough(t *testing.T) {
+	cases := []struct {
+		name     string
+		first    da.StatusCode
+		expected da.StatusCode
+		blocks   int
+	}{
+		{"error falls through", da.StatusError, da.StatusSuccess, 1},
+		{"timeout falls through", da.StatusTimeout, da.StatusSuccess, 1},
+		{"height from future is returned", da.StatusHeightFromFuture, da.StatusHeightFromFuture, 0},
+		{"empty namespace is returned", da.StatusNamespaceEmpty, da.StatusNamespaceEmpty, 0},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			first := &testClient{code: uint64(c.first)}
+			second := &testClient{code: uint64(da.StatusSuccess)}
+			m := newTestClient(t, map[string]da.DataAvailabilityLayerClient{"first": first, "second": second}, []string{"first", "second"}, 1)
+			res := m.RetrieveBlocks(context.Background(), 1)
+			assert.Equal(t, c.expected, res.Code)
+			assert.Len(t, res.Blocks, c.blocks)
+		})
+	}
+}
+
+func TestOptionalInterfaces(t *testing.T) {
+	assert := assert.New(t)
+	clients := map[string]da.DataAvailabilityLayerClient{
+		"plain": &testClient{code: uint64(da.StatusSuccess)},
+		"txs1":  &testTxClient{testClient{code: uint64(da.StatusSuccess)}},
+		"txs2":  &testTxClient{testClient{code: uint64(da.StatusSuccess)}},
+	}
+
+	m := newTestClient(t, clients, []string{"txs1", "txs2"}, 1)
+	assert.True(da.Implements[da.TxRetriever](m))
+	assert.False(da.Implements[da.HeaderRetriever](m))
+	assert.Equal(da.StatusSuccess, m.RetrieveTxs(context.Background(), 1).Code)
+
+	m = newTestClient(t, clients, []string{"txs1", "plain"}, 1)
+	assert.False(da.Implements[da.TxRetriever](m))
+}
@@ -0,0 +1,25 @@
+// Command mockplugin serves mock DA client as an out-of-process DA plugin. It's an example for authors of DA plugins.
+//
+// Usage: --rollkit.da_plugins="mockplugin=/path/to/mockplugin" --rollkit.da_layer=mockplugin
+package main
+
+import (
+	"log"
+	"os"
+
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	"github.com/rollkit/rollkit/da/mock"
+	"github.com/rollkit/rollkit/da/plugin"
+	"github.com/rollkit/rollkit/store"
+)
+
+func main() {
+	kv, err := store.NewDefaultInMemoryKVStore()
+	if err != nil {
+		log.Panic(err)
+	}
+	if err := plugin.Serve(&mock.DataAvailabilityLayerClient{}, kv, tmlog.NewTMLogger(os.Stdout)); err != nil {
+		log.Panic(err)
+	}
+}
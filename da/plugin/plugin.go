@@ -0,0 +1,439 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/rollkit/rollkit/da"
+	grpcda "github.com/rollkit/rollkit/da/grpc"
+	"github.com/rollkit/rollkit/da/registry"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+)
+
+// stopTimeout is the time given to plugin process to exit gracefully, before it's killed.
+const stopTimeout = 5 * time.Second
+
+var errNotRunning = errors.New("DA plugin is not running")
+
+// Spec describes DA plugin executable and how its process is supervised.
+type Spec struct {
+	// Name of the plugin in DA registry.
+	Name string
+	// Command is the path to plugin executable.
+	Command string
+	// Args are passed to plugin executable.
+	Args []string
+	// Env contains additional environment variables (in key=value format) for plugin process.
+	Env []string
+
+	// StartTimeout is the maximum time between launching the process and plugin reporting that it's serving.
+	StartTimeout time.Duration
+	// HealthCheckInterval defines how often plugin health is checked.
+	HealthCheckInterval time.Duration
+	// HealthCheckTimeout is the maximum duration of a single health check.
+	HealthCheckTimeout time.Duration
+	// MaxHealthCheckFailures is the number of consecutive failed health checks, after which plugin is restarted.
+	MaxHealthCheckFailures int
+	// RestartDelay is the time between plugin crash (or failed start) and the next start.
+	RestartDelay time.Duration
+	// MaxRestarts limits the number of plugin restarts (0 means no limit).
+	MaxRestarts int
+}
+
+// DefaultSpec defines default values for plugin supervision.
+var DefaultSpec = Spec{
+	StartTimeout:           10 * time.Second,
+	HealthCheckInterval:    5 * time.Second,
+	HealthCheckTimeout:     time.Second,
+	MaxHealthCheckFailures: 3,
+	RestartDelay:           time.Second,
+}
+
+// ParseSpecs parses comma separated list of plugins in name=command format. Command may contain space separated
+// arguments. Default supervision parameters are used for all plugins.
+func ParseSpecs(plugins string) ([]Spec, error) {
+	var specs []Spec
+	for _, entry := range strings.Split(plugins, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, command, found := strings.Cut(entry, "=")
+		fields := strings.Fields(command)
+		name = strings.TrimSpace(name)
+		if !found || name == "" || len(fields) == 0 {
+			return nil, fmt.Errorf("invalid DA plugin %q: expected name=command", entry)
+		}
+		spec := DefaultSpec
+		spec.Name = name
+		spec.Command = fields[0]
+		spec.Args = fields[1:]
+		specs = append(specs, spec)
+	}
+	return specs, nil
+}
+
+var (
+	registeredMtx sync.Mutex
+	registered    = make(map[string]Spec)
+)
+
+// Register adds DA plugins to DA client registry.
+//
+// Registering the same plugin again is a no-op, so nodes sharing the process can be created with the same
+// configuration.
+func Register(specs []Spec) error {
+	registeredMtx.Lock()
+	defer registeredMtx.Unlock()
+	for _, spec := range specs {
+		if prev, ok := registered[spec.Name]; ok && reflect.DeepEqual(prev, spec) {
+			continue
+		}
+		spec := spec
+		err := registry.Register(spec.Name, func() da.DataAvailabilityLayerClient {
+			return NewDataAvailabilityLayerClient(spec)
+		})
+		if err != nil {
+			return err
+		}
+		registered[spec.Name] = spec
+	}
+	return nil
+}
+
+// DataAvailabilityLayerClient runs DA client implementation in a separate process.
+//
+// Plugin process is launched on Start. It serves DALCService gRPC API and gRPC health checking protocol on a unix
+// socket (see Serve). Namespace ID and DA configuration are passed to the process in environment variables. Output of
+// the process is forwarded to the logger. Plugin is restarted if the process exits or health checks fail.
+type DataAvailabilityLayerClient struct {
+	spec        Spec
+	namespaceID types.NamespaceID
+	config      []byte
+	logger      log.Logger
+
+	dir string
+
+	mtx    sync.RWMutex
+	client *grpcda.DataAvailabilityLayerClient
+	proc   *process
+
+	quit chan struct{}
+	done chan struct{}
+}
+
+// process is a running instance of the plugin.
+type process struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	conn   *grpc.ClientConn
+	health healthpb.HealthClient
+	exited chan struct{}
+}
+
+var _ da.DataAvailabilityLayerClient = &DataAvailabilityLayerClient{}
+var _ da.BlockRetriever = &DataAvailabilityLayerClient{}
+var _ da.HeightSubscriber = &DataAvailabilityLayerClient{}
+
+// NewDataAvailabilityLayerClient returns new DA client launching the plugin described by spec.
+func NewDataAvailabilityLayerClient(spec Spec) *DataAvailabilityLayerClient {
+	return &DataAvailabilityLayerClient{spec: spec}
+}
+
+// Init stores configuration, to be passed to plugin process. Datastore is not used, plugins persist data on their own.
+func (d *DataAvailabilityLayerClient) Init(namespaceID types.NamespaceID, config []byte, _ ds.Datastore, logger log.Logger) error {
+	if d.spec.Command == "" {
+		return fmt.Errorf("no command for DA plugin %q", d.spec.Name)
+	}
+	d.namespaceID = namespaceID
+	d.config = config
+	d.logger = logger
+	return nil
+}
+
+// Start launches plugin process and waits until it's serving.
+func (d *DataAvailabilityLayerClient) Start() error {
+	d.logger.Info("starting DA plugin", "name", d.spec.Name, "command", d.spec.Command)
+	dir, err := os.MkdirTemp("", "rollkit-da-plugin-")
+	if err != nil {
+		return err
+	}
+	d.dir = dir
+	if err := d.launch(); err != nil {
+		_ = os.RemoveAll(d.dir)
+		return err
+	}
+	d.quit = make(chan struct{})
+	d.done = make(chan struct{})
+	go d.supervise()
+	return nil
+}
+
+// Stop terminates plugin process.
+func (d *DataAvailabilityLayerClient) Stop() error {
+	d.logger.Info("stopping DA plugin", "name", d.spec.Name)
+	close(d.quit)
+	<-d.done
+	d.terminate()
+	return os.RemoveAll(d.dir)
+}
+
+// SubmitBlock submits a block to DA layer using the plugin.
+func (d *DataAvailabilityLayerClient) SubmitBlock(ctx context.Context, block *types.Block) da.ResultSubmitBlock {
+	client := d.current()
+	if client == nil {
+		return da.ResultSubmitBlock{BaseResult: notRunning()}
+	}
+	return client.SubmitBlock(ctx, block)
+}
+
+// SubmitBlocks submits blocks to DA layer using the plugin.
+func (d *DataAvailabilityLayerClient) SubmitBlocks(ctx context.Context, blocks []*types.Block) da.ResultSubmitBlock {
+	client := d.current()
+	if client == nil {
+		return da.ResultSubmitBlock{BaseResult: notRunning()}
+	}
+	return client.SubmitBlocks(ctx, blocks)
+}
+
+// CheckBlockAvailability queries DA layer using the plugin.
+func (d *DataAvailabilityLayerClient) CheckBlockAvailability(ctx context.Context, daHeight uint64) da.ResultCheckBlock {
+	client := d.current()
+	if client == nil {
+		return da.ResultCheckBlock{BaseResult: notRunning()}
+	}
+	return client.CheckBlockAvailability(ctx, daHeight)
+}
+
+// RetrieveBlocks retrieves blocks from DA layer using the plugin.
+func (d *DataAvailabilityLayerClient) RetrieveBlocks(ctx context.Context, daHeight uint64) da.ResultRetrieveBlocks {
+	client := d.current()
+	if client == nil {
+		return da.ResultRetrieveBlocks{BaseResult: notRunning()}
+	}
+	return client.RetrieveBlocks(ctx, daHeight)
+}
+
+// SubscribeHeights streams heights of new DA blocks from the plugin. Channel is closed if plugin is restarted.
+func (d *DataAvailabilityLayerClient) SubscribeHeights(ctx context.Context) (<-chan uint64, error) {
+	client := d.current()
+	if client == nil {
+		return nil, errNotRunning
+	}
+	return client.SubscribeHeights(ctx)
+}
+
+func (d *DataAvailabilityLayerClient) current() *grpcda.DataAvailabilityLayerClient {
+	d.mtx.RLock()
+	defer d.mtx.RUnlock()
+	return d.client
+}
+
+func notRunning() da.BaseResult {
+	return da.BaseResult{Code: da.StatusError, Message: errNotRunning.Error()}
+}
+
+// launch starts plugin process, and waits until plugin reports that it's serving.
+func (d *DataAvailabilityLayerClient) launch() error {
+	socket := filepath.Join(d.dir, "plugin.sock")
+	_ = os.Remove(socket)
+
+	cmd := exec.Command(d.spec.Command, d.spec.Args...) //nolint:gosec
+	cmd.Env = append(os.Environ(), d.spec.Env...)
+	cmd.Env = append(cmd.Env,
+		EnvSocket+"="+socket,
+		EnvNamespaceID+"="+hex.EncodeToString(d.namespaceID[:]),
+		EnvConfig+"="+string(d.config),
+	)
+	cmd.Stdout = &lineWriter{logger: d.logger, name: d.spec.Name}
+	cmd.Stderr = &lineWriter{logger: d.logger, name: d.spec.Name}
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to launch DA plugin %q: %w", d.spec.Name, err)
+	}
+	proc := &process{cmd: cmd, stdin: stdin, exited: make(chan struct{})}
+	go func() {
+		err := cmd.Wait()
+		d.logger.Info("DA plugin process exited", "name", d.spec.Name, "error", err)
+		close(proc.exited)
+	}()
+
+	proc.conn, err = grpc.Dial(socket,
+		grpc.WithInsecure(),
+		grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			var dialer net.Dialer
+			return dialer.DialContext(ctx, "unix", addr)
+		}),
+		// plugin is local, there is no need to back off for long
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  50 * time.Millisecond,
+				Multiplier: backoff.DefaultConfig.Multiplier,
+				Jitter:     backoff.DefaultConfig.Jitter,
+				MaxDelay:   time.Second,
+			},
+		}),
+	)
+	if err != nil {
+		stopProcess(proc)
+		return err
+	}
+	proc.health = healthpb.NewHealthClient(proc.conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.spec.StartTimeout)
+	defer cancel()
+	go func() {
+		select {
+		case <-proc.exited:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+	if err := checkHealth(ctx, proc.health, grpc.WaitForReady(true)); err != nil {
+		stopProcess(proc)
+		return fmt.Errorf("DA plugin %q failed to start: %w", d.spec.Name, err)
+	}
+
+	d.mtx.Lock()
+	d.proc = proc
+	d.client = grpcda.NewClient(proc.conn, d.logger)
+	d.mtx.Unlock()
+	d.logger.Info("DA plugin started", "name", d.spec.Name, "pid", cmd.Process.Pid)
+	return nil
+}
+
+// terminate stops running plugin process (if any).
+func (d *DataAvailabilityLayerClient) terminate() {
+	d.mtx.Lock()
+	proc := d.proc
+	d.proc = nil
+	d.client = nil
+	d.mtx.Unlock()
+	if proc != nil {
+		stopProcess(proc)
+	}
+}
+
+// supervise restarts the plugin if its process exits or fails consecutive health checks.
+func (d *DataAvailabilityLayerClient) supervise() {
+	defer close(d.done)
+	ticker := time.NewTicker(d.spec.HealthCheckInterval)
+	defer ticker.Stop()
+
+	failures := 0
+	restarts := 0
+	for {
+		d.mtx.RLock()
+		proc := d.proc
+		d.mtx.RUnlock()
+
+		select {
+		case <-d.quit:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), d.spec.HealthCheckTimeout)
+			err := checkHealth(ctx, proc.health)
+			cancel()
+			if err == nil {
+				failures = 0
+				continue
+			}
+			failures++
+			d.logger.Error("DA plugin health check failed", "name", d.spec.Name, "failures", failures, "error", err)
+			if failures < d.spec.MaxHealthCheckFailures {
+				continue
+			}
+		case <-proc.exited:
+			d.logger.Error("DA plugin crashed", "name", d.spec.Name)
+		}
+
+		// restart plugin, until it starts successfully
+		failures = 0
+		d.terminate()
+		for {
+			if d.spec.MaxRestarts > 0 && restarts >= d.spec.MaxRestarts {
+				d.logger.Error("DA plugin restart limit reached", "name", d.spec.Name, "restarts", restarts)
+				<-d.quit
+				return
+			}
+			select {
+			case <-d.quit:
+				return
+			case <-time.After(d.spec.RestartDelay):
+			}
+			restarts++
+			d.logger.Info("restarting DA plugin", "name", d.spec.Name, "restarts", restarts)
+			err := d.launch()
+			if err == nil {
+				break
+			}
+			d.logger.Error("failed to restart DA plugin", "name", d.spec.Name, "error", err)
+		}
+	}
+}
+
+func checkHealth(ctx context.Context, client healthpb.HealthClient, opts ...grpc.CallOption) error {
+	resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{}, opts...)
+	if err != nil {
+		return err
+	}
+	if resp.Status != healthpb.HealthCheckResponse_SERVING {
+		return fmt.Errorf("plugin is not serving: %s", resp.Status)
+	}
+	return nil
+}
+
+// stopProcess asks plugin to exit by closing its standard input; process is killed if it doesn't exit in time.
+func stopProcess(proc *process) {
+	if proc.conn != nil {
+		_ = proc.conn.Close()
+	}
+	_ = proc.stdin.Close()
+	select {
+	case <-proc.exited:
+	case <-time.After(stopTimeout):
+		_ = proc.cmd.Process.Kill()
+		<-proc.exited
+	}
+}
+
+// lineWriter logs output of plugin process line by line.
+type lineWriter struct {
+	logger log.Logger
+	name   string
+	buf    []byte
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.logger.Info(string(bytes.TrimRight(w.buf[:i], "\r")), "plugin", w.name)
+		w.buf = w.buf[i+1:]
+	}
+	return len(p), nil
+}
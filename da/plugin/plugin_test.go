@@ -0,0 +1,199 @@
+package plugin
+
+import (
+	"context"
+	"math/rand"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/da/mock"
+	"github.com/rollkit/rollkit/da/registry"
+	"github.com/rollkit/rollkit/log/test"
+	"github.com/rollkit/rollkit/store"
+	"github.com/rollkit/rollkit/types"
+)
+
+// envTestPlugin makes test binary act as a plugin serving mock DA client.
+const envTestPlugin = "ROLLKIT_DA_PLUGIN_TEST"
+
+func TestMain(m *testing.M) {
+	switch os.Getenv(envTestPlugin) {
+	case "":
+		os.Exit(m.Run())
+	case "fail":
+		os.Exit(1)
+	default:
+		kv, err := store.NewDefaultInMemoryKVStore()
+		if err == nil {
+			err = Serve(&mock.DataAvailabilityLayerClient{}, kv, tmlog.NewTMLogger(os.Stdout))
+		}
+		if err != nil {
+			tmlog.NewTMLogger(os.Stderr).Error("plugin failed", "error", err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+}
+
+func testSpec(mode string) Spec {
+	spec := DefaultSpec
+	spec.Name = "test-plugin"
+	spec.Command = os.Args[0]
+	spec.Env = []string{envTestPlugin + "=" + mode}
+	spec.HealthCheckInterval = 50 * time.Millisecond
+	spec.RestartDelay = 10 * time.Millisecond
+	return spec
+}
+
+func TestParseSpecs(t *testing.T) {
+	specs, err := ParseSpecs("")
+	assert.NoError(t, err)
+	assert.Empty(t, specs)
+
+	specs, err = ParseSpecs("foo=/usr/bin/foo, bar = /opt/bar --home /tmp/bar")
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, "foo", specs[0].Name)
+	assert.Equal(t, "/usr/bin/foo", specs[0].Command)
+	assert.Empty(t, specs[0].Args)
+	assert.Equal(t, "bar", specs[1].Name)
+	assert.Equal(t, "/opt/bar", specs[1].Command)
+	assert.Equal(t, []string{"--home", "/tmp/bar"}, specs[1].Args)
+	assert.Equal(t, DefaultSpec.HealthCheckInterval, specs[1].HealthCheckInterval)
+
+	for _, invalid := range []string{"foo", "=/usr/bin/foo", "foo=", "foo= "} {
+		_, err = ParseSpecs(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	spec := testSpec("mock")
+	spec.Name = "registered-plugin"
+	require.NoError(t, Register([]Spec{spec}))
+	// registering the same plugin again is fine
+	require.NoError(t, Register([]Spec{spec}))
+
+	client := registry.GetClient(spec.Name)
+	require.NotNil(t, client)
+	assert.IsType(t, &DataAvailabilityLayerClient{}, client)
+
+	spec.Args = []string{"-v"}
+	assert.Error(t, Register([]Spec{spec}))
+	spec.Name = "mock"
+	assert.Error(t, Register([]Spec{spec}))
+}
+
+func TestPlugin(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	dalc := NewDataAvailabilityLayerClient(testSpec("mock"))
+	require.NoError(dalc.Init(types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8}, []byte("20ms"), nil, test.NewLogger(t)))
+	require.NoError(dalc.Start())
+	defer func() {
+		require.NoError(dalc.Stop())
+		_, err := os.Stat(dalc.dir)
+		assert.True(os.IsNotExist(err))
+	}()
+
+	submitAndRetrieve := func() {
+		b1, b2 := getRandomBlock(1, 10), getRandomBlock(2, 0)
+		res := dalc.SubmitBlocks(ctx, []*types.Block{b1, b2})
+		require.Equal(da.StatusSuccess, res.Code, res.Message)
+		// blocks are available after mock DA produces next height
+		require.Eventually(func() bool {
+			return dalc.CheckBlockAvailability(ctx, res.DAHeight).DataAvailable
+		}, time.Second, 10*time.Millisecond)
+		ret := dalc.RetrieveBlocks(ctx, res.DAHeight)
+		require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+		assert.Equal([]*types.Block{b1, b2}, ret.Blocks)
+	}
+	submitAndRetrieve()
+
+	// DA config is passed to the plugin - mock DA produces new heights every 20ms
+	subCtx, cancel := context.WithCancel(ctx)
+	heights, err := dalc.SubscribeHeights(subCtx)
+	require.NoError(err)
+	select {
+	case <-heights:
+	case <-time.After(time.Second):
+		t.Fatal("no DA heights received from plugin")
+	}
+	cancel()
+
+	// plugin is restarted after crash
+	pid := dalc.proc.cmd.Process.Pid
+	require.NoError(dalc.proc.cmd.Process.Kill())
+	require.Eventually(func() bool {
+		dalc.mtx.RLock()
+		defer dalc.mtx.RUnlock()
+		return dalc.proc != nil && dalc.proc.cmd.Process.Pid != pid
+	}, 10*time.Second, 10*time.Millisecond)
+	submitAndRetrieve()
+}
+
+func TestPluginStartFailure(t *testing.T) {
+	dalc := NewDataAvailabilityLayerClient(testSpec("fail"))
+	require.NoError(t, dalc.Init(types.NamespaceID{}, nil, nil, test.NewLogger(t)))
+	assert.Error(t, dalc.Start())
+
+	spec := testSpec("mock")
+	spec.Command = ""
+	assert.Error(t, NewDataAvailabilityLayerClient(spec).Init(types.NamespaceID{}, nil, nil, test.NewLogger(t)))
+}
+
+func TestPluginRestartLimit(t *testing.T) {
+	spec := testSpec("mock")
+	spec.MaxRestarts = 1
+	dalc := NewDataAvailabilityLayerClient(spec)
+	require.NoError(t, dalc.Init(types.NamespaceID{}, nil, nil, test.NewLogger(t)))
+	require.NoError(t, dalc.Start())
+	defer func() { require.NoError(t, dalc.Stop()) }()
+
+	// plugin is restarted once, then it's not running anymore
+	for i := 0; i < 2; i++ {
+		dalc.mtx.RLock()
+		proc := dalc.proc
+		dalc.mtx.RUnlock()
+		require.NotNil(t, proc)
+		require.NoError(t, proc.cmd.Process.Kill())
+		require.Eventually(t, func() bool {
+			dalc.mtx.RLock()
+			defer dalc.mtx.RUnlock()
+			return dalc.proc != proc
+		}, 10*time.Second, 10*time.Millisecond)
+		if i == 0 {
+			require.Eventually(t, func() bool { return dalc.current() != nil }, 10*time.Second, 10*time.Millisecond)
+		}
+	}
+	time.Sleep(100 * time.Millisecond)
+	res := dalc.SubmitBlock(context.Background(), getRandomBlock(1, 1))
+	assert.Equal(t, da.StatusError, res.Code)
+	assert.Equal(t, errNotRunning.Error(), res.Message)
+}
+
+func getRandomBlock(height uint64, nTxs int) *types.Block {
+	block := &types.Block{}
+	block.SignedHeader.Header.BaseHeader.Height = height
+	block.SignedHeader.Header.AggregatorsHash = make([]byte, 32)
+	block.SignedHeader.Header.AppHash = getRandomBytes(32)
+	for i := 0; i < nTxs; i++ {
+		block.Data.Txs = append(block.Data.Txs, getRandomBytes(100))
+		block.Data.IntermediateStateRoots.RawRootsList = append(block.Data.IntermediateStateRoots.RawRootsList, getRandomBytes(32))
+	}
+	return block
+}
+
+func getRandomBytes(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data) //nolint:gosec
+	return data
+}
@@ -0,0 +1,122 @@
+package plugin
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	ds "github.com/ipfs/go-datastore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/pb/dalc"
+)
+
+// Environment variables set by the node for plugin process.
+const (
+	// EnvSocket is the path of unix socket, that plugin serves gRPC API on.
+	EnvSocket = "ROLLKIT_DA_PLUGIN_SOCKET"
+	// EnvNamespaceID is the hex encoded namespace ID of the rollup.
+	EnvNamespaceID = "ROLLKIT_DA_PLUGIN_NAMESPACE_ID"
+	// EnvConfig is the DA layer configuration (rollkit.da_config) passed verbatim.
+	EnvConfig = "ROLLKIT_DA_PLUGIN_CONFIG"
+)
+
+// ErrSubscriptionNotSupported is returned when DA client is not able to notify about new DA heights.
+var ErrSubscriptionNotSupported = errors.New("DA client doesn't support DA height subscriptions")
+
+// Env contains parameters passed by the node to plugin process.
+type Env struct {
+	Socket      string
+	NamespaceID types.NamespaceID
+	Config      []byte
+}
+
+// ReadEnv reads parameters passed by the node from environment variables.
+func ReadEnv() (Env, error) {
+	env := Env{
+		Socket: os.Getenv(EnvSocket),
+		Config: []byte(os.Getenv(EnvConfig)),
+	}
+	if env.Socket == "" {
+		return env, fmt.Errorf("%s is not set, plugin has to be started by rollkit node", EnvSocket)
+	}
+	nsID, err := hex.DecodeString(os.Getenv(EnvNamespaceID))
+	if err != nil || len(nsID) != len(env.NamespaceID) {
+		return env, fmt.Errorf("invalid namespace ID in %s: %q", EnvNamespaceID, os.Getenv(EnvNamespaceID))
+	}
+	copy(env.NamespaceID[:], nsID)
+	return env, nil
+}
+
+// NewServer creates gRPC server serving DALCService API and gRPC health checking protocol.
+func NewServer(service dalc.DALCServiceServer) *grpc.Server {
+	srv := grpc.NewServer()
+	dalc.RegisterDALCServiceServer(srv, service)
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	return srv
+}
+
+// Serve runs DA client as a plugin.
+//
+// Client is initialized with parameters passed by the node, started and served on the unix socket until SIGINT or
+// SIGTERM is received, or the node closes standard input of the process. Plugins implemented in other languages have
+// to follow the same protocol.
+func Serve(client da.DataAvailabilityLayerClient, kv ds.Datastore, logger log.Logger) error {
+	env, err := ReadEnv()
+	if err != nil {
+		return err
+	}
+	if err := client.Init(env.NamespaceID, env.Config, kv, logger); err != nil {
+		return fmt.Errorf("failed to initialize DA client: %w", err)
+	}
+	if err := client.Start(); err != nil {
+		return fmt.Errorf("failed to start DA client: %w", err)
+	}
+	defer func() {
+		if err := client.Stop(); err != nil {
+			logger.Error("failed to stop DA client", "error", err)
+		}
+	}()
+
+	_ = os.Remove(env.Socket)
+	listener, err := net.Listen("unix", env.Socket)
+	if err != nil {
+		return err
+	}
+	srv := NewServer(NewService(client))
+	served := make(chan error, 1)
+	go func() {
+		served <- srv.Serve(listener)
+	}()
+	logger.Info("serving DA plugin", "socket", env.Socket)
+
+	// node closes standard input when plugin should exit (or when node process dies)
+	stdinClosed := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(io.Discard, os.Stdin)
+		close(stdinClosed)
+	}()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-served:
+		return err
+	case <-stdinClosed:
+	case <-signals:
+	}
+	logger.Info("stopping DA plugin")
+	srv.Stop()
+	return nil
+}
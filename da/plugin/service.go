@@ -0,0 +1,116 @@
+package plugin
+
+import (
+	"context"
+
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/types"
+	"github.com/rollkit/rollkit/types/pb/dalc"
+	"github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// service implements DALCService gRPC API on top of DA client.
+type service struct {
+	client da.DataAvailabilityLayerClient
+}
+
+var _ dalc.DALCServiceServer = &service{}
+
+// NewService returns DALCService gRPC API implementation, that passes requests to given DA client.
+//
+// Block retrieval and DA height subscriptions are served only if client implements da.BlockRetriever and
+// da.HeightSubscriber respectively.
+func NewService(client da.DataAvailabilityLayerClient) dalc.DALCServiceServer {
+	return &service{client: client}
+}
+
+func (s *service) SubmitBlock(ctx context.Context, request *dalc.SubmitBlockRequest) (*dalc.SubmitBlockResponse, error) {
+	var block types.Block
+	if err := block.FromProto(request.Block); err != nil {
+		return nil, err
+	}
+	resp := s.client.SubmitBlock(ctx, &block)
+	return &dalc.SubmitBlockResponse{
+		Result:     daResponse(resp.BaseResult),
+		Commitment: resp.Commitment,
+		Namespace:  resp.Namespace,
+		Fee:        resp.Fee,
+	}, nil
+}
+
+func (s *service) SubmitBlocks(ctx context.Context, request *dalc.SubmitBlocksRequest) (*dalc.SubmitBlocksResponse, error) {
+	blocks := make([]*types.Block, len(request.Blocks))
+	for i := range request.Blocks {
+		blocks[i] = new(types.Block)
+		if err := blocks[i].FromProto(request.Blocks[i]); err != nil {
+			return nil, err
+		}
+	}
+	resp := s.client.SubmitBlocks(ctx, blocks)
+	return &dalc.SubmitBlocksResponse{
+		Result:     daResponse(resp.BaseResult),
+		Commitment: resp.Commitment,
+		Namespace:  resp.Namespace,
+		Fee:        resp.Fee,
+	}, nil
+}
+
+func (s *service) CheckBlockAvailability(ctx context.Context, request *dalc.CheckBlockAvailabilityRequest) (*dalc.CheckBlockAvailabilityResponse, error) {
+	resp := s.client.CheckBlockAvailability(ctx, request.DAHeight)
+	return &dalc.CheckBlockAvailabilityResponse{
+		Result:        daResponse(resp.BaseResult),
+		DataAvailable: resp.DataAvailable,
+	}, nil
+}
+
+func (s *service) RetrieveBlocks(ctx context.Context, request *dalc.RetrieveBlocksRequest) (*dalc.RetrieveBlocksResponse, error) {
+	retriever, ok := s.client.(da.BlockRetriever)
+	if !ok {
+		return &dalc.RetrieveBlocksResponse{Result: &dalc.DAResponse{
+			Code:    dalc.StatusCode(da.StatusError),
+			Message: "block retrieval not supported",
+		}}, nil
+	}
+	resp := retriever.RetrieveBlocks(ctx, request.DAHeight)
+	blocks := make([]*rollkit.Block, len(resp.Blocks))
+	for i := range resp.Blocks {
+		bp, err := resp.Blocks[i].ToProto()
+		if err != nil {
+			return nil, err
+		}
+		blocks[i] = bp
+	}
+	return &dalc.RetrieveBlocksResponse{
+		Result: daResponse(resp.BaseResult),
+		Blocks: blocks,
+	}, nil
+}
+
+func (s *service) SubscribeHeights(_ *dalc.SubscribeHeightsRequest, stream dalc.DALCService_SubscribeHeightsServer) error {
+	subscriber, ok := s.client.(da.HeightSubscriber)
+	if !ok {
+		return ErrSubscriptionNotSupported
+	}
+	heights, err := subscriber.SubscribeHeights(stream.Context())
+	if err != nil {
+		return err
+	}
+	// let the client know that subscription is established
+	if err := stream.SendHeader(nil); err != nil {
+		return err
+	}
+	for height := range heights {
+		if err := stream.Send(&dalc.SubscribeHeightsResponse{DAHeight: height}); err != nil {
+			return err
+		}
+	}
+	return stream.Context().Err()
+}
+
+func daResponse(result da.BaseResult) *dalc.DAResponse {
+	return &dalc.DAResponse{
+		Code:     dalc.StatusCode(result.Code),
+		Message:  result.Message,
+		DAHeight: result.DAHeight,
+	}
+}
@@ -5,8 +5,11 @@ import (
 
 	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/da/celestia"
+	"github.com/rollkit/rollkit/da/chaos"
+	"github.com/rollkit/rollkit/da/failover"
 	"github.com/rollkit/rollkit/da/grpc"
 	"github.com/rollkit/rollkit/da/mock"
+	"github.com/rollkit/rollkit/da/multi"
 )
 
 // ErrAlreadyRegistered is used when user tries to register DA using a name already used in registry.
@@ -25,6 +28,13 @@ var clients = map[string]func() da.DataAvailabilityLayerClient{
 	"celestia": func() da.DataAvailabilityLayerClient { return &celestia.DataAvailabilityLayerClient{} },
 }
 
+func init() {
+	// multi, failover and chaos clients instantiate underlying clients using the registry
+	clients["multi"] = func() da.DataAvailabilityLayerClient { return multi.NewDataAvailabilityLayerClient(GetClient) }
+	clients["failover"] = func() da.DataAvailabilityLayerClient { return failover.NewDataAvailabilityLayerClient(GetClient) }
+	clients["chaos"] = func() da.DataAvailabilityLayerClient { return chaos.NewDataAvailabilityLayerClient(GetClient) }
+}
+
 // GetClient returns client identified by name.
 func GetClient(name string) da.DataAvailabilityLayerClient {
 	f, ok := clients[name]
@@ -2,7 +2,10 @@ package test
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"math"
 	"math/rand"
 	"net"
 	"os"
@@ -10,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/celestiaorg/go-cnc"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
@@ -19,9 +23,13 @@ import (
 	"github.com/rollkit/rollkit/da"
 	"github.com/rollkit/rollkit/da/celestia"
 	cmock "github.com/rollkit/rollkit/da/celestia/mock"
+	"github.com/rollkit/rollkit/da/chaos"
+	"github.com/rollkit/rollkit/da/devnet"
+	"github.com/rollkit/rollkit/da/failover"
 	grpcda "github.com/rollkit/rollkit/da/grpc"
 	"github.com/rollkit/rollkit/da/grpc/mockserv"
 	"github.com/rollkit/rollkit/da/mock"
+	"github.com/rollkit/rollkit/da/multi"
 	"github.com/rollkit/rollkit/da/registry"
 	"github.com/rollkit/rollkit/log/test"
 	"github.com/rollkit/rollkit/store"
@@ -71,29 +79,10 @@ func TestDALC(t *testing.T) {
 }
 
 func doTestDALC(t *testing.T, dalc da.DataAvailabilityLayerClient) {
-	require := require.New(t)
 	assert := assert.New(t)
 	ctx := context.Background()
 
-	// mock DALC will advance block height every 100ms
-	conf := []byte{}
-	if _, ok := dalc.(*mock.DataAvailabilityLayerClient); ok {
-		conf = []byte(mockDaBlockTime.String())
-	}
-	if _, ok := dalc.(*celestia.DataAvailabilityLayerClient); ok {
-		config := celestia.Config{
-			BaseURL:  "http://localhost:26658",
-			Timeout:  30 * time.Second,
-			GasLimit: 3000000,
-		}
-		conf, _ = json.Marshal(config)
-	}
-	kvStore, _ := store.NewDefaultInMemoryKVStore()
-	err := dalc.Init(testNamespaceID, conf, kvStore, test.NewLogger(t))
-	require.NoError(err)
-
-	err = dalc.Start()
-	require.NoError(err)
+	initDALC(t, dalc)
 
 	// wait a bit more than mockDaBlockTime, so mock can "produce" some blocks
 	time.Sleep(mockDaBlockTime + 20*time.Millisecond)
@@ -123,7 +112,7 @@ func doTestDALC(t *testing.T, dalc da.DataAvailabilityLayerClient) {
 
 	// this height should not be used by DALC
 	check = dalc.CheckBlockAvailability(ctx, h1-1)
-	assert.Equal(da.StatusSuccess, check.Code)
+	assert.Equal(da.StatusNamespaceEmpty, check.Code)
 	assert.False(check.DataAvailable)
 }
 
@@ -145,26 +134,632 @@ func TestRetrieve(t *testing.T) {
 	}
 }
 
+func TestSubscribeHeights(t *testing.T) {
+	grpcServer := startMockGRPCServ(t)
+	defer grpcServer.GracefulStop()
+
+	for _, client := range registry.RegisteredClients() {
+		t.Run(client, func(t *testing.T) {
+			dalc := registry.GetClient(client)
+			subscriber, ok := dalc.(da.HeightSubscriber)
+			if !ok {
+				return
+			}
+			require := require.New(t)
+			initDALC(t, dalc)
+			defer func() { require.NoError(dalc.Stop()) }()
+
+			ctx, cancel := context.WithCancel(context.Background())
+			heights, err := subscriber.SubscribeHeights(ctx)
+			require.NoError(err)
+
+			var last uint64
+			for i := 0; i < 3; i++ {
+				select {
+				case height := <-heights:
+					require.Greater(height, last)
+					last = height
+				case <-time.After(10 * mockDaBlockTime):
+					t.Fatal("timeout while waiting for new DA height")
+				}
+			}
+			// the last produced height is available for retrieval
+			if retriever, ok := dalc.(da.BlockRetriever); ok {
+				res := retriever.RetrieveBlocks(context.Background(), last)
+				require.NotEqual(da.StatusHeightFromFuture, res.Code, res.Message)
+			}
+
+			cancel()
+			require.Eventually(func() bool {
+				select {
+				case _, ok := <-heights:
+					return !ok
+				default:
+					return false
+				}
+			}, time.Second, 10*time.Millisecond)
+		})
+	}
+
+	// subscription fails if gRPC server is not available
+	dalc := &grpcda.DataAvailabilityLayerClient{}
+	conf, _ := json.Marshal(grpcda.Config{Host: "127.0.0.1", Port: 1})
+	require.NoError(t, dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(t, dalc.Start())
+	_, err := dalc.SubscribeHeights(context.Background())
+	assert.Error(t, err)
+}
+
+func TestSubmitBlocks(t *testing.T) {
+	grpcServer := startMockGRPCServ(t)
+	defer grpcServer.GracefulStop()
+
+	httpServer := startMockCelestiaNodeServer(t)
+	defer httpServer.Stop()
+
+	for _, client := range registry.RegisteredClients() {
+		t.Run(client, func(t *testing.T) {
+			dalc := registry.GetClient(client)
+			_, ok := dalc.(da.BlockRetriever)
+			if ok {
+				doTestSubmitBlocks(t, dalc)
+			}
+		})
+	}
+}
+
+func doTestSubmitBlocks(t *testing.T, dalc da.DataAvailabilityLayerClient) {
+	ctx := context.Background()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	initDALC(t, dalc)
+
+	// wait a bit more than mockDaBlockTime, so mock can "produce" some blocks
+	time.Sleep(mockDaBlockTime + 20*time.Millisecond)
+
+	batch := []*types.Block{getRandomBlock(1, 5), getRandomBlock(2, 0), getRandomBlock(3, 10)}
+	resp := dalc.SubmitBlocks(ctx, batch)
+	require.Equal(da.StatusSuccess, resp.Code, resp.Message)
+	assert.NotEmpty(resp.Commitment)
+	assert.Len(resp.Namespace, len(testNamespaceID))
+
+	// wait a bit more than mockDaBlockTime, so Rollkit blocks can be "included" in mock block
+	time.Sleep(mockDaBlockTime + 20*time.Millisecond)
+
+	ret := dalc.(da.BlockRetriever).RetrieveBlocks(ctx, resp.DAHeight)
+	assert.Equal(da.StatusSuccess, ret.Code, ret.Message)
+	require.Len(ret.Blocks, len(batch))
+	for _, b := range batch {
+		assert.Contains(ret.Blocks, b)
+	}
+}
+
+func TestCelestiaSplitBlock(t *testing.T) {
+	const maxBlobSize = 1024
+
+	httpServer := startMockCelestiaNodeServer(t, cmock.WithMaxBlobSize(maxBlobSize))
+	defer httpServer.Stop()
+
+	ctx := context.Background()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	block := getRandomBlock(1, 50)
+
+	// without splitting, blob is rejected by the node
+	dalc := &celestia.DataAvailabilityLayerClient{}
+	initDALC(t, dalc)
+	resp := dalc.SubmitBlock(ctx, block)
+	assert.Equal(da.StatusBlobTooBig, resp.Code)
+
+	config := celestia.Config{
+		BaseURL:     "http://localhost:26658",
+		Timeout:     30 * time.Second,
+		GasLimit:    3000000,
+		MaxBlobSize: maxBlobSize,
+	}
+	conf, _ := json.Marshal(config)
+	dalc = &celestia.DataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(dalc.Start())
+
+	resp = dalc.SubmitBlock(ctx, block)
+	require.Equal(da.StatusSuccess, resp.Code, resp.Message)
+
+	// wait a bit more than mockDaBlockTime, so Rollkit blocks can be "included" in mock block
+	time.Sleep(mockDaBlockTime + 20*time.Millisecond)
+
+	// parts may be spread across multiple DA heights; block is returned when the last part is retrieved
+	for h := uint64(1); h <= resp.DAHeight; h++ {
+		ret := dalc.RetrieveBlocks(ctx, h)
+		if h < resp.DAHeight {
+			require.Contains([]da.StatusCode{da.StatusSuccess, da.StatusNamespaceEmpty}, ret.Code, ret.Message)
+			assert.Empty(ret.Blocks)
+		} else {
+			require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+			require.Len(ret.Blocks, 1)
+			assert.Equal(block, ret.Blocks[0])
+		}
+	}
+}
+
+// partFailingStore rejects blobs after limit blobs were posted, and counts posted blobs.
+type partFailingStore struct {
+	*devnet.BlobStore
+	limit     int
+	submitted map[string]int
+}
+
+func (s *partFailingStore) Submit(ctx context.Context, namespace []byte, blob []byte) (uint64, error) {
+	if len(s.submitted) >= s.limit {
+		return 0, errors.New("submission failed")
+	}
+	s.submitted[string(blob)]++
+	return s.BlobStore.Submit(ctx, namespace, blob)
+}
+
+func TestCelestiaSplitBlockRetry(t *testing.T) {
+	const maxBlobSize = 1024
+
+	ctx := context.Background()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	blobStore, err := devnet.NewBlobStore(ctx, kv)
+	require.NoError(err)
+	failingStore := &partFailingStore{BlobStore: blobStore, limit: 2, submitted: make(map[string]int)}
+	httpServer := startMockCelestiaNodeServer(t, cmock.WithBlobStore(failingStore), cmock.WithMaxBlobSize(maxBlobSize))
+	defer httpServer.Stop()
+
+	config := celestia.Config{
+		BaseURL:     "http://localhost:26658",
+		Timeout:     30 * time.Second,
+		GasLimit:    3000000,
+		MaxBlobSize: maxBlobSize,
+	}
+	conf, _ := json.Marshal(config)
+	dalc := &celestia.DataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(dalc.Start())
+
+	block := getRandomBlock(1, 50)
+	res := dalc.SubmitBlock(ctx, block)
+	assert.NotEqual(da.StatusSuccess, res.Code)
+	assert.Len(failingStore.submitted, 2)
+	_, err = blobStore.Produce(ctx)
+	require.NoError(err)
+
+	// only missing parts are posted on retry
+	failingStore.limit = math.MaxInt
+	res = dalc.SubmitBlock(ctx, block)
+	require.Equal(da.StatusSuccess, res.Code, res.Message)
+	assert.Greater(len(failingStore.submitted), 2)
+	for _, n := range failingStore.submitted {
+		assert.Equal(1, n)
+	}
+	_, err = blobStore.Produce(ctx)
+	require.NoError(err)
+
+	ret := dalc.RetrieveBlocks(ctx, res.DAHeight-1)
+	require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+	assert.Empty(ret.Blocks)
+	ret = dalc.RetrieveBlocks(ctx, res.DAHeight)
+	require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+	assert.Equal([]*types.Block{block}, ret.Blocks)
+}
+
+func TestCelestiaSeparateNamespaces(t *testing.T) {
+	httpServer := startMockCelestiaNodeServer(t)
+	defer httpServer.Stop()
+
+	ctx := context.Background()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	headerNamespaceID := types.NamespaceID{1, 1, 1, 1, 1, 1, 1, 1}
+	dataNamespaceID := types.NamespaceID{2, 2, 2, 2, 2, 2, 2, 2}
+	config := celestia.Config{
+		BaseURL:           "http://localhost:26658",
+		Timeout:           30 * time.Second,
+		GasLimit:          3000000,
+		HeaderNamespaceID: hex.EncodeToString(headerNamespaceID[:]),
+		DataNamespaceID:   hex.EncodeToString(dataNamespaceID[:]),
+		// mock DA heights advance by up to 10 per block
+		DataLookback: 30,
+	}
+	conf, _ := json.Marshal(config)
+	dalc := &celestia.DataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(dalc.Start())
+
+	newBlock := func(height uint64, nTxs int) *types.Block {
+		b := getRandomBlock(height, nTxs)
+		b.SignedHeader.Header.DataHash = b.Data.Hash()
+		return b
+	}
+	b1, b2, b3 := newBlock(1, 10), newBlock(2, 0), newBlock(3, 5)
+	// header that doesn't commit to its data can't be joined with it
+	forged := newBlock(4, 5)
+	forged.SignedHeader.Header.DataHash = getRandomBytes(32)
+
+	res1 := dalc.SubmitBlock(ctx, b1)
+	require.Equal(da.StatusSuccess, res1.Code, res1.Message)
+	assert.Equal(headerNamespaceID[:], res1.Namespace)
+	res2 := dalc.SubmitBlocks(ctx, []*types.Block{b2, b3, forged})
+	require.Equal(da.StatusSuccess, res2.Code, res2.Message)
+
+	// block data is posted before header, at lower DA height
+	cncClient, err := cnc.NewClient(config.BaseURL, cnc.WithTimeout(config.Timeout))
+	require.NoError(err)
+	b4 := newBlock(5, 3)
+	submitRaw := func(namespaceID types.NamespaceID, blob []byte) uint64 {
+		blob, err := types.CompressBlob(blob, types.CodecZstd)
+		require.NoError(err)
+		resp, err := cncClient.SubmitPFB(ctx, namespaceID, blob, 0, 3000000)
+		require.NoError(err)
+		return uint64(resp.Height)
+	}
+	dataBlob, err := types.MarshalDataBatch([]*types.Data{&b4.Data})
+	require.NoError(err)
+	time.Sleep(2 * mockDaBlockTime)
+	dataHeight := submitRaw(dataNamespaceID, dataBlob)
+	require.Greater(dataHeight, res2.DAHeight)
+	time.Sleep(2 * mockDaBlockTime)
+	headerBlob, err := types.MarshalHeaders([]*types.SignedHeader{&b4.SignedHeader})
+	require.NoError(err)
+	headerHeight := submitRaw(headerNamespaceID, headerBlob)
+	require.Greater(headerHeight, dataHeight)
+
+	// wait a bit more than mockDaBlockTime, so Rollkit blocks can be "included" in mock block
+	time.Sleep(mockDaBlockTime + 20*time.Millisecond)
+
+	retrieve := func(daHeight uint64) []*types.Block {
+		ret := dalc.RetrieveBlocks(ctx, daHeight)
+		require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+		return ret.Blocks
+	}
+	assert.Equal([]*types.Block{b1}, retrieve(res1.DAHeight)[:1])
+	assert.Contains(retrieve(res2.DAHeight), b2)
+	assert.Contains(retrieve(res2.DAHeight), b3)
+	assert.NotContains(retrieve(res2.DAHeight), forged)
+	assert.Equal([]*types.Block{b4}, retrieve(headerHeight))
+
+	// headers can be retrieved without block data
+	headers := dalc.RetrieveHeaders(ctx, res2.DAHeight)
+	require.Equal(da.StatusSuccess, headers.Code, headers.Message)
+	assert.Contains(headers.Headers, &b3.SignedHeader)
+	assert.Contains(headers.Headers, &forged.SignedHeader)
+	assert.Equal(da.StatusNamespaceEmpty, dalc.RetrieveHeaders(ctx, dataHeight).Code)
+
+	// blocks are not posted to the namespace passed to Init
+	dalc = &celestia.DataAvailabilityLayerClient{}
+	initDALC(t, dalc)
+	assert.Equal(da.StatusNamespaceEmpty, dalc.RetrieveBlocks(ctx, res1.DAHeight).Code)
+	assert.Equal(da.StatusError, dalc.RetrieveHeaders(ctx, res1.DAHeight).Code)
+
+	// namespaces have to be valid and different
+	for _, invalid := range []celestia.Config{
+		{HeaderNamespaceID: config.HeaderNamespaceID},
+		{HeaderNamespaceID: "0102", DataNamespaceID: config.DataNamespaceID},
+		{HeaderNamespaceID: config.HeaderNamespaceID, DataNamespaceID: config.HeaderNamespaceID},
+	} {
+		conf, _ := json.Marshal(invalid)
+		assert.Error((&celestia.DataAvailabilityLayerClient{}).Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	}
+}
+
+// headerFailingStore rejects blobs posted to header namespace, while fail is set, and counts posted blobs.
+type headerFailingStore struct {
+	*devnet.BlobStore
+	headerNamespace []byte
+	fail            bool
+	submitted       map[string]int
+}
+
+func (s *headerFailingStore) Submit(ctx context.Context, namespace []byte, blob []byte) (uint64, error) {
+	if s.fail && string(namespace) == string(s.headerNamespace) {
+		return 0, errors.New("header submission failed")
+	}
+	s.submitted[string(namespace)]++
+	return s.BlobStore.Submit(ctx, namespace, blob)
+}
+
+func TestCelestiaSeparateNamespacesRetry(t *testing.T) {
+	ctx := context.Background()
+	require := require.New(t)
+	assert := assert.New(t)
+
+	headerNamespaceID := types.NamespaceID{1, 1, 1, 1, 1, 1, 1, 1}
+	dataNamespaceID := types.NamespaceID{2, 2, 2, 2, 2, 2, 2, 2}
+	kv, err := store.NewDefaultInMemoryKVStore()
+	require.NoError(err)
+	blobStore, err := devnet.NewBlobStore(ctx, kv)
+	require.NoError(err)
+	failingStore := &headerFailingStore{
+		BlobStore:       blobStore,
+		headerNamespace: headerNamespaceID[:],
+		submitted:       make(map[string]int),
+	}
+	httpServer := startMockCelestiaNodeServer(t, cmock.WithBlobStore(failingStore))
+	defer httpServer.Stop()
+
+	config := celestia.Config{
+		BaseURL:           "http://localhost:26658",
+		Timeout:           30 * time.Second,
+		GasLimit:          3000000,
+		HeaderNamespaceID: hex.EncodeToString(headerNamespaceID[:]),
+		DataNamespaceID:   hex.EncodeToString(dataNamespaceID[:]),
+		DataLookback:      2,
+	}
+	conf, _ := json.Marshal(config)
+	dalc := &celestia.DataAvailabilityLayerClient{}
+	require.NoError(dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+	require.NoError(dalc.Start())
+
+	newBlock := func(height uint64) *types.Block {
+		b := getRandomBlock(height, 5)
+		b.SignedHeader.Header.DataHash = b.Data.Hash()
+		return b
+	}
+	produce := func(n int) {
+		for i := 0; i < n; i++ {
+			_, err := blobStore.Produce(ctx)
+			require.NoError(err)
+		}
+	}
+
+	// block data is not posted again, if only headers failed
+	b1 := newBlock(1)
+	failingStore.fail = true
+	res := dalc.SubmitBlock(ctx, b1)
+	assert.NotEqual(da.StatusSuccess, res.Code)
+	res = dalc.SubmitBlock(ctx, b1)
+	assert.NotEqual(da.StatusSuccess, res.Code)
+	assert.Equal(1, failingStore.submitted[string(dataNamespaceID[:])])
+	produce(1)
+	failingStore.fail = false
+	res = dalc.SubmitBlock(ctx, b1)
+	require.Equal(da.StatusSuccess, res.Code, res.Message)
+	assert.Equal(1, failingStore.submitted[string(dataNamespaceID[:])])
+	produce(1)
+	ret := dalc.RetrieveBlocks(ctx, res.DAHeight)
+	require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+	assert.Equal([]*types.Block{b1}, ret.Blocks)
+
+	// block data is posted again, if headers would be included too far from it
+	b2 := newBlock(2)
+	failingStore.fail = true
+	res = dalc.SubmitBlock(ctx, b2)
+	assert.NotEqual(da.StatusSuccess, res.Code)
+	produce(int(config.DataLookback) + 1)
+	failingStore.fail = false
+	res = dalc.SubmitBlock(ctx, b2)
+	assert.Equal(da.StatusError, res.Code)
+	assert.Equal(2, failingStore.submitted[string(dataNamespaceID[:])])
+	res = dalc.SubmitBlock(ctx, b2)
+	require.Equal(da.StatusSuccess, res.Code, res.Message)
+	assert.Equal(3, failingStore.submitted[string(dataNamespaceID[:])])
+	produce(1)
+	ret = dalc.RetrieveBlocks(ctx, res.DAHeight)
+	require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+	assert.Contains(ret.Blocks, b2)
+}
+
+func TestCelestiaFeeBumping(t *testing.T) {
+	const minGasPrice = 0.1
+
+	httpServer := startMockCelestiaNodeServer(t, cmock.WithMinGasPrice(minGasPrice))
+	defer httpServer.Stop()
+
+	ctx := context.Background()
+	block := getRandomBlock(1, 10)
+
+	newClient := func(gasLimit uint64, maxFee int64) *celestia.DataAvailabilityLayerClient {
+		conf, _ := json.Marshal(celestia.Config{
+			BaseURL:  "http://localhost:26658",
+			Timeout:  30 * time.Second,
+			GasPrice: minGasPrice / 4,
+			GasLimit: gasLimit,
+			MaxFee:   maxFee,
+		})
+		dalc := &celestia.DataAvailabilityLayerClient{}
+		require.NoError(t, dalc.Init(testNamespaceID, conf, nil, test.NewLogger(t)))
+		require.NoError(t, dalc.Start())
+		return dalc
+	}
+
+	t.Run("bump until accepted", func(t *testing.T) {
+		assert := assert.New(t)
+		// gas is estimated from blob size
+		dalc := newClient(0, 0)
+
+		resp := dalc.SubmitBlock(ctx, block)
+		assert.Equal(da.StatusInsufficientFee, resp.Code, resp.Message)
+
+		bumps := 0
+		for resp.Code == da.StatusInsufficientFee && bumps < 10 {
+			assert.True(dalc.BumpFee())
+			bumps++
+			resp = dalc.SubmitBlock(ctx, block)
+		}
+		assert.Equal(da.StatusSuccess, resp.Code, resp.Message)
+		// fee has to be at least 4x higher; 1.5^4 > 4 > 1.5^3
+		assert.Equal(4, bumps)
+		assert.Greater(resp.Fee, uint64(0))
+	})
+
+	t.Run("capped", func(t *testing.T) {
+		assert := assert.New(t)
+		// base fee is 2500, required fee is 10000
+		dalc := newClient(100000, 7500)
+
+		resp := dalc.SubmitBlock(ctx, block)
+		assert.Equal(da.StatusInsufficientFee, resp.Code, resp.Message)
+		bumps := 0
+		for dalc.BumpFee() {
+			bumps++
+			resp = dalc.SubmitBlock(ctx, block)
+			assert.Equal(da.StatusInsufficientFee, resp.Code, resp.Message)
+		}
+		// 2500 -> 3750 -> 5625 -> 7500
+		assert.Equal(3, bumps)
+	})
+}
+
+func TestMultiQuorum(t *testing.T) {
+	mockConf, _ := json.Marshal(mockDaBlockTime.String())
+	// nothing listens on this port, so submissions to gRPC client always fail
+	grpcConf, _ := json.Marshal(grpcda.Config{Host: "127.0.0.1", Port: 1})
+
+	cases := []struct {
+		name     string
+		quorum   int
+		expected da.StatusCode
+	}{
+		{"quorum reached", 1, da.StatusSuccess},
+		{"quorum not reached", 2, da.StatusError},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ctx := context.Background()
+			require := require.New(t)
+			assert := assert.New(t)
+
+			config := multi.Config{
+				Clients: []da.ClientConfig{
+					{Name: "grpc", Config: grpcConf},
+					{Name: "mock", Config: mockConf},
+				},
+				Quorum: c.quorum,
+			}
+			conf, _ := json.Marshal(config)
+			kvStore, _ := store.NewDefaultInMemoryKVStore()
+			dalc := registry.GetClient("multi")
+			require.NoError(dalc.Init(testNamespaceID, conf, kvStore, test.NewLogger(t)))
+			require.NoError(dalc.Start())
+			defer func() {
+				_ = dalc.Stop()
+			}()
+
+			// wait a bit more than mockDaBlockTime, so mock can "produce" some blocks
+			time.Sleep(mockDaBlockTime + 20*time.Millisecond)
+
+			b := getRandomBlock(1, 10)
+			resp := dalc.SubmitBlock(ctx, b)
+			require.Equal(c.expected, resp.Code, resp.Message)
+			if c.expected != da.StatusSuccess {
+				return
+			}
+
+			// wait a bit more than mockDaBlockTime, so Rollkit blocks can be "included" in mock block
+			time.Sleep(mockDaBlockTime + 20*time.Millisecond)
+
+			// retrieval from gRPC client fails, so it falls through to the mock
+			ret := dalc.(da.BlockRetriever).RetrieveBlocks(ctx, resp.DAHeight)
+			require.Equal(da.StatusSuccess, ret.Code, ret.Message)
+			require.Len(ret.Blocks, 1)
+			assert.Equal(b, ret.Blocks[0])
+		})
+	}
+
+	dalc := registry.GetClient("multi")
+	config, _ := json.Marshal(multi.Config{Clients: []da.ClientConfig{{Name: "mock", Config: mockConf}}, Quorum: 2})
+	assert.Error(t, dalc.Init(testNamespaceID, config, nil, test.NewLogger(t)))
+}
+
+func initDALC(t *testing.T, dalc da.DataAvailabilityLayerClient) {
+	t.Helper()
+	require := require.New(t)
+
+	// mock DALC will advance block height every 100ms
+	conf := []byte{}
+	if _, ok := dalc.(*mock.DataAvailabilityLayerClient); ok {
+		conf = []byte(mockDaBlockTime.String())
+	}
+	if _, ok := dalc.(*celestia.DataAvailabilityLayerClient); ok {
+		config := celestia.Config{
+			BaseURL:  "http://localhost:26658",
+			Timeout:  30 * time.Second,
+			GasLimit: 3000000,
+		}
+		conf, _ = json.Marshal(config)
+	}
+	if _, ok := dalc.(*multi.DataAvailabilityLayerClient); ok {
+		// first client is unreachable, so all requests fall through to the mock
+		mockConf, _ := json.Marshal(mockDaBlockTime.String())
+		grpcConf, _ := json.Marshal(grpcda.Config{Host: "127.0.0.1", Port: 1})
+		config := multi.Config{
+			Clients: []da.ClientConfig{
+				{Name: "grpc", Config: grpcConf},
+				{Name: "mock", Config: mockConf},
+			},
+			Quorum: 1,
+		}
+		conf, _ = json.Marshal(config)
+	}
+	if _, ok := dalc.(*failover.DataAvailabilityLayerClient); ok {
+		// primary backend is unreachable, so client fails over to the mock
+		mockConf, _ := json.Marshal(mockDaBlockTime.String())
+		grpcConf, _ := json.Marshal(grpcda.Config{Host: "127.0.0.1", Port: 1})
+		config := failover.Config{
+			Backends: []da.ClientConfig{
+				{Name: "grpc", Config: grpcConf},
+				{Name: "mock", Config: mockConf},
+			},
+			FailureThreshold: 1,
+			Cooldown:         time.Minute,
+		}
+		conf, _ = json.Marshal(config)
+	}
+	if _, ok := dalc.(*chaos.DataAvailabilityLayerClient); ok {
+		// only latency is injected, so results are the same as for the mock
+		mockConf, _ := json.Marshal(mockDaBlockTime.String())
+		config := chaos.Config{
+			Backend:       da.ClientConfig{Name: "mock", Config: mockConf},
+			Latency:       time.Millisecond,
+			LatencyJitter: 5 * time.Millisecond,
+		}
+		conf, _ = json.Marshal(config)
+	}
+	kvStore, _ := store.NewDefaultInMemoryKVStore()
+	err := dalc.Init(testNamespaceID, conf, kvStore, test.NewLogger(t))
+	require.NoError(err)
+
+	err = dalc.Start()
+	require.NoError(err)
+}
+
 func startMockGRPCServ(t *testing.T) *grpc.Server {
 	t.Helper()
 	conf := grpcda.DefaultConfig
 	logger := tmlog.NewTMLogger(os.Stdout)
 
 	kvStore, _ := store.NewDefaultInMemoryKVStore()
-	srv := mockserv.GetServer(kvStore, conf, []byte(mockDaBlockTime.String()), logger)
+	srv, err := mockserv.GetServer(kvStore, grpcda.ServerConfig{}, []byte(mockDaBlockTime.String()), logger)
+	if err != nil {
+		t.Fatal(err)
+	}
 	lis, err := net.Listen("tcp", conf.Host+":"+strconv.Itoa(conf.Port))
 	if err != nil {
 		t.Fatal(err)
 	}
+	// server can be stopped before Serve is called - in such case listener is closed asynchronously
+	t.Cleanup(func() {
+		_ = lis.Close()
+	})
 	go func() {
 		_ = srv.Serve(lis)
 	}()
 	return srv
 }
 
-func startMockCelestiaNodeServer(t *testing.T) *cmock.Server {
+func startMockCelestiaNodeServer(t *testing.T, options ...cmock.Option) *cmock.Server {
 	t.Helper()
-	httpSrv := cmock.NewServer(mockDaBlockTime, test.NewLogger(t))
+	httpSrv := cmock.NewServer(mockDaBlockTime, test.NewLogger(t), options...)
 	l, err := net.Listen("tcp4", "127.0.0.1:26658")
 	if err != nil {
 		t.Fatal("failed to create listener for mock celestia-node RPC server", "error", err)
@@ -181,25 +776,7 @@ func doTestRetrieve(t *testing.T, dalc da.DataAvailabilityLayerClient) {
 	require := require.New(t)
 	assert := assert.New(t)
 
-	// mock DALC will advance block height every 100ms
-	conf := []byte{}
-	if _, ok := dalc.(*mock.DataAvailabilityLayerClient); ok {
-		conf = []byte(mockDaBlockTime.String())
-	}
-	if _, ok := dalc.(*celestia.DataAvailabilityLayerClient); ok {
-		config := celestia.Config{
-			BaseURL:  "http://localhost:26658",
-			Timeout:  30 * time.Second,
-			GasLimit: 3000000,
-		}
-		conf, _ = json.Marshal(config)
-	}
-	kvStore, _ := store.NewDefaultInMemoryKVStore()
-	err := dalc.Init(testNamespaceID, conf, kvStore, test.NewLogger(t))
-	require.NoError(err)
-
-	err = dalc.Start()
-	require.NoError(err)
+	initDALC(t, dalc)
 
 	// wait a bit more than mockDaBlockTime, so mock can "produce" some blocks
 	time.Sleep(mockDaBlockTime + 20*time.Millisecond)
@@ -0,0 +1,193 @@
+package lease
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// lockRetryInterval is the interval between attempts to lock the lease file.
+	lockRetryInterval = 10 * time.Millisecond
+	// staleLockTimeout is the time after which lock file held by the same owner is considered abandoned by crashed
+	// process, and removed.
+	staleLockTimeout = 10 * time.Second
+	// maxClockSkew is the maximum expected difference between clocks of machines sharing the lease file. Lease
+	// holder stops signing blocks maxClockSkew before lease expiry.
+	maxClockSkew = time.Second
+)
+
+// errLockLost is returned when lock file was removed or taken over by another process, while lease was updated.
+var errLockLost = errors.New("lease file lock was lost")
+
+// File is a lease backend stored in a file. Lease is updated under exclusive lock file (created next to the lease
+// file), so the file can be shared by aggregators on different machines, using shared filesystem.
+//
+// Lock file contains unique token of its owner. It's created atomically with hard link, so it's never observed
+// partially written, and it's considered stale only if the same token is observed for staleLockTimeout, measured
+// with local clock - clocks of machines sharing the filesystem are not compared. Token is checked again before lease
+// file is written, so lease is not updated by process that lost the lock.
+type File struct {
+	path         string
+	now          func() time.Time
+	staleTimeout time.Duration
+	clockSkew    time.Duration
+}
+
+var _ Backend = &File{}
+
+// NewFile creates lease backend stored in file at given path.
+func NewFile(path string) *File {
+	return &File{path: path, now: time.Now, staleTimeout: staleLockTimeout, clockSkew: maxClockSkew}
+}
+
+// Acquire implements Backend.
+func (f *File) Acquire(ctx context.Context, holder string, duration time.Duration) (Lease, error) {
+	var l Lease
+	err := f.update(ctx, func(lease *Lease) (bool, error) {
+		err := lease.acquire(holder, f.now(), duration)
+		l = *lease
+		return err == nil, err
+	})
+	return l, err
+}
+
+// Sign implements Backend.
+func (f *File) Sign(ctx context.Context, holder string, height uint64) error {
+	return f.update(ctx, func(lease *Lease) (bool, error) {
+		err := lease.sign(holder, f.now(), f.clockSkew, height)
+		return err == nil, err
+	})
+}
+
+// Release implements Backend.
+func (f *File) Release(ctx context.Context, holder string) error {
+	return f.update(ctx, func(lease *Lease) (bool, error) {
+		return lease.release(holder), nil
+	})
+}
+
+// update reads the lease, modifies it and writes it back if needed, under exclusive lock.
+func (f *File) update(ctx context.Context, modify func(*Lease) (bool, error)) error {
+	token, err := f.lock(ctx)
+	if err != nil {
+		return err
+	}
+	defer f.unlock(token)
+
+	var l Lease
+	data, err := os.ReadFile(f.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read lease file: %w", err)
+	}
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &l); err != nil {
+			return fmt.Errorf("failed to decode lease file: %w", err)
+		}
+	}
+
+	changed, modifyErr := modify(&l)
+	if !changed {
+		return modifyErr
+	}
+	data, err = json.Marshal(l)
+	if err != nil {
+		return err
+	}
+	// lease file is replaced atomically, so it's never partially written
+	tmp := f.path + "." + token + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write lease file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp) }()
+	if !f.holdsLock(token) {
+		return errLockLost
+	}
+	if err := os.Rename(tmp, f.path); err != nil {
+		return fmt.Errorf("failed to write lease file: %w", err)
+	}
+	return modifyErr
+}
+
+func (f *File) lockPath() string {
+	return f.path + ".lock"
+}
+
+// lock creates lock file with unique token, waiting until it's removed by other process. Token is returned.
+func (f *File) lock(ctx context.Context) (string, error) {
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return "", err
+	}
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	token := hex.EncodeToString(b)
+	// lock file is hard link to fully written file, so it's created atomically, only if it doesn't exist
+	tmp := f.lockPath() + "." + token
+	if err := os.WriteFile(tmp, []byte(token), 0o600); err != nil {
+		return "", fmt.Errorf("failed to lock lease file: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp) }()
+
+	var owner string
+	var ownerSince time.Time
+	for {
+		err := os.Link(tmp, f.lockPath())
+		if err == nil {
+			return token, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", fmt.Errorf("failed to lock lease file: %w", err)
+		}
+		current, err := os.ReadFile(f.lockPath())
+		if err != nil {
+			// lock file was removed in the meantime
+			owner = ""
+		} else if string(current) != owner {
+			owner, ownerSince = string(current), time.Now()
+		} else if time.Since(ownerSince) > f.staleTimeout {
+			f.breakLock(owner, token)
+			owner = ""
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
+// breakLock removes lock file abandoned by the owner with stale token. Lock file is atomically renamed first, so
+// the lock taken by another process after it was found stale, is not removed - it's restored instead.
+func (f *File) breakLock(stale string, token string) {
+	broken := f.lockPath() + "." + token + ".stale"
+	if err := os.Rename(f.lockPath(), broken); err != nil {
+		return
+	}
+	defer func() { _ = os.Remove(broken) }()
+	if owner, err := os.ReadFile(broken); err == nil && string(owner) != stale {
+		// restoring fails if lock was taken again; previous owner finds out it lost the lock, before writing lease
+		_ = os.Link(broken, f.lockPath())
+	}
+}
+
+// holdsLock returns true if lock file is owned by the owner with given token.
+func (f *File) holdsLock(token string) bool {
+	owner, err := os.ReadFile(f.lockPath())
+	return err == nil && string(owner) == token
+}
+
+func (f *File) unlock(token string) {
+	if f.holdsLock(token) {
+		_ = os.Remove(f.lockPath())
+	}
+}
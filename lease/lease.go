@@ -0,0 +1,92 @@
+// Package lease provides leader lease used to coordinate hot-standby aggregators sharing the same signing key.
+//
+// Only the holder of the lease produces blocks. Lease has to be renewed before it expires; after expiry it can be
+// acquired by another aggregator. Lease also records the height of the last signed block, so blocks are never signed
+// twice for the same height, even if the previous holder is still running.
+package lease
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrHeld is returned when lease is held by another holder.
+	ErrHeld = errors.New("lease is held by another holder")
+	// ErrNotHolder is returned when holder tries to sign a block without holding the lease.
+	ErrNotHolder = errors.New("lease is not held")
+	// ErrAlreadySigned is returned when block at given height was already signed.
+	ErrAlreadySigned = errors.New("block at this height was already signed")
+)
+
+// Lease is the state of leader lease.
+type Lease struct {
+	// Holder identifies the current (or the last) holder of the lease.
+	Holder string `json:"holder"`
+	// Expiry is the time after which lease can be acquired by another holder.
+	Expiry time.Time `json:"expiry"`
+	// Height is the height of the last block signed by any holder.
+	Height uint64 `json:"height"`
+}
+
+// Backend stores leader lease shared by aggregators.
+type Backend interface {
+	// Acquire acquires or renews the lease for holder, for given duration. If lease is held by another holder and
+	// it's not expired, ErrHeld is returned. Current state of the lease is returned.
+	Acquire(ctx context.Context, holder string, duration time.Duration) (Lease, error)
+	// Sign records that holder signs block at given height. It fails if holder doesn't hold unexpired lease, or if
+	// block at given height (or higher) was already signed.
+	Sign(ctx context.Context, holder string, height uint64) error
+	// Release releases the lease held by holder, so it can be acquired by another holder immediately.
+	Release(ctx context.Context, holder string) error
+}
+
+// New creates lease backend from specification in "type:argument" format.
+// Supported backends:
+//   - "file:<path>" - lease stored in a file, that can be shared by aggregators (for example on network filesystem).
+func New(spec string) (Backend, error) {
+	kind, arg, _ := strings.Cut(spec, ":")
+	switch kind {
+	case "file":
+		if arg == "" {
+			return nil, errors.New("lease file path is not set")
+		}
+		return NewFile(arg), nil
+	default:
+		return nil, fmt.Errorf("unknown lease backend: %q", kind)
+	}
+}
+
+func (l *Lease) acquire(holder string, now time.Time, duration time.Duration) error {
+	if l.Holder != holder && now.Before(l.Expiry) {
+		return fmt.Errorf("%w: %s until %s", ErrHeld, l.Holder, l.Expiry)
+	}
+	l.Holder = holder
+	l.Expiry = now.Add(duration)
+	return nil
+}
+
+// sign records height signed by holder. Lease is considered expired skew before its expiry, as clock of the next
+// holder can be ahead of the clock of the current holder.
+func (l *Lease) sign(holder string, now time.Time, skew time.Duration, height uint64) error {
+	if l.Holder != holder || !now.Before(l.Expiry.Add(-skew)) {
+		return ErrNotHolder
+	}
+	if height <= l.Height {
+		return fmt.Errorf("%w: signed height %d, requested %d", ErrAlreadySigned, l.Height, height)
+	}
+	l.Height = height
+	return nil
+}
+
+func (l *Lease) release(holder string) bool {
+	if l.Holder != holder {
+		return false
+	}
+	// height is kept, to prevent signing blocks at the same height by the next holder
+	l.Expiry = time.Time{}
+	return true
+}
@@ -0,0 +1,143 @@
+package lease
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackends(t *testing.T) {
+	cases := []struct {
+		name string
+		new  func(t *testing.T, now func() time.Time) Backend
+	}{
+		{"memory", func(t *testing.T, now func() time.Time) Backend {
+			m := NewMemory()
+			m.now = now
+			return m
+		}},
+		{"file", func(t *testing.T, now func() time.Time) Backend {
+			f := NewFile(filepath.Join(t.TempDir(), "lease", "lease.json"))
+			f.now = now
+			return f
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+			ctx := context.Background()
+
+			now := time.Unix(1000, 0)
+			backend := c.new(t, func() time.Time { return now })
+
+			l, err := backend.Acquire(ctx, "primary", 10*time.Second)
+			require.NoError(err)
+			assert.Equal("primary", l.Holder)
+			assert.Equal(now.Add(10*time.Second), l.Expiry)
+			require.NoError(backend.Sign(ctx, "primary", 1))
+			require.NoError(backend.Sign(ctx, "primary", 2))
+			assert.ErrorIs(backend.Sign(ctx, "primary", 2), ErrAlreadySigned)
+
+			// lease is held until it expires
+			_, err = backend.Acquire(ctx, "standby", 10*time.Second)
+			assert.ErrorIs(err, ErrHeld)
+			assert.ErrorIs(backend.Sign(ctx, "standby", 3), ErrNotHolder)
+			now = now.Add(5 * time.Second)
+			_, err = backend.Acquire(ctx, "primary", 10*time.Second)
+			require.NoError(err)
+			now = now.Add(9 * time.Second)
+			_, err = backend.Acquire(ctx, "standby", 10*time.Second)
+			assert.ErrorIs(err, ErrHeld)
+
+			// after expiry, lease is taken over with the height signed by the previous holder
+			now = now.Add(time.Second)
+			l, err = backend.Acquire(ctx, "standby", 10*time.Second)
+			require.NoError(err)
+			assert.Equal("standby", l.Holder)
+			assert.Equal(uint64(2), l.Height)
+			assert.ErrorIs(backend.Sign(ctx, "primary", 3), ErrNotHolder)
+			assert.ErrorIs(backend.Sign(ctx, "standby", 2), ErrAlreadySigned)
+			require.NoError(backend.Sign(ctx, "standby", 3))
+
+			// released lease can be acquired immediately
+			require.NoError(backend.Release(ctx, "primary"))
+			_, err = backend.Acquire(ctx, "primary", 10*time.Second)
+			assert.ErrorIs(err, ErrHeld)
+			require.NoError(backend.Release(ctx, "standby"))
+			l, err = backend.Acquire(ctx, "primary", 10*time.Second)
+			require.NoError(err)
+			assert.Equal(uint64(3), l.Height)
+		})
+	}
+}
+
+func TestFileLock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+	ctx := context.Background()
+
+	f := NewFile(filepath.Join(t.TempDir(), "lease.json"))
+	f.staleTimeout = 50 * time.Millisecond
+
+	// lock abandoned by crashed process is broken after the same owner is observed for stale timeout
+	require.NoError(os.WriteFile(f.lockPath(), []byte("crashed"), 0o600))
+	start := time.Now()
+	_, err := f.Acquire(ctx, "primary", time.Second)
+	require.NoError(err)
+	assert.GreaterOrEqual(time.Since(start), f.staleTimeout)
+	assert.NoFileExists(f.lockPath())
+
+	// lock taken by another process after it was found stale is restored
+	require.NoError(os.WriteFile(f.lockPath(), []byte("fresh"), 0o600))
+	f.breakLock("crashed", "breaker")
+	owner, err := os.ReadFile(f.lockPath())
+	require.NoError(err)
+	assert.Equal("fresh", string(owner))
+	require.NoError(os.Remove(f.lockPath()))
+
+	// lease is not written by process that lost the lock
+	token, err := f.lock(ctx)
+	require.NoError(err)
+	assert.True(f.holdsLock(token))
+	require.NoError(os.WriteFile(f.lockPath(), []byte("other"), 0o600))
+	assert.False(f.holdsLock(token))
+	f.unlock(token)
+	assert.FileExists(f.lockPath())
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	_, err = f.Acquire(ctx, "primary", time.Second)
+	assert.ErrorIs(err, context.DeadlineExceeded)
+}
+
+func TestFileClockSkew(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	f := NewFile(filepath.Join(t.TempDir(), "lease.json"))
+	f.now = func() time.Time { return now }
+
+	_, err := f.Acquire(ctx, "primary", 10*time.Second)
+	require.NoError(t, err)
+	require.NoError(t, f.Sign(ctx, "primary", 1))
+
+	// clock of the next holder can be ahead, so blocks are not signed shortly before expiry
+	now = now.Add(10*time.Second - maxClockSkew)
+	assert.ErrorIs(t, f.Sign(ctx, "primary", 2), ErrNotHolder)
+}
+
+func TestNew(t *testing.T) {
+	backend, err := New("file:" + filepath.Join(t.TempDir(), "lease.json"))
+	assert.NoError(t, err)
+	assert.IsType(t, &File{}, backend)
+
+	_, err = New("file:")
+	assert.Error(t, err)
+	_, err = New("etcd:localhost:2379")
+	assert.Error(t, err)
+}
@@ -0,0 +1,45 @@
+package lease
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is a lease backend kept in memory. It can be shared only by aggregators running in the same process, so
+// it's intended for testing. Aggregators share the same clock, so no clock skew margin is used.
+type Memory struct {
+	mtx   sync.Mutex
+	lease Lease
+	now   func() time.Time
+}
+
+var _ Backend = &Memory{}
+
+// NewMemory creates new in-memory lease backend.
+func NewMemory() *Memory {
+	return &Memory{now: time.Now}
+}
+
+// Acquire implements Backend.
+func (m *Memory) Acquire(_ context.Context, holder string, duration time.Duration) (Lease, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	err := m.lease.acquire(holder, m.now(), duration)
+	return m.lease, err
+}
+
+// Sign implements Backend.
+func (m *Memory) Sign(_ context.Context, holder string, height uint64) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.lease.sign(holder, m.now(), 0, height)
+}
+
+// Release implements Backend.
+func (m *Memory) Release(_ context.Context, holder string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.lease.release(holder)
+	return nil
+}
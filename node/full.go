@@ -6,10 +6,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"path/filepath"
 
 	ds "github.com/ipfs/go-datastore"
 	ktds "github.com/ipfs/go-datastore/keytransform"
 	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
 	"go.uber.org/multierr"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -23,7 +26,9 @@ import (
 	"github.com/rollkit/rollkit/block"
 	"github.com/rollkit/rollkit/config"
 	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/da/plugin"
 	"github.com/rollkit/rollkit/da/registry"
+	"github.com/rollkit/rollkit/lease"
 	"github.com/rollkit/rollkit/mempool"
 	mempoolv1 "github.com/rollkit/rollkit/mempool/v1"
 	"github.com/rollkit/rollkit/p2p"
@@ -45,6 +50,9 @@ const (
 	// genesisChunkSize is the maximum size, in bytes, of each
 	// chunk in the genesis structure for the chunked API
 	genesisChunkSize = 16 * 1024 * 1024 // 16 MiB
+
+	// signStateFile is the default name of the file with the last-sign-state of the proposer key
+	signStateFile = "rollkit_sign_state.json"
 )
 
 var _ Node = &FullNode{}
@@ -78,6 +86,9 @@ type FullNode struct {
 
 	hExService *HeaderExchangeService
 
+	// serves Prometheus metrics, if enabled
+	prometheusSrv *http.Server
+
 	// keep context here only because of API compatibility
 	// - it's used in `OnStart` (defined in service.Service interface)
 	ctx context.Context
@@ -98,6 +109,13 @@ func newFullNode(
 	genesis *tmtypes.GenesisDoc,
 	logger log.Logger,
 ) (*FullNode, error) {
+	if conf.Aggregator && conf.Based {
+		return nil, errors.New("aggregator mode can't be used with based sequencing")
+	}
+	if conf.LeaseBackend != "" && !conf.Aggregator {
+		return nil, errors.New("leader lease can be used only in aggregator mode")
+	}
+
 	proxyApp := proxy.NewAppConns(clientCreator)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
 	if err := proxyApp.Start(); err != nil {
@@ -132,6 +150,13 @@ func newFullNode(
 	}
 	s := store.New(ctx, mainKV)
 
+	plugins, err := plugin.ParseSpecs(conf.DAPlugins)
+	if err != nil {
+		return nil, err
+	}
+	if err := plugin.Register(plugins); err != nil {
+		return nil, fmt.Errorf("failed to register data availability layer plugins: %w", err)
+	}
 	dalc := registry.GetClient(conf.DALayer)
 	if dalc == nil {
 		return nil, fmt.Errorf("couldn't get data availability client named '%s'", conf.DALayer)
@@ -140,26 +165,51 @@ func newFullNode(
 	if err != nil {
 		return nil, fmt.Errorf("data availability layer client initialization error: %w", err)
 	}
+	metrics := newMetrics(conf.Instrumentation, genesis.ChainID)
+	metrics.setDAMetrics(dalc)
 
 	indexerService, txIndexer, blockIndexer, err := createAndStartIndexerService(ctx, conf, indexerKV, eventBus, logger)
 	if err != nil {
 		return nil, err
 	}
 
-	mp := mempoolv1.NewTxMempool(logger, llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0)
+	mp := mempoolv1.NewTxMempool(logger, llcfg.DefaultMempoolConfig(), proxyApp.Mempool(), 0, mempoolv1.WithMetrics(metrics.Mempool))
 	mpIDs := newMempoolIDs()
 	mp.EnableTxsAvailable()
 
+	if conf.SignStateFile == "" && conf.RootDir != "" {
+		// last-sign-state is kept in data directory, like Tendermint's priv_validator_state.json
+		dataDir := conf.DBPath
+		if !filepath.IsAbs(dataDir) {
+			dataDir = filepath.Join(conf.RootDir, dataDir)
+		}
+		conf.SignStateFile = filepath.Join(dataDir, signStateFile)
+	}
+
 	doneBuildingChannel := make(chan struct{})
 	blockManager, err := block.NewManager(signingKey, conf.BlockManagerConfig, genesis, s, mp, proxyApp.Consensus(), dalc, eventBus, logger.With("module", "BlockManager"), doneBuildingChannel)
 	if err != nil {
 		return nil, fmt.Errorf("BlockManager initialization error: %w", err)
 	}
+	blockManager.SetMetrics(metrics.Block)
+	if conf.LeaseBackend != "" {
+		leaseBackend, err := lease.New(conf.LeaseBackend)
+		if err != nil {
+			return nil, fmt.Errorf("leader lease initialization error: %w", err)
+		}
+		// aggregators sharing the signing key are distinguished by their P2P identity
+		holder, err := peer.IDFromPrivateKey(p2pKey)
+		if err != nil {
+			return nil, err
+		}
+		blockManager.SetLease(leaseBackend, holder.String())
+	}
 
 	headerExchangeService, err := NewHeaderExchangeService(ctx, mainKV, conf, genesis, client, logger.With("module", "HeaderExchangeService"))
 	if err != nil {
 		return nil, fmt.Errorf("HeaderExchangeService initialization error: %w", err)
 	}
+	blockManager.SetHeaderStore(headerExchangeService.headerStore)
 
 	ctx, cancel := context.WithCancel(ctx)
 
@@ -225,7 +275,9 @@ func (n *FullNode) headerPublishLoop(ctx context.Context) {
 	for {
 		select {
 		case signedHeader := <-n.blockManager.HeaderCh:
-			n.hExService.writeToHeaderStoreAndBroadcast(ctx, signedHeader)
+			if err := n.hExService.writeToHeaderStoreAndBroadcast(ctx, signedHeader); err == nil {
+				n.blockManager.SetSoftConfirmed(uint64(signedHeader.Height()))
+			}
 		case <-ctx.Done():
 			return
 		}
@@ -269,13 +321,22 @@ func (n *FullNode) OnStart() error {
 	if err = n.dalc.Start(); err != nil {
 		return fmt.Errorf("error while starting data availability layer client: %w", err)
 	}
+	if n.conf.Instrumentation.Prometheus {
+		n.prometheusSrv = startPrometheusServer(n.conf.Instrumentation, n.Logger)
+	}
 	if n.conf.Aggregator {
 		n.Logger.Info("working in aggregator mode", "block time", n.conf.BlockTime)
 		go n.blockManager.AggregationLoop(n.ctx, n.conf.LazyAggregator)
+		go n.blockManager.SubmissionLoop(n.ctx, n.cancel)
 		go n.headerPublishLoop(n.ctx)
 	}
-	go n.blockManager.RetrieveLoop(n.ctx)
-	go n.blockManager.SyncLoop(n.ctx, n.cancel)
+	if n.conf.Based {
+		n.Logger.Info("working in based sequencing mode")
+		go n.blockManager.BasedLoop(n.ctx)
+	} else {
+		go n.blockManager.RetrieveLoop(n.ctx, n.cancel)
+		go n.blockManager.SyncLoop(n.ctx, n.cancel)
+	}
 	go n.fraudProofPublishLoop(n.ctx)
 
 	return nil
@@ -302,6 +363,9 @@ func (n *FullNode) OnStop() {
 	err := n.dalc.Stop()
 	err = multierr.Append(err, n.P2P.Close())
 	err = multierr.Append(err, n.hExService.Stop())
+	if n.prometheusSrv != nil {
+		err = multierr.Append(err, n.prometheusSrv.Shutdown(context.Background()))
+	}
 	n.Logger.Error("errors while stopping node:", "errors", err)
 }
 
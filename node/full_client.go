@@ -7,6 +7,7 @@ import (
 	"sort"
 	"time"
 
+	ds "github.com/ipfs/go-datastore"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/config"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
@@ -24,6 +25,7 @@ import (
 	rconfig "github.com/rollkit/rollkit/config"
 	abciconv "github.com/rollkit/rollkit/conv/abci"
 	"github.com/rollkit/rollkit/mempool"
+	"github.com/rollkit/rollkit/types"
 )
 
 const (
@@ -435,6 +437,47 @@ func (c *FullClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBl
 	}, nil
 }
 
+// BlockStatus returns finality status of a block at given height.
+//
+// Blocks without recorded status (e.g. created before finality tracking was introduced) have unknown finality.
+func (c *FullClient) BlockStatus(ctx context.Context, height *int64) (*types.BlockStatus, error) {
+	heightValue := c.normalizeHeight(height)
+	if heightValue > c.node.Store.Height() {
+		return nil, fmt.Errorf("height %d is not available, latest height is %d", heightValue, c.node.Store.Height())
+	}
+	status, err := c.node.Store.LoadBlockStatus(heightValue)
+	if errors.Is(err, ds.ErrNotFound) {
+		return &types.BlockStatus{Height: heightValue, Finality: types.FinalityUnknown}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &status, nil
+}
+
+// DAIncludedHeight returns height of the last block known to be included in DA layer.
+func (c *FullClient) DAIncludedHeight(ctx context.Context) (uint64, error) {
+	return c.node.blockManager.DAIncludedHeight(), nil
+}
+
+// DAInclusion returns information about inclusion of a block at given height in DA layer.
+func (c *FullClient) DAInclusion(ctx context.Context, height *int64) (*types.DAInclusion, error) {
+	inclusion, err := c.node.Store.LoadDAInclusion(c.normalizeHeight(height))
+	if err != nil {
+		return nil, err
+	}
+	return &inclusion, nil
+}
+
+// DAInclusionByHash returns information about inclusion of a block with given hash in DA layer.
+func (c *FullClient) DAInclusionByHash(ctx context.Context, hash []byte) (*types.DAInclusion, error) {
+	inclusion, err := c.node.Store.LoadDAInclusionByHash(hash)
+	if err != nil {
+		return nil, err
+	}
+	return &inclusion, nil
+}
+
 // BlockByHash returns BlockID and block itself for given hash.
 func (c *FullClient) BlockByHash(ctx context.Context, hash []byte) (*ctypes.ResultBlock, error) {
 	block, err := c.node.Store.LoadBlockByHash(hash)
@@ -543,7 +586,7 @@ func (c *FullClient) Tx(ctx context.Context, hash []byte, prove bool) (*ctypes.R
 	var proof tmtypes.TxProof
 	if prove {
 		block, _ := c.node.Store.LoadBlock(uint64(height))
-		blockProof := block.Data.Txs.Proof(int(index)) // XXX: overflow on 32-bit machines
+		blockProof := block.Data.TxProof(int(index)) // XXX: overflow on 32-bit machines
 		proof = tmtypes.TxProof{
 			RootHash: blockProof.RootHash,
 			Data:     tmtypes.Tx(blockProof.Data),
@@ -290,6 +290,35 @@ func TestGetBlock(t *testing.T) {
 	require.NoError(err)
 }
 
+func TestGetBlockStatus(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, rpc := getRPC(t)
+
+	for h := uint64(1); h <= 2; h++ {
+		require.NoError(rpc.node.Store.SaveBlock(getRandomBlock(h, 1), &types.Commit{}))
+		rpc.node.Store.SetHeight(h)
+	}
+	expected := types.BlockStatus{Height: 1, Finality: types.FinalityDAIncluded, DAHeight: 7}
+	require.NoError(rpc.node.Store.SaveBlockStatus(expected))
+
+	h := int64(1)
+	status, err := rpc.BlockStatus(context.Background(), &h)
+	require.NoError(err)
+	assert.Equal(expected, *status)
+
+	// block without recorded status
+	status, err = rpc.BlockStatus(context.Background(), nil)
+	require.NoError(err)
+	assert.Equal(uint64(2), status.Height)
+	assert.Equal(types.FinalityUnknown, status.Finality)
+
+	h = 3
+	_, err = rpc.BlockStatus(context.Background(), &h)
+	assert.Error(err)
+}
+
 func TestGetCommit(t *testing.T) {
 	require := require.New(t)
 	assert := assert.New(t)
@@ -18,7 +18,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/libs/log"
+	tmquery "github.com/tendermint/tendermint/libs/pubsub/query"
 	"github.com/tendermint/tendermint/proxy"
 	tmtypes "github.com/tendermint/tendermint/types"
 
@@ -192,6 +194,120 @@ func TestLazyAggregator(t *testing.T) {
 
 }
 
+func TestBatchedDASubmission(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+	app.On("GetAppHash", mock.Anything).Return(abci.ResponseGetAppHash{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	genesisValidators, signingKey := getGenesisValidatorSetWithSigner(1)
+	blockManagerConfig := config.BlockManagerConfig{
+		BlockTime:      1 * time.Second,
+		DABlockTime:    100 * time.Millisecond,
+		NamespaceID:    types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+		DABatchSize:    2,
+		DABatchTimeout: 10 * time.Second,
+	}
+	node, err := newFullNode(context.Background(), config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: blockManagerConfig}, key, signingKey, proxy.NewLocalClientCreator(app), &tmtypes.GenesisDoc{ChainID: "test", Validators: genesisValidators}, log.TestingLogger())
+	require.NoError(err)
+	require.NotNil(node)
+
+	dalc := &mockda.DataAvailabilityLayerClient{}
+	ds, _ := store.NewDefaultInMemoryKVStore()
+	_ = dalc.Init([8]byte{}, []byte((100 * time.Millisecond).String()), ds, log.TestingLogger())
+	_ = dalc.Start()
+	node.dalc = dalc
+	node.blockManager.SetDALC(dalc)
+
+	require.NoError(node.Start())
+	time.Sleep(4500 * time.Millisecond)
+	require.NoError(node.Stop())
+	time.Sleep(200 * time.Millisecond)
+
+	require.GreaterOrEqual(node.Store.Height(), uint64(4))
+	submitted := 0
+	for h := uint64(1); ; h++ {
+		res := dalc.RetrieveBlocks(context.Background(), h)
+		if res.Code != da.StatusSuccess && res.Code != da.StatusNamespaceEmpty {
+			break
+		}
+		if len(res.Blocks) > 0 {
+			// blocks are submitted in pairs
+			assert.Len(res.Blocks, 2, "DA height %d", h)
+		}
+		submitted += len(res.Blocks)
+	}
+	assert.GreaterOrEqual(submitted, 4)
+	assert.Equal(0, submitted%2)
+}
+
+func TestBlockFinalityEvents(t *testing.T) {
+	require := require.New(t)
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+	app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+	app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+	app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+	app.On("GetAppHash", mock.Anything).Return(abci.ResponseGetAppHash{})
+
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	genesisValidators, signingKey := getGenesisValidatorSetWithSigner(1)
+	blockManagerConfig := config.BlockManagerConfig{
+		BlockTime:   1 * time.Second,
+		DABlockTime: 100 * time.Millisecond,
+		NamespaceID: types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+	}
+	node, err := newFullNode(context.Background(), config.NodeConfig{DALayer: "mock", Aggregator: true, BlockManagerConfig: blockManagerConfig}, key, signingKey, proxy.NewLocalClientCreator(app), &tmtypes.GenesisDoc{ChainID: "test", Validators: genesisValidators}, log.TestingLogger())
+	require.NoError(err)
+	require.NotNil(node)
+
+	sub, err := node.EventBus().Subscribe(context.Background(), "test", tmquery.MustParse(fmt.Sprintf("%s='%s'", tmtypes.EventTypeKey, types.EventBlockStatus)), 100)
+	require.NoError(err)
+
+	require.NoError(node.Start())
+	defer func() {
+		require.NoError(node.Stop())
+	}()
+
+	// soft confirmation is skipped if block is included in DA layer before it's gossiped
+	seen := make(map[types.FinalityStatus]bool)
+	timeout := time.After(5 * time.Second)
+	for !seen[types.FinalityProduced] || !seen[types.FinalityDAIncluded] {
+		select {
+		case msg := <-sub.Out():
+			status, ok := msg.Data().(types.BlockStatus)
+			require.True(ok)
+			if status.Height == 1 {
+				seen[status.Finality] = true
+			}
+		case <-timeout:
+			t.Fatalf("not all finality events received: %v", seen)
+		}
+	}
+
+	h := int64(1)
+	client := NewFullClient(node)
+	status, err := client.BlockStatus(context.Background(), &h)
+	require.NoError(err)
+	require.Equal(types.FinalityDAIncluded, status.Finality)
+
+	inclusion, err := client.DAInclusion(context.Background(), &h)
+	require.NoError(err)
+	require.Equal(status.DAHeight, inclusion.DAHeight)
+	require.NotEmpty(inclusion.Commitment)
+}
+
 func TestHeaderExchange(t *testing.T) {
 	testSingleAggreatorSingleFullNode(t)
 	testSingleAggreatorTwoFullNode(t)
@@ -557,3 +673,87 @@ func createNode(ctx context.Context, n int, isMalicious bool, aggregator bool, d
 
 	return node, app
 }
+
+// TestProposerRotation setups a network of aggregators sharing DA layer, where every aggregator is a validator.
+// Blocks are produced by validators in turns, and every node applies blocks produced by the others.
+func TestProposerRotation(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	const numNodes = 3
+	const numBlocks = 2 * numNodes
+
+	dalc := &mockda.DataAvailabilityLayerClient{}
+	ds, _ := store.NewDefaultInMemoryKVStore()
+	require.NoError(dalc.Init([8]byte{}, []byte((20 * time.Millisecond).String()), ds, log.TestingLogger()))
+	require.NoError(dalc.Start())
+	defer func() { _ = dalc.Stop() }()
+
+	vKeys := make([]ed25519.PrivKey, numNodes)
+	genesisValidators := make([]tmtypes.GenesisValidator, numNodes)
+	for i := range vKeys {
+		vKeys[i] = ed25519.GenPrivKey()
+		genesisValidators[i] = tmtypes.GenesisValidator{Address: vKeys[i].PubKey().Address(), PubKey: vKeys[i].PubKey(), Power: 100}
+	}
+	genesis := &tmtypes.GenesisDoc{ChainID: "test", Validators: genesisValidators}
+
+	nodes := make([]*FullNode, numNodes)
+	for i := range nodes {
+		app := &mocks.Application{}
+		app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+		app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+		app.On("BeginBlock", mock.Anything).Return(abci.ResponseBeginBlock{})
+		app.On("DeliverTx", mock.Anything).Return(abci.ResponseDeliverTx{})
+		app.On("EndBlock", mock.Anything).Return(abci.ResponseEndBlock{})
+		app.On("Commit", mock.Anything).Return(abci.ResponseCommit{})
+		app.On("GetAppHash", mock.Anything).Return(abci.ResponseGetAppHash{})
+
+		key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+		signingKey, err := crypto.UnmarshalEd25519PrivateKey(vKeys[i].Bytes())
+		require.NoError(err)
+		nodes[i], err = newFullNode(context.Background(), config.NodeConfig{
+			DALayer:    "mock",
+			Aggregator: true,
+			BlockManagerConfig: config.BlockManagerConfig{
+				BlockTime:   200 * time.Millisecond,
+				DABlockTime: 20 * time.Millisecond,
+				NamespaceID: types.NamespaceID{1, 2, 3, 4, 5, 6, 7, 8},
+			},
+		}, key, signingKey, proxy.NewLocalClientCreator(app), genesis, log.TestingLogger())
+		require.NoError(err)
+
+		// use same, common DALC, so nodes can share data
+		nodes[i].dalc = dalc
+		nodes[i].blockManager.SetDALC(dalc)
+	}
+	for _, node := range nodes {
+		require.NoError(node.Start())
+		defer func(node *FullNode) {
+			assert.NoError(node.Stop())
+		}(node)
+	}
+
+	for _, node := range nodes {
+		node := node
+		require.Eventually(func() bool {
+			return node.Store.Height() >= numBlocks
+		}, 30*time.Second, 50*time.Millisecond)
+	}
+
+	proposers := make(map[string]int)
+	for h := uint64(1); h <= numBlocks; h++ {
+		block, err := nodes[0].Store.LoadBlock(h)
+		require.NoError(err)
+		for _, node := range nodes[1:] {
+			synced, err := node.Store.LoadBlock(h)
+			require.NoError(err)
+			assert.Equal(block.Hash(), synced.Hash())
+		}
+		validators, err := nodes[0].Store.LoadValidators(h)
+		require.NoError(err)
+		assert.Equal(validators.GetProposer().Address.Bytes(), block.SignedHeader.ProposerAddress)
+		proposers[string(block.SignedHeader.ProposerAddress)]++
+	}
+	// every validator proposes blocks
+	assert.Len(proposers, numNodes)
+}
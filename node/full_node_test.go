@@ -3,6 +3,9 @@ package node
 import (
 	"context"
 	"crypto/rand"
+	"io"
+	"net"
+	"net/http"
 	"testing"
 	"time"
 
@@ -88,3 +91,43 @@ func TestMempoolDirectly(t *testing.T) {
 
 	assert.Equal(int64(4*len("tx*")), node.Mempool.SizeBytes())
 }
+
+func TestPrometheusMetrics(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(err)
+	addr := l.Addr().String()
+	require.NoError(l.Close())
+
+	app := &mocks.Application{}
+	app.On("InitChain", mock.Anything).Return(abci.ResponseInitChain{})
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	key, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	signingKey, _, _ := crypto.GenerateEd25519Key(rand.Reader)
+	conf := config.NodeConfig{
+		DALayer:         "mock",
+		Instrumentation: config.InstrumentationConfig{Prometheus: true, PrometheusListenAddr: addr, Namespace: "test"},
+	}
+	node, err := newFullNode(context.Background(), conf, key, signingKey, proxy.NewLocalClientCreator(app), &types.GenesisDoc{ChainID: "test"}, log.TestingLogger())
+	require.NoError(err)
+	require.NoError(node.Start())
+	defer func() {
+		assert.NoError(node.Stop())
+	}()
+
+	require.NoError(node.Mempool.CheckTx([]byte("tx1"), func(r *abci.Response) {}, mempool.TxInfo{}))
+
+	var body []byte
+	require.Eventually(func() bool {
+		resp, err := http.Get("http://" + addr + "/metrics")
+		if err != nil {
+			return false
+		}
+		defer resp.Body.Close()
+		body, err = io.ReadAll(resp.Body)
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+	assert.Contains(string(body), `test_mempool_size{chain_id="test"} 1`)
+}
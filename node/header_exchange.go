@@ -92,7 +92,7 @@ func (hExService *HeaderExchangeService) tryInitHeaderStoreAndStartSyncer(ctx co
 	}
 }
 
-func (hExService *HeaderExchangeService) writeToHeaderStoreAndBroadcast(ctx context.Context, signedHeader *types.SignedHeader) {
+func (hExService *HeaderExchangeService) writeToHeaderStoreAndBroadcast(ctx context.Context, signedHeader *types.SignedHeader) error {
 	// Init the header store if first block, else append to store
 	if err := hExService.initOrAppendHeaderStore(ctx, signedHeader); err != nil {
 		hExService.logger.Error("failed to write block header to header store", "error", err)
@@ -108,7 +108,9 @@ func (hExService *HeaderExchangeService) writeToHeaderStoreAndBroadcast(ctx cont
 	// Broadcast for subscribers
 	if err := hExService.sub.Broadcast(ctx, signedHeader); err != nil {
 		hExService.logger.Error("failed to broadcast block header", "error", err)
+		return err
 	}
+	return nil
 }
 
 // OnStart is a part of Service interface.
@@ -0,0 +1,92 @@
+package node
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/rollkit/rollkit/block"
+	"github.com/rollkit/rollkit/config"
+	"github.com/rollkit/rollkit/da"
+	"github.com/rollkit/rollkit/da/celestia"
+	"github.com/rollkit/rollkit/da/failover"
+	"github.com/rollkit/rollkit/mempool"
+)
+
+const (
+	// defaultMetricsNamespace is used if namespace is not configured
+	defaultMetricsNamespace = "rollkit"
+
+	prometheusReadHeaderTimeout = 10 * time.Second
+)
+
+// Metrics contains metrics of all node components.
+type Metrics struct {
+	Block    *block.Metrics
+	Mempool  *mempool.Metrics
+	Failover *failover.Metrics
+	Celestia *celestia.Metrics
+}
+
+// newMetrics creates metrics of node components, labeled with chain ID. Prometheus metrics (registered in default
+// registry) are created only if enabled in configuration, otherwise all metrics are no-op.
+func newMetrics(conf config.InstrumentationConfig, chainID string) *Metrics {
+	if !conf.Prometheus {
+		return &Metrics{
+			Block:    block.NopMetrics(),
+			Mempool:  mempool.NopMetrics(),
+			Failover: failover.NopMetrics(),
+			Celestia: celestia.NopMetrics(),
+		}
+	}
+	namespace := conf.Namespace
+	if namespace == "" {
+		namespace = defaultMetricsNamespace
+	}
+	return &Metrics{
+		Block:    block.PrometheusMetrics(namespace, "chain_id", chainID),
+		Mempool:  mempool.PrometheusMetrics(namespace, "chain_id", chainID),
+		Failover: failover.PrometheusMetrics(namespace, "chain_id", chainID),
+		Celestia: celestia.PrometheusMetrics(namespace, "chain_id", chainID),
+	}
+}
+
+// setDAMetrics injects metrics into initialized DA client and all clients wrapped by it.
+func (m *Metrics) setDAMetrics(client da.DataAvailabilityLayerClient) {
+	switch c := client.(type) {
+	case *failover.DataAvailabilityLayerClient:
+		c.SetMetrics(m.Failover)
+	case *celestia.DataAvailabilityLayerClient:
+		c.SetMetrics(m.Celestia)
+	}
+	if w, ok := client.(da.Wrapper); ok {
+		for _, wrapped := range w.Unwrap() {
+			m.setDAMetrics(wrapped)
+		}
+	}
+}
+
+// startPrometheusServer starts HTTP server serving Prometheus metrics under /metrics.
+func startPrometheusServer(conf config.InstrumentationConfig, logger log.Logger) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer, promhttp.HandlerFor(
+			prometheus.DefaultGatherer,
+			promhttp.HandlerOpts{MaxRequestsInFlight: conf.MaxOpenConnections},
+		),
+	))
+	srv := &http.Server{
+		Addr:              conf.PrometheusListenAddr,
+		Handler:           mux,
+		ReadHeaderTimeout: prometheusReadHeaderTimeout,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+			logger.Error("Prometheus HTTP server ListenAndServe", "error", err)
+		}
+	}()
+	return srv
+}
@@ -3,16 +3,20 @@ package json
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"time"
 
 	"github.com/gorilla/rpc/v2/json2"
+	"github.com/tendermint/tendermint/p2p"
 	rpcclient "github.com/tendermint/tendermint/rpc/client"
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/rollkit/rollkit/log"
+	"github.com/rollkit/rollkit/types"
 )
 
 // GetHTTPHandler returns handler configured to serve Tendermint-compatible RPC.
@@ -38,6 +42,38 @@ func newMethod(m interface{}) *method {
 	}
 }
 
+// finalityClient is implemented by clients tracking finality status of blocks.
+type finalityClient interface {
+	BlockStatus(ctx context.Context, height *int64) (*types.BlockStatus, error)
+	DAIncludedHeight(ctx context.Context) (uint64, error)
+}
+
+// daInclusionClient is implemented by clients storing information about inclusion of blocks in DA layer.
+type daInclusionClient interface {
+	DAInclusion(ctx context.Context, height *int64) (*types.DAInclusion, error)
+	DAInclusionByHash(ctx context.Context, hash []byte) (*types.DAInclusion, error)
+}
+
+// resultBlock extends ctypes.ResultBlock with finality status of the block.
+type resultBlock struct {
+	BlockID  tmtypes.BlockID    `json:"block_id"`
+	Block    *tmtypes.Block     `json:"block"`
+	Finality *types.BlockStatus `json:"finality,omitempty"`
+}
+
+// resultStatus extends ctypes.ResultStatus with finality information.
+type resultStatus struct {
+	NodeInfo      p2p.DefaultNodeInfo  `json:"node_info"`
+	SyncInfo      ctypes.SyncInfo      `json:"sync_info"`
+	ValidatorInfo ctypes.ValidatorInfo `json:"validator_info"`
+	FinalityInfo  *finalityInfo        `json:"finality_info,omitempty"`
+}
+
+type finalityInfo struct {
+	LatestBlockFinality    *types.BlockStatus `json:"latest_block_finality"`
+	LatestDAIncludedHeight uint64             `json:"latest_da_included_height"`
+}
+
 type service struct {
 	client  rpcclient.Client
 	methods map[string]*method
@@ -61,6 +97,9 @@ func newService(c rpcclient.Client, l log.Logger) *service {
 		"genesis_chunked":      newMethod(s.GenesisChunked),
 		"block":                newMethod(s.Block),
 		"block_by_hash":        newMethod(s.BlockByHash),
+		"block_status":         newMethod(s.BlockStatus),
+		"da_inclusion":         newMethod(s.DAInclusion),
+		"da_inclusion_by_hash": newMethod(s.DAInclusionByHash),
 		"block_results":        newMethod(s.BlockResults),
 		"commit":               newMethod(s.Commit),
 		"check_tx":             newMethod(s.CheckTx),
@@ -139,8 +178,32 @@ func (s *service) Health(req *http.Request, args *healthArgs) (*ctypes.ResultHea
 	return s.client.Health(req.Context())
 }
 
-func (s *service) Status(req *http.Request, args *statusArgs) (*ctypes.ResultStatus, error) {
-	return s.client.Status(req.Context())
+func (s *service) Status(req *http.Request, args *statusArgs) (*resultStatus, error) {
+	res, err := s.client.Status(req.Context())
+	if err != nil {
+		return nil, err
+	}
+	status := &resultStatus{
+		NodeInfo:      res.NodeInfo,
+		SyncInfo:      res.SyncInfo,
+		ValidatorInfo: res.ValidatorInfo,
+	}
+	if fc, ok := s.client.(finalityClient); ok {
+		height := res.SyncInfo.LatestBlockHeight
+		finality, err := fc.BlockStatus(req.Context(), &height)
+		if err != nil {
+			return nil, err
+		}
+		daIncluded, err := fc.DAIncludedHeight(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		status.FinalityInfo = &finalityInfo{
+			LatestBlockFinality:    finality,
+			LatestDAIncludedHeight: daIncluded,
+		}
+	}
+	return status, nil
 }
 
 func (s *service) NetInfo(req *http.Request, args *netInfoArgs) (*ctypes.ResultNetInfo, error) {
@@ -159,12 +222,62 @@ func (s *service) GenesisChunked(req *http.Request, args *genesisChunkedArgs) (*
 	return s.client.GenesisChunked(req.Context(), uint(args.ID))
 }
 
-func (s *service) Block(req *http.Request, args *blockArgs) (*ctypes.ResultBlock, error) {
-	return s.client.Block(req.Context(), (*int64)(&args.Height))
+func (s *service) Block(req *http.Request, args *blockArgs) (*resultBlock, error) {
+	res, err := s.client.Block(req.Context(), (*int64)(&args.Height))
+	if err != nil {
+		return nil, err
+	}
+	return s.withFinality(req.Context(), res)
+}
+
+func (s *service) BlockByHash(req *http.Request, args *blockByHashArgs) (*resultBlock, error) {
+	res, err := s.client.BlockByHash(req.Context(), args.Hash)
+	if err != nil {
+		return nil, err
+	}
+	return s.withFinality(req.Context(), res)
+}
+
+func (s *service) BlockStatus(req *http.Request, args *blockStatusArgs) (*types.BlockStatus, error) {
+	fc, ok := s.client.(finalityClient)
+	if !ok {
+		return nil, errors.New("block finality status is not supported by this node")
+	}
+	return fc.BlockStatus(req.Context(), (*int64)(&args.Height))
+}
+
+func (s *service) DAInclusion(req *http.Request, args *daInclusionArgs) (*types.DAInclusion, error) {
+	dc, ok := s.client.(daInclusionClient)
+	if !ok {
+		return nil, errors.New("DA inclusion information is not supported by this node")
+	}
+	return dc.DAInclusion(req.Context(), (*int64)(&args.Height))
+}
+
+func (s *service) DAInclusionByHash(req *http.Request, args *daInclusionByHashArgs) (*types.DAInclusion, error) {
+	dc, ok := s.client.(daInclusionClient)
+	if !ok {
+		return nil, errors.New("DA inclusion information is not supported by this node")
+	}
+	return dc.DAInclusionByHash(req.Context(), args.Hash)
 }
 
-func (s *service) BlockByHash(req *http.Request, args *blockByHashArgs) (*ctypes.ResultBlock, error) {
-	return s.client.BlockByHash(req.Context(), args.Hash)
+// withFinality adds finality status to block returned by client (if client tracks finality of blocks).
+func (s *service) withFinality(ctx context.Context, res *ctypes.ResultBlock) (*resultBlock, error) {
+	block := &resultBlock{
+		BlockID: res.BlockID,
+		Block:   res.Block,
+	}
+	fc, ok := s.client.(finalityClient)
+	if !ok || res.Block == nil {
+		return block, nil
+	}
+	finality, err := fc.BlockStatus(ctx, &res.Block.Height)
+	if err != nil {
+		return nil, err
+	}
+	block.Finality = finality
+	return block, nil
 }
 
 func (s *service) BlockResults(req *http.Request, args *blockResultsArgs) (*ctypes.ResultBlockResults, error) {
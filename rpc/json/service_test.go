@@ -76,6 +76,8 @@ func TestREST(t *testing.T) {
 		// to keep test simple, allow returning application error in following case
 		{"valid/int param", "/block?height=321", http.StatusOK, int(json2.E_INTERNAL), "failed to load hash from index"},
 		{"invalid/int param", "/block?height=foo", http.StatusOK, int(json2.E_PARSE), "failed to parse param 'height'"},
+		{"invalid/da inclusion height", "/da_inclusion?height=321", http.StatusOK, int(json2.E_INTERNAL), "failed to load DA inclusion"},
+		{"invalid/block status height", "/block_status?height=321", http.StatusOK, int(json2.E_INTERNAL), "height 321 is not available"},
 		{"valid/bool int string params",
 			"/tx_search?" + txSearchParams.Encode(),
 			http.StatusOK, -1, `"total_count":"0"`},
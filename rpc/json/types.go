@@ -41,6 +41,15 @@ type blockArgs struct {
 type blockByHashArgs struct {
 	Hash []byte `json:"hash"`
 }
+type blockStatusArgs struct {
+	Height StrInt64 `json:"height"`
+}
+type daInclusionArgs struct {
+	Height StrInt64 `json:"height"`
+}
+type daInclusionByHashArgs struct {
+	Hash []byte `json:"hash"`
+}
 type blockResultsArgs struct {
 	Height StrInt64 `json:"height"`
 }
@@ -0,0 +1,137 @@
+// Package signer provides signer of block headers protected against double signing.
+//
+// Signer keeps the last-sign-state record (similar to Tendermint's priv_validator_state.json): height of the last
+// signature, with signed bytes and signature. Signer refuses to sign different bytes at height that was already
+// signed (or at lower height), while signing the same bytes again returns the same signature. There is exactly one
+// header signed at every height. Record is persisted before signature is returned, so protection holds across
+// crashes and restarts.
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+)
+
+// ErrDoubleSign is returned when signing would conflict with already signed data.
+var ErrDoubleSign = errors.New("conflicting data already signed")
+
+// State is the last-sign-state record.
+type State struct {
+	Height    uint64 `json:"height"`
+	SignBytes []byte `json:"sign_bytes,omitempty"`
+	Signature []byte `json:"signature,omitempty"`
+}
+
+// Signer signs data with private key, protecting against double signing.
+type Signer struct {
+	key crypto.PrivKey
+	// path of the file with last-sign-state; if empty, state is kept only in memory
+	path string
+
+	mtx   sync.Mutex
+	state State
+}
+
+// New creates signer using given key, with last-sign-state stored in file at given path. State is loaded from the
+// file, if it exists. If path is empty, state is kept only in memory, and protection doesn't survive restarts.
+func New(key crypto.PrivKey, path string) (*Signer, error) {
+	s := &Signer{key: key, path: path}
+	if path == "" {
+		return s, nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read sign state: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.state); err != nil {
+		return nil, fmt.Errorf("failed to decode sign state: %w", err)
+	}
+	return s, nil
+}
+
+// PubKey returns public key of the signer.
+func (s *Signer) PubKey() crypto.PubKey {
+	return s.key.GetPublic()
+}
+
+// State returns the last-sign-state record.
+func (s *Signer) State() State {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	return s.state
+}
+
+// Sign signs data at given height. Signing is idempotent - the same data signed again at the same height, gets the
+// same signature. ErrDoubleSign is returned if different data was signed at this height, or if height is lower than
+// the last signed one.
+func (s *Signer) Sign(height uint64, signBytes []byte) ([]byte, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	last := s.state
+	if height < last.Height {
+		return nil, fmt.Errorf("%w: height %d is lower than last signed %d", ErrDoubleSign, height, last.Height)
+	}
+	if height == last.Height && last.Signature != nil {
+		if !bytes.Equal(signBytes, last.SignBytes) {
+			return nil, fmt.Errorf("%w: different data signed at height %d", ErrDoubleSign, height)
+		}
+		return last.Signature, nil
+	}
+
+	signature, err := s.key.Sign(signBytes)
+	if err != nil {
+		return nil, err
+	}
+	state := State{Height: height, SignBytes: signBytes, Signature: signature}
+	// signature can't be used before state is saved, otherwise it could be signed again after restart
+	if err := s.save(state); err != nil {
+		return nil, err
+	}
+	s.state = state
+	return signature, nil
+}
+
+// save writes state to file atomically, so it's never partially written.
+func (s *Signer) save(state State) error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to save sign state: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to save sign state: %w", err)
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to save sign state: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to save sign state: %w", err)
+	}
+	return nil
+}
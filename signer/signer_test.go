@@ -0,0 +1,79 @@
+package signer
+
+import (
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	s, err := New(key, "")
+	require.NoError(err)
+
+	sig1, err := s.Sign(1, []byte("header1"))
+	require.NoError(err)
+	ok, err := s.PubKey().Verify([]byte("header1"), sig1)
+	require.NoError(err)
+	assert.True(ok)
+
+	// signing the same data again is idempotent
+	sig, err := s.Sign(1, []byte("header1"))
+	require.NoError(err)
+	assert.Equal(sig1, sig)
+
+	// different data can't be signed at the same height
+	_, err = s.Sign(1, []byte("conflicting"))
+	assert.ErrorIs(err, ErrDoubleSign)
+
+	_, err = s.Sign(2, []byte("header2"))
+	require.NoError(err)
+	_, err = s.Sign(1, []byte("header1"))
+	assert.ErrorIs(err, ErrDoubleSign)
+	assert.Equal(State{Height: 2, SignBytes: []byte("header2"), Signature: s.State().Signature}, s.State())
+}
+
+func TestSignAfterRestart(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	key, _, err := crypto.GenerateEd25519Key(rand.Reader)
+	require.NoError(err)
+	path := filepath.Join(t.TempDir(), "data", "sign_state.json")
+
+	s, err := New(key, path)
+	require.NoError(err)
+	sig1, err := s.Sign(1, []byte("header1"))
+	require.NoError(err)
+
+	// node crashed after signing; state is recovered from file
+	s, err = New(key, path)
+	require.NoError(err)
+	assert.Equal(uint64(1), s.State().Height)
+	sig, err := s.Sign(1, []byte("header1"))
+	require.NoError(err)
+	assert.Equal(sig1, sig)
+	_, err = s.Sign(1, []byte("rebuilt header1"))
+	assert.ErrorIs(err, ErrDoubleSign)
+
+	_, err = s.Sign(2, []byte("header2"))
+	require.NoError(err)
+	s, err = New(key, path)
+	require.NoError(err)
+	_, err = s.Sign(1, []byte("header1"))
+	assert.ErrorIs(err, ErrDoubleSign)
+
+	// corrupted state file is not ignored
+	require.NoError(os.WriteFile(path, []byte("{"), 0o600))
+	_, err = New(key, path)
+	assert.Error(err)
+}
@@ -24,6 +24,7 @@ import (
 
 var ErrFraudProofGenerated = errors.New("failed to ApplyBlock: halting node due to fraud")
 var ErrEmptyValSetGenerated = errors.New("applying the validator changes would result in empty set")
+var ErrNotProposer = errors.New("block is not proposed by the expected proposer")
 
 // BlockExecutor creates and applies blocks and maintains state.
 type BlockExecutor struct {
@@ -33,6 +34,8 @@ type BlockExecutor struct {
 	proxyApp           proxy.AppConnConsensus
 	mempool            mempool.Mempool
 	fraudProofsEnabled bool
+	// legacyDataHashHeight is the first height at which blocks have to commit to block data with DataHash
+	legacyDataHashHeight uint64
 
 	eventBus *tmtypes.EventBus
 
@@ -42,18 +45,21 @@ type BlockExecutor struct {
 }
 
 // NewBlockExecutor creates new instance of BlockExecutor.
-// Proposer address and namespace ID will be used in all newly created blocks.
-func NewBlockExecutor(proposerAddress []byte, namespaceID [8]byte, chainID string, mempool mempool.Mempool, proxyApp proxy.AppConnConsensus, fraudProofsEnabled bool, eventBus *tmtypes.EventBus, logger log.Logger) *BlockExecutor {
+// Proposer address and namespace ID will be used in all newly created blocks. Empty proposer address means based
+// sequencing mode, where blocks are derived from DA layer and have no proposer.
+// Blocks below legacyDataHashHeight are allowed to have legacy DataHash, that doesn't commit to block data.
+func NewBlockExecutor(proposerAddress []byte, namespaceID [8]byte, chainID string, mempool mempool.Mempool, proxyApp proxy.AppConnConsensus, fraudProofsEnabled bool, legacyDataHashHeight uint64, eventBus *tmtypes.EventBus, logger log.Logger) *BlockExecutor {
 	return &BlockExecutor{
-		proposerAddress:    proposerAddress,
-		namespaceID:        namespaceID,
-		chainID:            chainID,
-		proxyApp:           proxyApp,
-		mempool:            mempool,
-		fraudProofsEnabled: fraudProofsEnabled,
-		eventBus:           eventBus,
-		logger:             logger,
-		FraudProofOutCh:    make(chan *abci.FraudProof),
+		proposerAddress:      proposerAddress,
+		namespaceID:          namespaceID,
+		chainID:              chainID,
+		proxyApp:             proxyApp,
+		mempool:              mempool,
+		fraudProofsEnabled:   fraudProofsEnabled,
+		legacyDataHashHeight: legacyDataHashHeight,
+		eventBus:             eventBus,
+		logger:               logger,
+		FraudProofOutCh:      make(chan *abci.FraudProof),
 	}
 }
 
@@ -93,11 +99,28 @@ func (e *BlockExecutor) InitChain(genesis *tmtypes.GenesisDoc) (*abci.ResponseIn
 }
 
 // CreateBlock reaps transactions from mempool and builds a block.
-func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, lastHeaderHash types.Hash, state types.State) *types.Block {
+// Forced transactions (from forced inclusion queue) are included ahead of mempool transactions.
+func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, lastHeaderHash types.Hash, state types.State, forcedTxs types.Txs) *types.Block {
 	maxBytes := state.ConsensusParams.Block.MaxBytes
 	maxGas := state.ConsensusParams.Block.MaxGas
 
-	mempoolTxs := e.mempool.ReapMaxBytesMaxGas(maxBytes, maxGas)
+	forced := make(map[string]struct{}, len(forcedTxs))
+	for _, tx := range forcedTxs {
+		forced[string(tx)] = struct{}{}
+		if maxBytes > 0 {
+			maxBytes -= int64(len(tx))
+			if maxBytes <= 0 {
+				// there is no space left for mempool transactions
+				maxBytes = 0
+			}
+		}
+	}
+	txs := append(types.Txs{}, forcedTxs...)
+	for _, tx := range toRollkitTxs(e.mempool.ReapMaxBytesMaxGas(maxBytes, maxGas)) {
+		if _, ok := forced[string(tx)]; !ok {
+			txs = append(txs, tx)
+		}
+	}
 
 	block := &types.Block{
 		SignedHeader: types.SignedHeader{
@@ -113,7 +136,6 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, las
 				},
 				//LastHeaderHash: lastHeaderHash,
 				//LastCommitHash:  lastCommitHash,
-				DataHash:        make(types.Hash, 32),
 				ConsensusHash:   make(types.Hash, 32),
 				AppHash:         state.AppHash,
 				LastResultsHash: state.LastResultsHash,
@@ -122,11 +144,12 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, las
 			Commit: *lastCommit,
 		},
 		Data: types.Data{
-			Txs:                    toRollkitTxs(mempoolTxs),
+			Txs:                    txs,
 			IntermediateStateRoots: types.IntermediateStateRoots{RawRootsList: nil},
 			Evidence:               types.EvidenceData{Evidence: nil},
 		},
 	}
+	block.SignedHeader.Header.DataHash = block.Data.Hash()
 	block.SignedHeader.Header.LastCommitHash = e.getLastCommitHash(lastCommit, &block.SignedHeader.Header)
 	block.SignedHeader.Header.LastHeaderHash = lastHeaderHash
 	block.SignedHeader.Header.AggregatorsHash = state.Validators.Hash()
@@ -134,6 +157,40 @@ func (e *BlockExecutor) CreateBlock(height uint64, lastCommit *types.Commit, las
 	return block
 }
 
+// CreateBasedBlock deterministically builds a block from transactions posted directly to DA layer (based sequencing).
+// Every node builds the same block from the same transactions, so block has no proposer and it's not signed.
+// Block time is derived from the previous block, as there is no local clock involved.
+func (e *BlockExecutor) CreateBasedBlock(height uint64, txs types.Txs, lastHeaderHash types.Hash, state types.State) *types.Block {
+	block := &types.Block{
+		SignedHeader: types.SignedHeader{
+			Header: types.Header{
+				Version: types.Version{
+					Block: state.Version.Consensus.Block,
+					App:   state.Version.Consensus.App,
+				},
+				BaseHeader: types.BaseHeader{
+					ChainID: e.chainID,
+					Height:  height,
+					Time:    uint64(state.LastBlockTime.Unix()) + 1,
+				},
+				ConsensusHash:   make(types.Hash, 32),
+				AppHash:         state.AppHash,
+				LastResultsHash: state.LastResultsHash,
+			},
+			Validators: state.Validators,
+		},
+		Data: types.Data{
+			Txs: txs,
+		},
+	}
+	block.SignedHeader.Header.DataHash = block.Data.Hash()
+	block.SignedHeader.Header.LastCommitHash = e.getLastCommitHash(&types.Commit{}, &block.SignedHeader.Header)
+	block.SignedHeader.Header.LastHeaderHash = lastHeaderHash
+	block.SignedHeader.Header.AggregatorsHash = state.Validators.Hash()
+
+	return block
+}
+
 // ApplyBlock validates and executes the block.
 func (e *BlockExecutor) ApplyBlock(ctx context.Context, state types.State, block *types.Block) (types.State, *tmstate.ABCIResponses, error) {
 	err := e.validate(state, block)
@@ -147,8 +204,25 @@ func (e *BlockExecutor) ApplyBlock(ctx context.Context, state types.State, block
 		return types.State{}, nil, err
 	}
 
+	return e.applyResponses(state, block, resp)
+}
+
+// ExecuteBlock executes the block created by CreateBlock, before it's signed. ISRs are generated during execution
+// (if fraud proofs are enabled), so DataHash is updated afterwards - header has to be signed only after that.
+func (e *BlockExecutor) ExecuteBlock(ctx context.Context, state types.State, block *types.Block) (types.State, *tmstate.ABCIResponses, error) {
+	resp, err := e.execute(ctx, state, block)
+	if err != nil {
+		return types.State{}, nil, err
+	}
+	block.SignedHeader.Header.DataHash = block.Data.Hash()
+
+	return e.applyResponses(state, block, resp)
+}
+
+// applyResponses updates state with responses of executed block.
+func (e *BlockExecutor) applyResponses(state types.State, block *types.Block, resp *tmstate.ABCIResponses) (types.State, *tmstate.ABCIResponses, error) {
 	abciValUpdates := resp.EndBlock.ValidatorUpdates
-	err = validateValidatorUpdates(abciValUpdates, state.ConsensusParams.Validator)
+	err := validateValidatorUpdates(abciValUpdates, state.ConsensusParams.Validator)
 	if err != nil {
 		return state, nil, fmt.Errorf("error in validator updates: %v", err)
 	}
@@ -276,11 +350,19 @@ func (e *BlockExecutor) commit(ctx context.Context, state types.State, block *ty
 	return resp.Data, uint64(resp.RetainHeight), err
 }
 
+// Validate checks if block can be applied on top of given state.
+func (e *BlockExecutor) Validate(state types.State, block *types.Block) error {
+	return e.validate(state, block)
+}
+
 func (e *BlockExecutor) validate(state types.State, block *types.Block) error {
 	err := block.ValidateBasic()
 	if err != nil {
 		return err
 	}
+	if block.SignedHeader.Header.IsBased() != (len(e.proposerAddress) == 0) {
+		return errors.New("block sequencing mode mismatch")
+	}
 	if block.SignedHeader.Header.Version.App != state.Version.Consensus.App ||
 		block.SignedHeader.Header.Version.Block != state.Version.Consensus.Block {
 		return errors.New("block version mismatch")
@@ -299,10 +381,46 @@ func (e *BlockExecutor) validate(state types.State, block *types.Block) error {
 		return errors.New("LastResultsHash mismatch")
 	}
 
+	// proposer rotates across validator set, according to proposer priorities
+	if err := validateProposer(state, block); err != nil {
+		return err
+	}
 	if !bytes.Equal(block.SignedHeader.Header.AggregatorsHash[:], state.Validators.Hash()) {
 		return errors.New("AggregatorsHash mismatch")
 	}
 
+	if block.SignedHeader.Header.HasLegacyDataHash() {
+		if uint64(block.SignedHeader.Header.Height()) >= e.legacyDataHashHeight {
+			return errors.New("legacy DataHash not allowed")
+		}
+	} else if !bytes.Equal(block.SignedHeader.Header.DataHash, block.Data.Hash()) {
+		return errors.New("DataHash mismatch")
+	}
+
+	return nil
+}
+
+// validateProposer checks that block is proposed and signed by the proposer of validator set from state.
+// Signed header includes validator set with the proposer used for signature verification; its hash doesn't cover
+// proposer priorities, so the proposer has to be checked explicitly.
+func validateProposer(state types.State, block *types.Block) error {
+	if state.Validators == nil || block.SignedHeader.Header.IsBased() {
+		return nil
+	}
+	expected := state.Validators.GetProposer()
+	if expected == nil {
+		return nil
+	}
+	if !bytes.Equal(block.SignedHeader.ProposerAddress, expected.Address) {
+		return fmt.Errorf("%w: expected %X, got %X", ErrNotProposer, expected.Address, block.SignedHeader.ProposerAddress)
+	}
+	if block.SignedHeader.Validators == nil {
+		return fmt.Errorf("%w: missing validator set", ErrNotProposer)
+	}
+	signer := block.SignedHeader.Validators.GetProposer()
+	if signer == nil || !signer.PubKey.Equals(expected.PubKey) {
+		return fmt.Errorf("%w: block signed by another validator", ErrNotProposer)
+	}
 	return nil
 }
 
@@ -40,7 +40,7 @@ func doTestCreateBlock(t *testing.T, fraudProofsEnabled bool) {
 	nsID := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
 
 	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
-	executor := NewBlockExecutor([]byte("test address"), nsID, "test", mpool, proxy.NewAppConnConsensus(client), fraudProofsEnabled, nil, logger)
+	executor := NewBlockExecutor([]byte("test address"), nsID, "test", mpool, proxy.NewAppConnConsensus(client), fraudProofsEnabled, 0, nil, logger)
 
 	state := types.State{}
 	state.ConsensusParams.Block.MaxBytes = 100
@@ -57,7 +57,7 @@ func doTestCreateBlock(t *testing.T, fraudProofsEnabled bool) {
 	state.Validators = tmtypes.NewValidatorSet(validators)
 
 	// empty block
-	block := executor.CreateBlock(1, &types.Commit{}, []byte{}, state)
+	block := executor.CreateBlock(1, &types.Commit{}, []byte{}, state, nil)
 	require.NotNil(block)
 	assert.Empty(block.Data.Txs)
 	assert.Equal(int64(1), block.SignedHeader.Header.Height())
@@ -65,7 +65,7 @@ func doTestCreateBlock(t *testing.T, fraudProofsEnabled bool) {
 	// one small Tx
 	err = mpool.CheckTx([]byte{1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{})
 	require.NoError(err)
-	block = executor.CreateBlock(2, &types.Commit{}, []byte{}, state)
+	block = executor.CreateBlock(2, &types.Commit{}, []byte{}, state, nil)
 	require.NotNil(block)
 	assert.Equal(int64(2), block.SignedHeader.Header.Height())
 	assert.Len(block.Data.Txs, 1)
@@ -75,9 +75,15 @@ func doTestCreateBlock(t *testing.T, fraudProofsEnabled bool) {
 	require.NoError(err)
 	err = mpool.CheckTx(make([]byte, 100), func(r *abci.Response) {}, mempool.TxInfo{})
 	require.NoError(err)
-	block = executor.CreateBlock(3, &types.Commit{}, []byte{}, state)
+	block = executor.CreateBlock(3, &types.Commit{}, []byte{}, state, nil)
 	require.NotNil(block)
 	assert.Len(block.Data.Txs, 2)
+
+	// forced transactions are included first, and mempool transactions fill the remaining space without duplicates
+	forced := types.Txs{types.Tx{4, 5, 6, 7}, make(types.Tx, 80)}
+	block = executor.CreateBlock(4, &types.Commit{}, []byte{}, state, forced)
+	require.NotNil(block)
+	assert.Equal(append(forced, types.Tx{1, 2, 3, 4}), block.Data.Txs)
 }
 
 func TestCreateBlockWithFraudProofsDisabled(t *testing.T) {
@@ -120,7 +126,6 @@ func doTestApplyBlock(t *testing.T, fraudProofsEnabled bool) {
 	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
 	eventBus := tmtypes.NewEventBus()
 	require.NoError(eventBus.Start())
-	executor := NewBlockExecutor([]byte("test address"), nsID, chainID, mpool, proxy.NewAppConnConsensus(client), fraudProofsEnabled, eventBus, logger)
 
 	txQuery, err := query.New("tm.event='Tx'")
 	require.NoError(err)
@@ -148,6 +153,7 @@ func doTestApplyBlock(t *testing.T, fraudProofsEnabled bool) {
 		Validators:     tmtypes.NewValidatorSet(validators),
 		LastValidators: tmtypes.NewValidatorSet(validators),
 	}
+	executor := NewBlockExecutor(vKey.PubKey().Address(), nsID, chainID, mpool, proxy.NewAppConnConsensus(client), fraudProofsEnabled, 0, eventBus, logger)
 	state.InitialHeight = 1
 	state.LastBlockHeight = 0
 	state.ConsensusParams.Block.MaxBytes = 100
@@ -155,7 +161,7 @@ func doTestApplyBlock(t *testing.T, fraudProofsEnabled bool) {
 
 	_ = mpool.CheckTx([]byte{1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{})
 	require.NoError(err)
-	block := executor.CreateBlock(1, &types.Commit{Signatures: []types.Signature{types.Signature([]byte{1, 1, 1})}}, []byte{}, state)
+	block := executor.CreateBlock(1, &types.Commit{Signatures: []types.Signature{types.Signature([]byte{1, 1, 1})}}, []byte{}, state, nil)
 	require.NotNil(block)
 	assert.Equal(int64(1), block.SignedHeader.Header.Height())
 	assert.Len(block.Data.Txs, 1)
@@ -181,23 +187,30 @@ func doTestApplyBlock(t *testing.T, fraudProofsEnabled bool) {
 	require.NoError(mpool.CheckTx([]byte{5, 6, 7, 8, 9}, func(r *abci.Response) {}, mempool.TxInfo{}))
 	require.NoError(mpool.CheckTx([]byte{1, 2, 3, 4, 5}, func(r *abci.Response) {}, mempool.TxInfo{}))
 	require.NoError(mpool.CheckTx(make([]byte, 90), func(r *abci.Response) {}, mempool.TxInfo{}))
-	block = executor.CreateBlock(2, &types.Commit{Signatures: []types.Signature{types.Signature([]byte{1, 1, 1})}}, []byte{}, newState)
+	block = executor.CreateBlock(2, &types.Commit{Signatures: []types.Signature{types.Signature([]byte{1, 1, 1})}}, []byte{}, newState, nil)
 	require.NotNil(block)
 	assert.Equal(int64(2), block.SignedHeader.Header.Height())
 	assert.Len(block.Data.Txs, 3)
-
-	headerBytes, _ = block.SignedHeader.Header.MarshalBinary()
-	sig, _ = vKey.Sign(headerBytes)
-	block.SignedHeader.Commit = types.Commit{
-		Signatures: []types.Signature{sig},
-	}
 	block.SignedHeader.Validators = tmtypes.NewValidatorSet(validators)
 
-	newState, resp, err = executor.ApplyBlock(context.Background(), newState, block)
+	// own block is executed before it's signed
+	prevState := newState
+	newState, resp, err = executor.ExecuteBlock(context.Background(), prevState, block)
 	require.NoError(err)
 	require.NotNil(newState)
 	require.NotNil(resp)
 	assert.Equal(int64(2), newState.LastBlockHeight)
+	assert.Equal(fraudProofsEnabled, block.Data.IntermediateStateRoots.RawRootsList != nil)
+	assert.Equal(block.Data.Hash(), block.SignedHeader.DataHash)
+	assert.Equal(block.Hash(), types.Hash(newState.LastBlockID.Hash))
+
+	headerBytes, _ = block.SignedHeader.Header.MarshalBinary()
+	sig, _ = vKey.Sign(headerBytes)
+	block.SignedHeader.Commit = types.Commit{
+		Signatures: []types.Signature{sig},
+	}
+	// signed block commits to ISRs, so it's valid for other nodes
+	require.NoError(executor.Validate(prevState, block))
 	_, _, err = executor.Commit(context.Background(), newState, block, resp)
 	require.NoError(err)
 
@@ -240,3 +253,141 @@ func TestApplyBlockWithFraudProofsDisabled(t *testing.T) {
 func TestApplyBlockWithFraudProofsEnabled(t *testing.T) {
 	doTestApplyBlock(t, true)
 }
+
+func TestValidateDataHash(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	app := &mocks.Application{}
+	app.On("CheckTx", mock.Anything).Return(abci.ResponseCheckTx{})
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+	require.NoError(mpool.CheckTx([]byte{1, 2, 3, 4}, func(r *abci.Response) {}, mempool.TxInfo{}))
+
+	vKey := ed25519.GenPrivKey()
+	validators := tmtypes.NewValidatorSet([]*tmtypes.Validator{tmtypes.NewValidator(vKey.PubKey(), 100)})
+	state := types.State{Validators: validators, InitialHeight: 1}
+	state.ConsensusParams.Block.MaxBytes = 100
+	state.ConsensusParams.Block.MaxGas = 100000
+
+	newBlock := func(executor *BlockExecutor, modify func(*types.Block)) *types.Block {
+		block := executor.CreateBlock(1, &types.Commit{}, []byte{}, state, nil)
+		require.Len(block.Data.Txs, 1)
+		modify(block)
+		headerBytes, err := block.SignedHeader.Header.MarshalBinary()
+		require.NoError(err)
+		sig, err := vKey.Sign(headerBytes)
+		require.NoError(err)
+		block.SignedHeader.Commit = types.Commit{Signatures: []types.Signature{sig}}
+		block.SignedHeader.Validators = validators
+		return block
+	}
+	nsID := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	executor := NewBlockExecutor(vKey.PubKey().Address(), nsID, "test", mpool, proxy.NewAppConnConsensus(client), false, 0, nil, logger)
+
+	assert.NoError(executor.Validate(state, newBlock(executor, func(*types.Block) {})))
+	assert.Error(executor.Validate(state, newBlock(executor, func(b *types.Block) {
+		b.Data.Txs = append(b.Data.Txs, types.Tx("injected"))
+	})))
+	legacy := func(b *types.Block) {
+		b.SignedHeader.DataHash = make(types.Hash, 32)
+	}
+	assert.Error(executor.Validate(state, newBlock(executor, legacy)))
+
+	// blocks below LegacyDataHashHeight don't have to commit to block data
+	legacyExecutor := NewBlockExecutor(vKey.PubKey().Address(), nsID, "test", mpool, proxy.NewAppConnConsensus(client), false, 2, nil, logger)
+	assert.NoError(legacyExecutor.Validate(state, newBlock(legacyExecutor, legacy)))
+	assert.NoError(legacyExecutor.Validate(state, newBlock(legacyExecutor, func(*types.Block) {})))
+}
+
+func TestValidateBasedBlock(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	app := &mocks.Application{}
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+
+	state := types.State{Validators: tmtypes.NewValidatorSet(nil), InitialHeight: 1}
+	nsID := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	executor := NewBlockExecutor(nil, nsID, "test", mpool, proxy.NewAppConnConsensus(client), false, 0, nil, logger)
+
+	txs := types.Txs{types.Tx("a"), types.Tx("b")}
+	block := executor.CreateBasedBlock(1, txs, nil, state)
+	require.NotNil(block)
+	assert.True(block.SignedHeader.Header.IsBased())
+	assert.Equal(txs, block.Data.Txs)
+	assert.NoError(block.ValidateBasic())
+	assert.NoError(executor.Validate(state, block))
+
+	// derivation is deterministic
+	assert.Equal(block.Hash(), executor.CreateBasedBlock(1, txs, nil, state).Hash())
+
+	// based blocks can't be signed
+	signed := executor.CreateBasedBlock(1, txs, nil, state)
+	signed.SignedHeader.Commit = types.Commit{Signatures: []types.Signature{[]byte{1, 2, 3}}}
+	assert.Error(signed.ValidateBasic())
+
+	// based blocks are rejected by executor of sequenced chain, and vice versa
+	vKey := ed25519.GenPrivKey()
+	sequencedExecutor := NewBlockExecutor(vKey.PubKey().Address(), nsID, "test", mpool, proxy.NewAppConnConsensus(client), false, 0, nil, logger)
+	assert.Error(sequencedExecutor.Validate(state, block))
+	sequenced := sequencedExecutor.CreateBlock(1, &types.Commit{}, []byte{}, state, nil)
+	assert.Error(executor.Validate(state, sequenced))
+}
+
+func TestValidateProposer(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	logger := log.TestingLogger()
+	app := &mocks.Application{}
+	client, err := proxy.NewLocalClientCreator(app).NewABCIClient()
+	require.NoError(err)
+	mpool := mempoolv1.NewTxMempool(logger, cfg.DefaultMempoolConfig(), proxy.NewAppConnMempool(client), 0)
+
+	keys := map[string]ed25519.PrivKey{}
+	var vals []*tmtypes.Validator
+	for i := 0; i < 2; i++ {
+		key := ed25519.GenPrivKey()
+		keys[string(key.PubKey().Address())] = key
+		vals = append(vals, tmtypes.NewValidator(key.PubKey(), 100))
+	}
+	validators := tmtypes.NewValidatorSet(vals)
+	state := types.State{Validators: validators, InitialHeight: 1}
+	proposer := validators.GetProposer()
+	other := validators.Validators[0]
+	if other.Address.String() == proposer.Address.String() {
+		other = validators.Validators[1]
+	}
+
+	nsID := [8]byte{1, 2, 3, 4, 5, 6, 7, 8}
+	// newBlock creates a block proposed by one validator and signed by another one
+	newBlock := func(proposer, signer *tmtypes.Validator) *types.Block {
+		executor := NewBlockExecutor(proposer.Address, nsID, "test", mpool, proxy.NewAppConnConsensus(client), false, 0, nil, logger)
+		block := executor.CreateBlock(1, &types.Commit{}, []byte{}, state, nil)
+		headerBytes, err := block.SignedHeader.Header.MarshalBinary()
+		require.NoError(err)
+		sig, err := keys[string(signer.Address)].Sign(headerBytes)
+		require.NoError(err)
+		block.SignedHeader.Commit = types.Commit{Signatures: []types.Signature{sig}}
+		block.SignedHeader.Validators = validators.Copy()
+		_, block.SignedHeader.Validators.Proposer = block.SignedHeader.Validators.GetByAddress(signer.Address)
+		require.NoError(block.ValidateBasic())
+		return block
+	}
+	executor := NewBlockExecutor(proposer.Address, nsID, "test", mpool, proxy.NewAppConnConsensus(client), false, 0, nil, logger)
+
+	assert.NoError(executor.Validate(state, newBlock(proposer, proposer)))
+	assert.ErrorIs(executor.Validate(state, newBlock(other, other)), ErrNotProposer)
+	assert.ErrorIs(executor.Validate(state, newBlock(proposer, other)), ErrNotProposer)
+
+	// proposer rotates after every block
+	state.Validators = validators.CopyIncrementProposerPriority(1)
+	assert.ErrorIs(executor.Validate(state, newBlock(proposer, proposer)), ErrNotProposer)
+	assert.NoError(executor.Validate(state, newBlock(other, other)))
+}
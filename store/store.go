@@ -2,9 +2,11 @@ package store
 
 import (
 	"context"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"strconv"
 	"sync/atomic"
 
 	ds "github.com/ipfs/go-datastore"
@@ -26,6 +28,12 @@ var (
 	statePrefix      = "s"
 	responsesPrefix  = "r"
 	validatorsPrefix = "v"
+	daSubmittedKey   = "d"
+	statusPrefix     = "f"
+	inclusionPrefix  = "a"
+	inclusionIndex   = "h"
+	forcedPrefix     = "q"
+	txPrefix         = "t"
 )
 
 // DefaultStore is a default store implmementation.
@@ -234,6 +242,177 @@ func (s *DefaultStore) LoadValidators(height uint64) (*tmtypes.ValidatorSet, err
 	return tmtypes.ValidatorSetFromProto(&pbValSet)
 }
 
+// SaveBlockStatus saves finality status of a block.
+func (s *DefaultStore) SaveBlockStatus(status types.BlockStatus) error {
+	blob, err := status.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal BlockStatus to binary: %w", err)
+	}
+	return s.db.Put(s.ctx, ds.NewKey(getStatusKey(status.Height)), blob)
+}
+
+// LoadBlockStatus returns finality status of a block at given height.
+func (s *DefaultStore) LoadBlockStatus(height uint64) (types.BlockStatus, error) {
+	blob, err := s.db.Get(s.ctx, ds.NewKey(getStatusKey(height)))
+	if err != nil {
+		return types.BlockStatus{}, fmt.Errorf("failed to load block status for height %v: %w", height, err)
+	}
+	var status types.BlockStatus
+	err = status.UnmarshalBinary(blob)
+	if err != nil {
+		return types.BlockStatus{}, fmt.Errorf("failed to unmarshal block status: %w", err)
+	}
+	return status, nil
+}
+
+// SaveDAInclusion saves information about inclusion of a block in DA layer.
+// Inclusion information is indexed both by block height and block hash.
+func (s *DefaultStore) SaveDAInclusion(inclusion types.DAInclusion) error {
+	blob, err := inclusion.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("failed to marshal DAInclusion to binary: %w", err)
+	}
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, inclusion.Height)
+
+	bb, err := s.db.NewTransaction(s.ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+
+	err = multierr.Append(err, bb.Put(s.ctx, ds.NewKey(getInclusionKey(inclusion.Height)), blob))
+	err = multierr.Append(err, bb.Put(s.ctx, ds.NewKey(getInclusionIndexKey(inclusion.Hash)), heightBytes))
+
+	if err != nil {
+		bb.Discard(s.ctx)
+		return err
+	}
+
+	if err = bb.Commit(s.ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// LoadDAInclusion returns information about inclusion of a block at given height in DA layer.
+func (s *DefaultStore) LoadDAInclusion(height uint64) (types.DAInclusion, error) {
+	blob, err := s.db.Get(s.ctx, ds.NewKey(getInclusionKey(height)))
+	if err != nil {
+		return types.DAInclusion{}, fmt.Errorf("failed to load DA inclusion for height %v: %w", height, err)
+	}
+	var inclusion types.DAInclusion
+	err = inclusion.UnmarshalBinary(blob)
+	if err != nil {
+		return types.DAInclusion{}, fmt.Errorf("failed to unmarshal DA inclusion: %w", err)
+	}
+	return inclusion, nil
+}
+
+// LoadDAInclusionByHash returns information about inclusion of a block with given hash in DA layer.
+func (s *DefaultStore) LoadDAInclusionByHash(hash types.Hash) (types.DAInclusion, error) {
+	heightBytes, err := s.db.Get(s.ctx, ds.NewKey(getInclusionIndexKey(hash)))
+	if err != nil {
+		return types.DAInclusion{}, fmt.Errorf("failed to load DA inclusion for hash %v: %w", hash, err)
+	}
+	if len(heightBytes) != 8 {
+		return types.DAInclusion{}, errors.New("invalid DA inclusion index entry length")
+	}
+	return s.LoadDAInclusion(binary.BigEndian.Uint64(heightBytes))
+}
+
+// SetDASubmittedHeight saves height of the last block successfully submitted to DA layer.
+func (s *DefaultStore) SetDASubmittedHeight(height uint64) error {
+	blob := make([]byte, 8)
+	binary.BigEndian.PutUint64(blob, height)
+	return s.db.Put(s.ctx, ds.NewKey(daSubmittedKey), blob)
+}
+
+// LoadDASubmittedHeight returns height of the last block successfully submitted to DA layer.
+func (s *DefaultStore) LoadDASubmittedHeight() (uint64, error) {
+	blob, err := s.db.Get(s.ctx, ds.NewKey(daSubmittedKey))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load DA submitted height: %w", err)
+	}
+	if len(blob) != 8 {
+		return 0, errors.New("invalid DA submitted height length")
+	}
+	return binary.BigEndian.Uint64(blob), nil
+}
+
+// SaveForcedTxs saves transactions from forced inclusion queue, posted to DA layer at given DA height.
+// If txs is empty, transactions saved for this DA height are removed.
+func (s *DefaultStore) SaveForcedTxs(daHeight uint64, txs types.Txs) error {
+	key := ds.NewKey(getForcedKey(daHeight))
+	if len(txs) == 0 {
+		return s.db.Delete(s.ctx, key)
+	}
+	blob, err := types.MarshalTxBatch(txs)
+	if err != nil {
+		return fmt.Errorf("failed to marshal forced transactions to binary: %w", err)
+	}
+	return s.db.Put(s.ctx, key, blob)
+}
+
+// LoadForcedTxs returns all transactions from forced inclusion queue, by DA height at which they were posted.
+func (s *DefaultStore) LoadForcedTxs() (map[uint64]types.Txs, error) {
+	results, err := PrefixEntries(s.ctx, s.db, GenerateKey([]interface{}{forcedPrefix}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load forced transactions: %w", err)
+	}
+	entries, err := results.Rest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load forced transactions: %w", err)
+	}
+	forced := make(map[uint64]types.Txs, len(entries))
+	for _, e := range entries {
+		daHeight, err := strconv.ParseUint(ds.RawKey(e.Key).BaseNamespace(), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid forced transactions key %s: %w", e.Key, err)
+		}
+		txs, err := types.UnmarshalTxBatch(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal forced transactions: %w", err)
+		}
+		forced[daHeight] = txs
+	}
+	return forced, nil
+}
+
+// SaveTxsInclusion records that transactions were included in block at given height.
+func (s *DefaultStore) SaveTxsInclusion(height uint64, txs types.Txs) error {
+	heightBytes := make([]byte, 8)
+	binary.BigEndian.PutUint64(heightBytes, height)
+
+	bb, err := s.db.NewTransaction(s.ctx, false)
+	if err != nil {
+		return fmt.Errorf("failed to create a new batch for transaction: %w", err)
+	}
+	for _, tx := range txs {
+		err = multierr.Append(err, bb.Put(s.ctx, ds.NewKey(getTxKey(tx.Hash())), heightBytes))
+	}
+	if err != nil {
+		bb.Discard(s.ctx)
+		return err
+	}
+
+	if err = bb.Commit(s.ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// LoadTxInclusion returns height of the block that included transaction with given hash.
+func (s *DefaultStore) LoadTxInclusion(hash types.Hash) (uint64, error) {
+	heightBytes, err := s.db.Get(s.ctx, ds.NewKey(getTxKey(hash)))
+	if err != nil {
+		return 0, fmt.Errorf("failed to load inclusion of transaction %v: %w", hash, err)
+	}
+	if len(heightBytes) != 8 {
+		return 0, errors.New("invalid transaction inclusion entry length")
+	}
+	return binary.BigEndian.Uint64(heightBytes), nil
+}
+
 // loadHashFromIndex returns the hash of a block given its height
 func (s *DefaultStore) loadHashFromIndex(height uint64) (header.Hash, error) {
 	blob, err := s.db.Get(s.ctx, ds.NewKey(getIndexKey(height)))
@@ -270,3 +449,23 @@ func getResponsesKey(height uint64) string {
 func getValidatorsKey(height uint64) string {
 	return GenerateKey([]interface{}{validatorsPrefix, height})
 }
+
+func getStatusKey(height uint64) string {
+	return GenerateKey([]interface{}{statusPrefix, height})
+}
+
+func getInclusionKey(height uint64) string {
+	return GenerateKey([]interface{}{inclusionPrefix, height})
+}
+
+func getInclusionIndexKey(hash types.Hash) string {
+	return GenerateKey([]interface{}{inclusionIndex, hex.EncodeToString(hash[:])})
+}
+
+func getForcedKey(daHeight uint64) string {
+	return GenerateKey([]interface{}{forcedPrefix, daHeight})
+}
+
+func getTxKey(hash types.Hash) string {
+	return GenerateKey([]interface{}{txPrefix, hex.EncodeToString(hash[:])})
+}
@@ -151,6 +151,121 @@ func TestRestart(t *testing.T) {
 	assert.Equal(expectedHeight, s2.Height())
 }
 
+func TestDASubmittedHeight(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx := context.Background()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s1 := New(ctx, kv)
+
+	_, err := s1.LoadDASubmittedHeight()
+	assert.ErrorIs(err, ds.ErrNotFound)
+
+	require.NoError(s1.SetDASubmittedHeight(42))
+	h, err := s1.LoadDASubmittedHeight()
+	require.NoError(err)
+	assert.Equal(uint64(42), h)
+
+	// height has to be persisted
+	s2 := New(ctx, kv)
+	h, err = s2.LoadDASubmittedHeight()
+	require.NoError(err)
+	assert.Equal(uint64(42), h)
+}
+
+func TestBlockStatus(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(context.Background(), kv)
+
+	_, err := s.LoadBlockStatus(1)
+	assert.ErrorIs(err, ds.ErrNotFound)
+
+	expected := types.BlockStatus{Height: 1, Finality: types.FinalityDAIncluded, DAHeight: 12}
+	require.NoError(s.SaveBlockStatus(types.BlockStatus{Height: 1, Finality: types.FinalityProduced}))
+	require.NoError(s.SaveBlockStatus(expected))
+	require.NoError(s.SaveBlockStatus(types.BlockStatus{Height: 2, Finality: types.FinalitySoftConfirmed}))
+
+	status, err := s.LoadBlockStatus(1)
+	require.NoError(err)
+	assert.Equal(expected, status)
+
+	status, err = s.LoadBlockStatus(2)
+	require.NoError(err)
+	assert.Equal(types.FinalitySoftConfirmed, status.Finality)
+}
+
+func TestDAInclusion(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	kv, _ := NewDefaultInMemoryKVStore()
+	s := New(context.Background(), kv)
+
+	block := getRandomBlock(7, 1)
+	expected := types.DAInclusion{
+		Height:     7,
+		Hash:       block.SignedHeader.Header.Hash(),
+		DAHeight:   42,
+		Commitment: []byte{1, 2, 3},
+		Namespace:  []byte{8, 7, 6, 5, 4, 3, 2, 1},
+	}
+	require.NoError(s.SaveDAInclusion(expected))
+
+	inclusion, err := s.LoadDAInclusion(7)
+	require.NoError(err)
+	assert.Equal(expected, inclusion)
+
+	inclusion, err = s.LoadDAInclusionByHash(expected.Hash)
+	require.NoError(err)
+	assert.Equal(expected, inclusion)
+
+	_, err = s.LoadDAInclusion(8)
+	assert.ErrorIs(err, ds.ErrNotFound)
+	_, err = s.LoadDAInclusionByHash(getRandomBlock(8, 1).SignedHeader.Header.Hash())
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
+
+func TestForcedTxs(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	ctx := context.Background()
+	kv, _ := NewDefaultInMemoryKVStore()
+	s1 := New(ctx, kv)
+
+	forced, err := s1.LoadForcedTxs()
+	require.NoError(err)
+	assert.Empty(forced)
+
+	require.NoError(s1.SaveForcedTxs(5, types.Txs{types.Tx("tx1"), types.Tx("tx2")}))
+	require.NoError(s1.SaveForcedTxs(12, types.Txs{types.Tx("tx3")}))
+	require.NoError(s1.SaveForcedTxs(7, types.Txs{types.Tx("tx4")}))
+	require.NoError(s1.SaveForcedTxs(7, nil))
+	require.NoError(s1.SaveTxsInclusion(3, types.Txs{types.Tx("tx1"), types.Tx("tx5")}))
+
+	// queue and included transactions have to be persisted
+	s2 := New(ctx, kv)
+	forced, err = s2.LoadForcedTxs()
+	require.NoError(err)
+	assert.Equal(map[uint64]types.Txs{
+		5:  {types.Tx("tx1"), types.Tx("tx2")},
+		12: {types.Tx("tx3")},
+	}, forced)
+	height, err := s2.LoadTxInclusion(types.Tx("tx5").Hash())
+	require.NoError(err)
+	assert.Equal(uint64(3), height)
+	_, err = s2.LoadTxInclusion(types.Tx("tx2").Hash())
+	assert.ErrorIs(err, ds.ErrNotFound)
+}
+
 func TestBlockResponses(t *testing.T) {
 	t.Parallel()
 	assert := assert.New(t)
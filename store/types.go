@@ -43,4 +43,33 @@ type Store interface {
 	SaveValidators(height uint64, validatorSet *tmtypes.ValidatorSet) error
 
 	LoadValidators(height uint64) (*tmtypes.ValidatorSet, error)
+
+	// SaveBlockStatus saves finality status of a block.
+	SaveBlockStatus(status types.BlockStatus) error
+	// LoadBlockStatus returns finality status of a block at given height.
+	LoadBlockStatus(height uint64) (types.BlockStatus, error)
+
+	// SaveDAInclusion saves information about inclusion of a block in DA layer.
+	SaveDAInclusion(inclusion types.DAInclusion) error
+	// LoadDAInclusion returns information about inclusion of a block at given height in DA layer.
+	LoadDAInclusion(height uint64) (types.DAInclusion, error)
+	// LoadDAInclusionByHash returns information about inclusion of a block with given hash in DA layer.
+	LoadDAInclusionByHash(hash types.Hash) (types.DAInclusion, error)
+
+	// SetDASubmittedHeight saves height of the last block successfully submitted to DA layer.
+	// Blocks above this height (up to Height) are pending submission.
+	SetDASubmittedHeight(height uint64) error
+	// LoadDASubmittedHeight returns height of the last block successfully submitted to DA layer.
+	LoadDASubmittedHeight() (uint64, error)
+
+	// SaveForcedTxs saves transactions from forced inclusion queue, posted to DA layer at given DA height.
+	// If txs is empty, transactions saved for this DA height are removed.
+	SaveForcedTxs(daHeight uint64, txs types.Txs) error
+	// LoadForcedTxs returns all transactions from forced inclusion queue, by DA height at which they were posted.
+	LoadForcedTxs() (map[uint64]types.Txs, error)
+
+	// SaveTxsInclusion records that transactions were included in block at given height.
+	SaveTxsInclusion(height uint64, txs types.Txs) error
+	// LoadTxInclusion returns height of the block that included transaction with given hash.
+	LoadTxInclusion(hash types.Hash) (uint64, error)
 }
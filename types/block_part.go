@@ -0,0 +1,367 @@
+package types
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+
+	pb "github.com/rollkit/rollkit/types/pb/rollkit"
+)
+
+// maxBlockParts is the upper limit on number of parts a single blob can be split into.
+const maxBlockParts = 1 << 16
+
+// blockPartOverhead is an upper bound of size of encoded BlockPart, excluding the data.
+const blockPartOverhead = 2*(2+sha256.Size) + 2*(2+5) + 2 + 10
+
+var (
+	// ErrBlobPartSize is returned when parts can't fit in the requested size.
+	ErrBlobPartSize = errors.New("maximum part size is too small")
+	// ErrInvalidBlockPart is returned when part is malformed or doesn't match other parts of the blob.
+	ErrInvalidBlockPart = errors.New("invalid block part")
+)
+
+// BlockPart is a part of a blob that is too large to be posted as a single DA blob.
+//
+// Parts are linked by hashes: every part (except the first one) contains the hash of the previous encoded part,
+// and all parts contain the hash of the whole blob.
+type BlockPart struct {
+	BlobHash Hash
+	Index    uint32
+	Total    uint32
+	PrevHash Hash
+	Data     []byte
+}
+
+// ToProto converts BlockPart into protobuf representation and returns it.
+func (p *BlockPart) ToProto() *pb.BlockPart {
+	return &pb.BlockPart{
+		BlobHash: p.BlobHash,
+		Index:    p.Index,
+		Total:    p.Total,
+		PrevHash: p.PrevHash,
+		Data:     p.Data,
+	}
+}
+
+// FromProto fills BlockPart with data from its protobuf representation.
+func (p *BlockPart) FromProto(other *pb.BlockPart) {
+	p.BlobHash = other.BlobHash
+	p.Index = other.Index
+	p.Total = other.Total
+	p.PrevHash = other.PrevHash
+	p.Data = other.Data
+}
+
+// MarshalBinary encodes BlockPart into binary form and returns it.
+func (p *BlockPart) MarshalBinary() ([]byte, error) {
+	return p.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of BlockPart into object.
+func (p *BlockPart) UnmarshalBinary(data []byte) error {
+	var pPart pb.BlockPart
+	err := pPart.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	p.FromProto(&pPart)
+	return nil
+}
+
+// DecodeBlockPart tries to decode blob as a BlockPart.
+// Second return value is false if blob is not a BlockPart (for example, it's a Block or a Batch).
+func DecodeBlockPart(blob []byte) (*BlockPart, bool) {
+	var part BlockPart
+	if err := part.UnmarshalBinary(blob); err != nil {
+		return nil, false
+	}
+	if len(part.BlobHash) != sha256.Size || part.Total == 0 {
+		return nil, false
+	}
+	return &part, true
+}
+
+// SplitBlob splits blob into hash-linked parts. Every returned (encoded) part is at most maxSize bytes long.
+func SplitBlob(blob []byte, maxSize int) ([][]byte, error) {
+	chunkSize := maxSize - blockPartOverhead
+	if chunkSize <= 0 {
+		return nil, ErrBlobPartSize
+	}
+	total := (len(blob) + chunkSize - 1) / chunkSize
+	if total > maxBlockParts {
+		return nil, fmt.Errorf("%w: blob of %d bytes needs %d parts", ErrBlobPartSize, len(blob), total)
+	}
+
+	blobHash := sha256.Sum256(blob)
+	parts := make([][]byte, 0, total)
+	var prevHash []byte
+	for i := 0; i < total; i++ {
+		end := (i + 1) * chunkSize
+		if end > len(blob) {
+			end = len(blob)
+		}
+		part := BlockPart{
+			BlobHash: blobHash[:],
+			Index:    uint32(i),
+			Total:    uint32(total),
+			PrevHash: prevHash,
+			Data:     blob[i*chunkSize : end],
+		}
+		encoded, err := part.MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, encoded)
+		hash := sha256.Sum256(encoded)
+		prevHash = hash[:]
+	}
+	return parts, nil
+}
+
+// PartStore persists parts of blobs, so blobs with parts retrieved before and after restart can be reassembled.
+type PartStore interface {
+	// SavePart saves encoded part of blob with given key.
+	SavePart(key []byte, encoded []byte) error
+	// DeleteParts removes all the parts of blob with given key.
+	DeleteParts(key []byte) error
+	// LoadParts returns all the saved parts.
+	LoadParts() ([][]byte, error)
+}
+
+// candidatePart is one of the parts with the same index. Parts are posted by anyone with access to the namespace,
+// so there may be competing parts for the same index.
+type candidatePart struct {
+	part *BlockPart
+	// hash of encoded part
+	hash []byte
+	// daHeight is the DA height the part was retrieved from; it's 0 for parts restored from the store, until they are
+	// retrieved again
+	daHeight uint64
+}
+
+type partialBlob struct {
+	blobHash []byte
+	total    uint32
+	parts    map[uint32][]candidatePart
+	// data of the reassembled blob and the DA height of its last part; data is nil until blob is complete
+	data       []byte
+	lastHeight uint64
+}
+
+// maxPartCandidates is the maximum number of competing parts kept for a single index of the blob.
+const maxPartCandidates = 4
+
+// maxAssembledChains is the maximum number of hash-linked chains of parts tried while reassembling a blob.
+const maxAssembledChains = 1024
+
+// BlobAssembler reassembles blobs split with SplitBlob.
+//
+// Parts can be added in any order, and parts of the same blob may be retrieved from different DA heights.
+// Competing parts with the same index are kept, and the blob is reassembled from the chain of parts linked by
+// PrevHash, matching the blob hash. Blob is not dropped if none of the chains match, as valid part can be retrieved
+// later. At most maxPending incomplete blobs are kept; the oldest one is dropped when the limit is exceeded.
+//
+// Reassembled blob is returned every time a part from the DA height of its last part is added, so DA heights can be
+// retrieved again (for example, to confirm them) with the same result. Up to maxPending reassembled blobs are kept
+// for this purpose.
+type BlobAssembler struct {
+	mtx        sync.Mutex
+	pending    map[string]*partialBlob
+	order      []string
+	complete   []string
+	maxPending int
+	store      PartStore
+}
+
+// NewBlobAssembler creates new BlobAssembler.
+func NewBlobAssembler(maxPending int) *BlobAssembler {
+	return &BlobAssembler{
+		pending:    make(map[string]*partialBlob),
+		maxPending: maxPending,
+	}
+}
+
+// SetStore sets the store of parts of blobs, and restores parts saved in it.
+func (a *BlobAssembler) SetStore(store PartStore) error {
+	encoded, err := store.LoadParts()
+	if err != nil {
+		return err
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+	for _, e := range encoded {
+		part, ok := decodeValidPart(e)
+		if !ok {
+			continue
+		}
+		a.add(part, e, 0)
+	}
+	a.store = store
+	return nil
+}
+
+// Add adds an encoded part retrieved from given DA height to the assembler.
+// When all the parts of the blob are available and valid, and the part is retrieved from the DA height of the last
+// part of the blob, the reassembled blob is returned. Otherwise, nil is returned.
+func (a *BlobAssembler) Add(daHeight uint64, encoded []byte) ([]byte, error) {
+	part, ok := decodeValidPart(encoded)
+	if !ok {
+		return nil, ErrInvalidBlockPart
+	}
+
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	blob, added, err := a.add(part, encoded, daHeight)
+	if err != nil {
+		return nil, err
+	}
+	if added && a.store != nil {
+		if err := a.store.SavePart([]byte(blobKey(part)), encoded); err != nil {
+			return nil, err
+		}
+	}
+	if blob.data == nil {
+		if uint32(len(blob.parts)) < blob.total {
+			return nil, nil
+		}
+		blob.data, blob.lastHeight, err = blob.assemble()
+		if err != nil {
+			return nil, err
+		}
+		a.markComplete(blobKey(part))
+	}
+	if daHeight != blob.lastHeight {
+		return nil, nil
+	}
+	return blob.data, nil
+}
+
+func decodeValidPart(encoded []byte) (*BlockPart, bool) {
+	part, ok := DecodeBlockPart(encoded)
+	if !ok || part.Index >= part.Total || part.Total > maxBlockParts {
+		return nil, false
+	}
+	return part, true
+}
+
+// blobKey identifies the blob by its hash and number of parts, so parts with invalid number of parts don't
+// interfere with valid ones.
+func blobKey(part *BlockPart) string {
+	return fmt.Sprintf("%x/%d", part.BlobHash, part.Total)
+}
+
+// add adds part retrieved from given DA height to the blob. Second return value is false if part was already added.
+// Part added again is assigned the new DA height, so the blob is reassembled again if the height changed.
+func (a *BlobAssembler) add(part *BlockPart, encoded []byte, daHeight uint64) (*partialBlob, bool, error) {
+	key := blobKey(part)
+	blob, ok := a.pending[key]
+	if !ok {
+		blob = &partialBlob{
+			blobHash: part.BlobHash,
+			total:    part.Total,
+			parts:    make(map[uint32][]candidatePart),
+		}
+		a.pending[key] = blob
+		a.order = append(a.order, key)
+		if len(a.order) > a.maxPending {
+			a.remove(a.order[0])
+		}
+	}
+	hash := sha256.Sum256(encoded)
+	candidates := blob.parts[part.Index]
+	for i := range candidates {
+		if bytes.Equal(candidates[i].hash, hash[:]) {
+			if candidates[i].daHeight != daHeight {
+				candidates[i].daHeight = daHeight
+				blob.data = nil
+			}
+			return blob, false, nil
+		}
+	}
+	if len(candidates) >= maxPartCandidates {
+		return nil, false, fmt.Errorf("%w: too many competing parts with index %d", ErrInvalidBlockPart, part.Index)
+	}
+	blob.parts[part.Index] = append(candidates, candidatePart{part: part, hash: hash[:], daHeight: daHeight})
+	// competing part can change the chain of parts matching blob hash
+	blob.data = nil
+	return blob, true, nil
+}
+
+// markComplete moves the blob from incomplete to reassembled blobs. The oldest reassembled blob is dropped when
+// there are more than maxPending of them.
+func (a *BlobAssembler) markComplete(key string) {
+	if removeKey(&a.order, key) {
+		a.complete = append(a.complete, key)
+	}
+	if len(a.complete) > a.maxPending {
+		a.remove(a.complete[0])
+	}
+}
+
+func (a *BlobAssembler) remove(key string) {
+	delete(a.pending, key)
+	if !removeKey(&a.order, key) {
+		removeKey(&a.complete, key)
+	}
+	if a.store != nil {
+		// parts are just not restored after restart, if they can't be deleted
+		_ = a.store.DeleteParts([]byte(key))
+	}
+}
+
+func removeKey(keys *[]string, key string) bool {
+	for i := range *keys {
+		if (*keys)[i] == key {
+			*keys = append((*keys)[:i], (*keys)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// assemble tries all the chains of parts linked by PrevHash, and returns data of the first chain matching the blob
+// hash, with the highest DA height of parts in the chain.
+func (b *partialBlob) assemble() ([]byte, uint64, error) {
+	tried := 0
+	var data []byte
+	var heights []uint64
+	var try func(index uint32, prevHash []byte) bool
+	try = func(index uint32, prevHash []byte) bool {
+		if index == b.total {
+			tried++
+			hash := sha256.Sum256(data)
+			return bytes.Equal(hash[:], b.blobHash)
+		}
+		for _, c := range b.parts[index] {
+			if tried >= maxAssembledChains {
+				return false
+			}
+			if !bytes.Equal(c.part.PrevHash, prevHash) {
+				continue
+			}
+			n := len(data)
+			data = append(data, c.part.Data...)
+			heights = append(heights, c.daHeight)
+			if try(index+1, c.hash) {
+				return true
+			}
+			data = data[:n]
+			heights = heights[:len(heights)-1]
+		}
+		return false
+	}
+	if !try(0, nil) {
+		return nil, 0, fmt.Errorf("%w: no chain of parts matches blob hash", ErrInvalidBlockPart)
+	}
+	lastHeight := uint64(0)
+	for _, h := range heights {
+		if h > lastHeight {
+			lastHeight = h
+		}
+	}
+	return data, lastHeight, nil
+}
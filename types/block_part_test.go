@@ -0,0 +1,254 @@
+package types
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitBlob(t *testing.T) {
+	t.Parallel()
+
+	blob := make([]byte, 10000)
+	_, err := rand.Read(blob)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		order func(parts [][]byte) [][]byte
+	}{
+		{"in order", func(parts [][]byte) [][]byte { return parts }},
+		{"reversed", func(parts [][]byte) [][]byte {
+			reversed := make([][]byte, len(parts))
+			for i := range parts {
+				reversed[len(parts)-1-i] = parts[i]
+			}
+			return reversed
+		}},
+		{"duplicated", func(parts [][]byte) [][]byte { return append(parts[:1:1], parts...) }},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			parts, err := SplitBlob(blob, 1000)
+			require.NoError(err)
+			require.Len(parts, (len(blob)+999-blockPartOverhead)/(1000-blockPartOverhead))
+			for _, part := range parts {
+				assert.LessOrEqual(len(part), 1000)
+			}
+
+			assembler := NewBlobAssembler(10)
+			ordered := c.order(parts)
+			for i, part := range ordered {
+				assembled, err := assembler.Add(1, part)
+				require.NoError(err)
+				if i < len(ordered)-1 {
+					assert.Nil(assembled)
+				} else {
+					assert.True(bytes.Equal(blob, assembled))
+				}
+			}
+		})
+	}
+}
+
+func TestSplitBlobErrors(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	_, err := SplitBlob([]byte{1, 2, 3}, blockPartOverhead)
+	assert.ErrorIs(err, ErrBlobPartSize)
+
+	blob := make([]byte, 5000)
+	_, err = rand.Read(blob)
+	require.NoError(err)
+	parts, err := SplitBlob(blob, 1000)
+	require.NoError(err)
+
+	// tampered data
+	var part BlockPart
+	require.NoError(part.UnmarshalBinary(parts[1]))
+	part.Data[0]++
+	tampered, err := part.MarshalBinary()
+	require.NoError(err)
+
+	assembler := NewBlobAssembler(10)
+	for i, p := range parts {
+		if i == 1 {
+			p = tampered
+		}
+		_, err = assembler.Add(1, p)
+	}
+	assert.ErrorIs(err, ErrInvalidBlockPart)
+
+	// not a part
+	block := &Block{Data: Data{Txs: Txs{Tx{1, 2, 3}}}}
+	encoded, err := block.MarshalBinary()
+	require.NoError(err)
+	_, ok := DecodeBlockPart(encoded)
+	assert.False(ok)
+	_, err = assembler.Add(1, encoded)
+	assert.ErrorIs(err, ErrInvalidBlockPart)
+}
+
+func TestBlobAssemblerCompetingParts(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	blob := make([]byte, 5000)
+	_, err := rand.Read(blob)
+	require.NoError(err)
+	parts, err := SplitBlob(blob, 1000)
+	require.NoError(err)
+
+	// bogus parts, with copied blob hash, are added before the valid ones
+	var bogus [][]byte
+	for _, i := range []int{0, 2} {
+		var part BlockPart
+		require.NoError(part.UnmarshalBinary(parts[i]))
+		part.Data = []byte("bogus")
+		encoded, err := part.MarshalBinary()
+		require.NoError(err)
+		bogus = append(bogus, encoded)
+	}
+	var wrongTotal BlockPart
+	require.NoError(wrongTotal.UnmarshalBinary(parts[1]))
+	wrongTotal.Total++
+	encoded, err := wrongTotal.MarshalBinary()
+	require.NoError(err)
+	bogus = append(bogus, encoded)
+
+	assembler := NewBlobAssembler(10)
+	for _, p := range bogus {
+		assembled, err := assembler.Add(1, p)
+		require.NoError(err)
+		assert.Nil(assembled)
+	}
+	for i, p := range parts {
+		assembled, err := assembler.Add(1, p)
+		require.NoError(err)
+		if i < len(parts)-1 {
+			assert.Nil(assembled)
+		} else {
+			assert.Equal(blob, assembled)
+		}
+	}
+}
+
+type memoryPartStore map[string][][]byte
+
+func (s memoryPartStore) SavePart(key []byte, encoded []byte) error {
+	s[string(key)] = append(s[string(key)], encoded)
+	return nil
+}
+
+func (s memoryPartStore) DeleteParts(key []byte) error {
+	delete(s, string(key))
+	return nil
+}
+
+func (s memoryPartStore) LoadParts() ([][]byte, error) {
+	var parts [][]byte
+	for _, p := range s {
+		parts = append(parts, p...)
+	}
+	return parts, nil
+}
+
+func TestBlobAssemblerRestore(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	blob := make([]byte, 5000)
+	_, err := rand.Read(blob)
+	require.NoError(err)
+	parts, err := SplitBlob(blob, 1000)
+	require.NoError(err)
+
+	store := memoryPartStore{}
+	assembler := NewBlobAssembler(10)
+	require.NoError(assembler.SetStore(store))
+	for _, p := range parts[:3] {
+		_, err := assembler.Add(1, p)
+		require.NoError(err)
+	}
+
+	// parts retrieved before restart are restored from the store
+	assembler = NewBlobAssembler(10)
+	require.NoError(assembler.SetStore(store))
+	var assembled []byte
+	for _, p := range parts[3:] {
+		assembled, err = assembler.Add(2, p)
+		require.NoError(err)
+	}
+	assert.Equal(blob, assembled)
+
+	// parts of reassembled blob are kept, until blob is dropped
+	first, ok := DecodeBlockPart(parts[0])
+	require.True(ok)
+	assert.Contains(store, blobKey(first))
+	assembler = NewBlobAssembler(1)
+	require.NoError(assembler.SetStore(store))
+	assembled, err = assembler.Add(2, parts[len(parts)-1])
+	require.NoError(err)
+	assert.Equal(blob, assembled)
+	other, err := SplitBlob([]byte("other"), 1000)
+	require.NoError(err)
+	_, err = assembler.Add(3, other[0])
+	require.NoError(err)
+	assert.NotContains(store, blobKey(first))
+}
+
+func TestBlobAssemblerRetrieveAgain(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	blob := make([]byte, 5000)
+	_, err := rand.Read(blob)
+	require.NoError(err)
+	parts, err := SplitBlob(blob, 1000)
+	require.NoError(err)
+
+	// parts are retrieved from consecutive DA heights, starting at 10
+	retrieve := func(assembler *BlobAssembler, i int) []byte {
+		assembled, err := assembler.Add(uint64(10+i), parts[i])
+		require.NoError(err)
+		return assembled
+	}
+	last := len(parts) - 1
+
+	// blob is returned at DA height of the last part, regardless of retrieval order
+	inOrder := NewBlobAssembler(10)
+	for i := range parts {
+		assembled := retrieve(inOrder, i)
+		if i < last {
+			assert.Nil(assembled)
+		}
+	}
+	reversed := NewBlobAssembler(10)
+	for i := last; i >= 0; i-- {
+		assert.Nil(retrieve(reversed, i))
+	}
+
+	// DA heights retrieved again return the same result
+	for _, assembler := range []*BlobAssembler{inOrder, reversed} {
+		for i := range parts {
+			if i < last {
+				assert.Nil(retrieve(assembler, i))
+			} else {
+				assert.Equal(blob, retrieve(assembler, i))
+			}
+		}
+		assert.Equal(blob, retrieve(assembler, last))
+	}
+}
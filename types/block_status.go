@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FinalityStatus describes how final a block is. Status of a block only moves forward.
+type FinalityStatus uint32
+
+const (
+	// FinalityUnknown is used for heights without any information about finality.
+	FinalityUnknown FinalityStatus = iota
+	// FinalityProduced is used for blocks that were created and applied by aggregator, but not published yet.
+	FinalityProduced
+	// FinalitySoftConfirmed is used for blocks that were gossiped in P2P network, but are not included in DA layer yet.
+	FinalitySoftConfirmed
+	// FinalityDAIncluded is used for blocks that are included in DA layer.
+	FinalityDAIncluded
+)
+
+var finalityNames = map[FinalityStatus]string{
+	FinalityUnknown:       "unknown",
+	FinalityProduced:      "produced",
+	FinalitySoftConfirmed: "soft-confirmed",
+	FinalityDAIncluded:    "da-included",
+}
+
+// EventBlockStatus is the type of event published when finality status of a block changes.
+const EventBlockStatus = "BlockStatus"
+
+// String returns human-readable name of finality status.
+func (f FinalityStatus) String() string {
+	if name, ok := finalityNames[f]; ok {
+		return name
+	}
+	return fmt.Sprintf("FinalityStatus(%d)", uint32(f))
+}
+
+// MarshalJSON encodes finality status as a string.
+func (f FinalityStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(f.String())
+}
+
+// UnmarshalJSON decodes finality status from a string.
+func (f *FinalityStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+	for status, n := range finalityNames {
+		if n == name {
+			*f = status
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown finality status: %q", name)
+}
+
+// BlockStatus contains finality status of a block at given height.
+//
+// BlockStatus is also used as data of EventBlockStatus events.
+type BlockStatus struct {
+	Height   uint64         `json:"height,string"`
+	Finality FinalityStatus `json:"finality"`
+	// DAHeight is the height of DA layer block containing the block; set only for DA-included blocks.
+	DAHeight uint64 `json:"da_height,string,omitempty"`
+}
@@ -0,0 +1,168 @@
+package types
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionCodec identifies algorithm used to compress the blob.
+type CompressionCodec byte
+
+const (
+	// CodecNone means that blob payload is not compressed.
+	CodecNone CompressionCodec = iota
+	// CodecZstd means that blob payload is compressed with zstd.
+	CodecZstd
+)
+
+// envelopeVersion is the current version of blob envelope format.
+const envelopeVersion = 1
+
+// maxDecompressedSize limits the size of decompressed blob, to protect against decompression bombs.
+const maxDecompressedSize = 128 << 20
+
+// envelopeMagic starts every enveloped blob.
+// Zero is not a valid protobuf field tag, so enveloped blobs are never confused with legacy (raw protobuf) blobs.
+var envelopeMagic = []byte{0x00, 'r', 'k'}
+
+// envelopeHeaderSize is the size of magic, version and codec.
+var envelopeHeaderSize = len(envelopeMagic) + 2
+
+var (
+	// ErrUnknownCodec is returned when blob is compressed with unsupported codec.
+	ErrUnknownCodec = errors.New("unknown compression codec")
+	// ErrUnsupportedEnvelope is returned when blob envelope version is not supported.
+	ErrUnsupportedEnvelope = errors.New("unsupported blob envelope version")
+)
+
+// Compressor compresses and decompresses blob payloads.
+type Compressor interface {
+	Compress(data []byte) ([]byte, error)
+	Decompress(data []byte) ([]byte, error)
+}
+
+var (
+	compressorsMtx sync.RWMutex
+	compressors    = map[CompressionCodec]Compressor{
+		CodecNone: noneCompressor{},
+		CodecZstd: &zstdCompressor{},
+	}
+	codecNames = map[string]CompressionCodec{
+		"none": CodecNone,
+		"zstd": CodecZstd,
+	}
+)
+
+// RegisterCompressor makes compressor available for the codec, under given name.
+func RegisterCompressor(codec CompressionCodec, name string, compressor Compressor) {
+	compressorsMtx.Lock()
+	defer compressorsMtx.Unlock()
+	compressors[codec] = compressor
+	codecNames[name] = codec
+}
+
+// ParseCompressionCodec returns codec registered with given name.
+func ParseCompressionCodec(name string) (CompressionCodec, error) {
+	compressorsMtx.RLock()
+	defer compressorsMtx.RUnlock()
+	codec, ok := codecNames[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+	}
+	return codec, nil
+}
+
+func getCompressor(codec CompressionCodec) (Compressor, error) {
+	compressorsMtx.RLock()
+	defer compressorsMtx.RUnlock()
+	compressor, ok := compressors[codec]
+	if !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownCodec, codec)
+	}
+	return compressor, nil
+}
+
+// CompressBlob compresses blob with given codec and wraps it in a self-describing envelope.
+func CompressBlob(blob []byte, codec CompressionCodec) ([]byte, error) {
+	compressor, err := getCompressor(codec)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := compressor.Compress(blob)
+	if err != nil {
+		return nil, err
+	}
+
+	enveloped := make([]byte, 0, envelopeHeaderSize+len(payload))
+	enveloped = append(enveloped, envelopeMagic...)
+	enveloped = append(enveloped, envelopeVersion, byte(codec))
+	return append(enveloped, payload...), nil
+}
+
+// DecompressBlob unwraps the envelope and decompresses the blob.
+// Blobs without envelope (posted before compression was introduced) are returned unchanged.
+func DecompressBlob(blob []byte) ([]byte, error) {
+	if !IsCompressedBlob(blob) {
+		return blob, nil
+	}
+	if version := blob[len(envelopeMagic)]; version != envelopeVersion {
+		return nil, fmt.Errorf("%w: %d", ErrUnsupportedEnvelope, version)
+	}
+	compressor, err := getCompressor(CompressionCodec(blob[len(envelopeMagic)+1]))
+	if err != nil {
+		return nil, err
+	}
+	return compressor.Decompress(blob[envelopeHeaderSize:])
+}
+
+// IsCompressedBlob checks if blob is wrapped in compression envelope.
+func IsCompressedBlob(blob []byte) bool {
+	return len(blob) >= envelopeHeaderSize && bytes.HasPrefix(blob, envelopeMagic)
+}
+
+type noneCompressor struct{}
+
+func (noneCompressor) Compress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+func (noneCompressor) Decompress(data []byte) ([]byte, error) {
+	return data, nil
+}
+
+// zstdCompressor lazily initializes encoder and decoder, which are safe for concurrent use.
+type zstdCompressor struct {
+	once    sync.Once
+	encoder *zstd.Encoder
+	decoder *zstd.Decoder
+	err     error
+}
+
+func (z *zstdCompressor) init() error {
+	z.once.Do(func() {
+		z.encoder, z.err = zstd.NewWriter(nil)
+		if z.err != nil {
+			return
+		}
+		z.decoder, z.err = zstd.NewReader(nil, zstd.WithDecoderMaxMemory(maxDecompressedSize))
+	})
+	return z.err
+}
+
+func (z *zstdCompressor) Compress(data []byte) ([]byte, error) {
+	if err := z.init(); err != nil {
+		return nil, err
+	}
+	return z.encoder.EncodeAll(data, nil), nil
+}
+
+func (z *zstdCompressor) Decompress(data []byte) ([]byte, error) {
+	if err := z.init(); err != nil {
+		return nil, err
+	}
+	return z.decoder.DecodeAll(data, nil)
+}
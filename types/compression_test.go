@@ -0,0 +1,182 @@
+package types
+
+import (
+	"crypto/rand"
+	"fmt"
+	mrand "math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestCompressBlob(t *testing.T) {
+	t.Parallel()
+
+	block := &Block{Data: Data{Txs: getCosmosTxs(100)}}
+	blob, err := block.MarshalBinary()
+	require.NoError(t, err)
+
+	for _, name := range []string{"none", "zstd"} {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			require := require.New(t)
+
+			codec, err := ParseCompressionCodec(name)
+			require.NoError(err)
+
+			compressed, err := CompressBlob(blob, codec)
+			require.NoError(err)
+			assert.True(IsCompressedBlob(compressed))
+
+			decompressed, err := DecompressBlob(compressed)
+			require.NoError(err)
+			assert.Equal(blob, decompressed)
+		})
+	}
+}
+
+func TestDecompressBlobLegacy(t *testing.T) {
+	t.Parallel()
+	assert := assert.New(t)
+	require := require.New(t)
+
+	// blobs posted before compression was introduced are raw protobuf
+	block := &Block{Data: Data{Txs: Txs{Tx{1, 2, 3}}}}
+	blob, err := block.MarshalBinary()
+	require.NoError(err)
+	assert.False(IsCompressedBlob(blob))
+
+	decompressed, err := DecompressBlob(blob)
+	require.NoError(err)
+	assert.Equal(blob, decompressed)
+
+	_, err = ParseCompressionCodec("brotli")
+	assert.ErrorIs(err, ErrUnknownCodec)
+
+	_, err = CompressBlob(blob, CompressionCodec(100))
+	assert.ErrorIs(err, ErrUnknownCodec)
+
+	unknown := append([]byte{0x00, 'r', 'k', envelopeVersion, 100}, blob...)
+	_, err = DecompressBlob(unknown)
+	assert.ErrorIs(err, ErrUnknownCodec)
+
+	unsupported := append([]byte{0x00, 'r', 'k', envelopeVersion + 1, byte(CodecNone)}, blob...)
+	_, err = DecompressBlob(unsupported)
+	assert.ErrorIs(err, ErrUnsupportedEnvelope)
+}
+
+func BenchmarkCompressBlob(b *testing.B) {
+	for _, nTxs := range []int{10, 100, 1000} {
+		block := &Block{Data: Data{Txs: getCosmosTxs(nTxs)}}
+		blob, err := block.MarshalBinary()
+		require.NoError(b, err)
+
+		for _, name := range []string{"none", "zstd"} {
+			codec, err := ParseCompressionCodec(name)
+			require.NoError(b, err)
+			b.Run(fmt.Sprintf("txs=%d/codec=%s", nTxs, name), func(b *testing.B) {
+				var compressed []byte
+				b.SetBytes(int64(len(blob)))
+				for i := 0; i < b.N; i++ {
+					compressed, err = CompressBlob(blob, codec)
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+				b.ReportMetric(float64(len(compressed)), "blob-bytes")
+				b.ReportMetric(100*(1-float64(len(compressed))/float64(len(blob))), "%saved")
+			})
+		}
+	}
+}
+
+// getCosmosTxs returns transactions encoded like Cosmos SDK TxRaw with a single bank MsgSend,
+// signed by one of a small set of accounts.
+func getCosmosTxs(n int) Txs {
+	const bech32Chars = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	accounts := make([]string, 20)
+	pubKeys := make([][]byte, len(accounts))
+	for i := range accounts {
+		addr := make([]byte, 38)
+		for j := range addr {
+			addr[j] = bech32Chars[mrand.Intn(len(bech32Chars))] //nolint:gosec
+		}
+		accounts[i] = "cosmos1" + string(addr)
+		pubKeys[i] = getRandomBytes(33)
+	}
+
+	txs := make(Txs, n)
+	for i := range txs {
+		from := mrand.Intn(len(accounts)) //nolint:gosec
+		to := mrand.Intn(len(accounts))   //nolint:gosec
+
+		coin := func(denom string, amount int) []byte {
+			var c []byte
+			c = protowire.AppendTag(c, 1, protowire.BytesType)
+			c = protowire.AppendString(c, denom)
+			c = protowire.AppendTag(c, 2, protowire.BytesType)
+			return protowire.AppendString(c, fmt.Sprint(amount))
+		}
+		anyMsg := func(typeURL string, value []byte) []byte {
+			var a []byte
+			a = protowire.AppendTag(a, 1, protowire.BytesType)
+			a = protowire.AppendString(a, typeURL)
+			a = protowire.AppendTag(a, 2, protowire.BytesType)
+			return protowire.AppendBytes(a, value)
+		}
+
+		var msgSend []byte
+		msgSend = protowire.AppendTag(msgSend, 1, protowire.BytesType)
+		msgSend = protowire.AppendString(msgSend, accounts[from])
+		msgSend = protowire.AppendTag(msgSend, 2, protowire.BytesType)
+		msgSend = protowire.AppendString(msgSend, accounts[to])
+		msgSend = protowire.AppendTag(msgSend, 3, protowire.BytesType)
+		msgSend = protowire.AppendBytes(msgSend, coin("stake", mrand.Intn(1000000))) //nolint:gosec
+
+		var body []byte
+		body = protowire.AppendTag(body, 1, protowire.BytesType)
+		body = protowire.AppendBytes(body, anyMsg("/cosmos.bank.v1beta1.MsgSend", msgSend))
+
+		var pubKey []byte
+		pubKey = protowire.AppendTag(pubKey, 1, protowire.BytesType)
+		pubKey = protowire.AppendBytes(pubKey, pubKeys[from])
+		var modeInfo []byte
+		modeInfo = protowire.AppendTag(modeInfo, 1, protowire.BytesType)
+		modeInfo = protowire.AppendBytes(modeInfo, protowire.AppendVarint(protowire.AppendTag(nil, 1, protowire.VarintType), 1))
+		var signerInfo []byte
+		signerInfo = protowire.AppendTag(signerInfo, 1, protowire.BytesType)
+		signerInfo = protowire.AppendBytes(signerInfo, anyMsg("/cosmos.crypto.secp256k1.PubKey", pubKey))
+		signerInfo = protowire.AppendTag(signerInfo, 2, protowire.BytesType)
+		signerInfo = protowire.AppendBytes(signerInfo, modeInfo)
+		signerInfo = protowire.AppendTag(signerInfo, 3, protowire.VarintType)
+		signerInfo = protowire.AppendVarint(signerInfo, uint64(mrand.Intn(1000))) //nolint:gosec
+		var fee []byte
+		fee = protowire.AppendTag(fee, 1, protowire.BytesType)
+		fee = protowire.AppendBytes(fee, coin("stake", 2000))
+		fee = protowire.AppendTag(fee, 2, protowire.VarintType)
+		fee = protowire.AppendVarint(fee, 200000)
+		var authInfo []byte
+		authInfo = protowire.AppendTag(authInfo, 1, protowire.BytesType)
+		authInfo = protowire.AppendBytes(authInfo, signerInfo)
+		authInfo = protowire.AppendTag(authInfo, 2, protowire.BytesType)
+		authInfo = protowire.AppendBytes(authInfo, fee)
+
+		var txRaw []byte
+		txRaw = protowire.AppendTag(txRaw, 1, protowire.BytesType)
+		txRaw = protowire.AppendBytes(txRaw, body)
+		txRaw = protowire.AppendTag(txRaw, 2, protowire.BytesType)
+		txRaw = protowire.AppendBytes(txRaw, authInfo)
+		txRaw = protowire.AppendTag(txRaw, 3, protowire.BytesType)
+		txRaw = protowire.AppendBytes(txRaw, getRandomBytes(64))
+		txs[i] = txRaw
+	}
+	return txs
+}
+
+func getRandomBytes(n int) []byte {
+	data := make([]byte, n)
+	_, _ = rand.Read(data)
+	return data
+}
@@ -0,0 +1,20 @@
+package types
+
+import (
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+)
+
+// DAInclusion describes where a block is stored in DA layer.
+type DAInclusion struct {
+	// Height is the height of the rollup block.
+	Height uint64 `json:"height,string"`
+	// Hash is the hash of the rollup block header.
+	Hash Hash `json:"hash"`
+	// DAHeight is the height of DA layer block containing the rollup block.
+	DAHeight uint64 `json:"da_height,string"`
+	// Commitment is DA layer specific identifier of data containing the block (e.g. blob commitment or tx hash).
+	// It's not known for blocks retrieved from DA layer.
+	Commitment tmbytes.HexBytes `json:"commitment,omitempty"`
+	// Namespace is the DA layer namespace containing the block.
+	Namespace tmbytes.HexBytes `json:"namespace"`
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"github.com/tendermint/tendermint/crypto/merkle"
 	tmbytes "github.com/tendermint/tendermint/libs/bytes"
 	tmversion "github.com/tendermint/tendermint/proto/tendermint/version"
 	tmtypes "github.com/tendermint/tendermint/types"
@@ -40,3 +41,23 @@ func (h *Header) Hash() Hash {
 func (b *Block) Hash() Hash {
 	return b.SignedHeader.Header.Hash()
 }
+
+// Hash returns Merkle root of block data. Header commits to block data with DataHash field.
+//
+// Leaves of Merkle tree are hashes of transactions, followed by intermediate state roots and hashes of evidence,
+// so transaction inclusion proofs can be verified against DataHash.
+func (d *Data) Hash() Hash {
+	return merkle.HashFromByteSlices(d.merkleLeaves())
+}
+
+func (d *Data) merkleLeaves() [][]byte {
+	leaves := make([][]byte, 0, len(d.Txs)+len(d.IntermediateStateRoots.RawRootsList)+len(d.Evidence.Evidence))
+	for _, tx := range d.Txs {
+		leaves = append(leaves, tx.Hash())
+	}
+	leaves = append(leaves, d.IntermediateStateRoots.RawRootsList...)
+	for _, ev := range d.Evidence.Evidence {
+		leaves = append(leaves, ev.Hash())
+	}
+	return leaves
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func TestDataHashAndTxProof(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	data := Data{
+		Txs: Txs{Tx("tx1"), Tx("tx2"), Tx("tx3")},
+	}
+	hash := data.Hash()
+	require.Len(hash, 32)
+	assert.Equal(Hash(data.Txs.Proof(0).RootHash), hash)
+	assert.NotEqual((&Data{}).Hash(), hash)
+	assert.False((&Header{DataHash: (&Data{}).Hash()}).HasLegacyDataHash())
+
+	// ISRs are committed as well
+	data.IntermediateStateRoots.RawRootsList = [][]byte{{1}, {2}, {3}, {4}}
+	assert.NotEqual(hash, data.Hash())
+	hash = data.Hash()
+
+	for i := range data.Txs {
+		proof := data.TxProof(i)
+		abciProof := tmtypes.TxProof{RootHash: proof.RootHash, Data: tmtypes.Tx(proof.Data), Proof: proof.Proof}
+		assert.NoError(abciProof.Validate(tmbytes.HexBytes(hash)), i)
+	}
+	proof := data.TxProof(0)
+	proof.Data = Tx("forged")
+	abciProof := tmtypes.TxProof{RootHash: proof.RootHash, Data: tmtypes.Tx(proof.Data), Proof: proof.Proof}
+	assert.Error(abciProof.Validate(tmbytes.HexBytes(hash)))
+}
@@ -35,6 +35,16 @@ const (
 	StatusCode_STATUS_CODE_SUCCESS     StatusCode = 1
 	StatusCode_STATUS_CODE_TIMEOUT     StatusCode = 2
 	StatusCode_STATUS_CODE_ERROR       StatusCode = 3
+	// requested DA height is not produced yet
+	StatusCode_STATUS_CODE_HEIGHT_FROM_FUTURE StatusCode = 4
+	// submitted data exceeds DA layer size limit
+	StatusCode_STATUS_CODE_BLOB_TOO_BIG StatusCode = 5
+	// submission was rejected because of too low fee
+	StatusCode_STATUS_CODE_INSUFFICIENT_FEE StatusCode = 6
+	// submitted data is already included in DA layer
+	StatusCode_STATUS_CODE_ALREADY_INCLUDED StatusCode = 7
+	// there is no data in the namespace at requested DA height
+	StatusCode_STATUS_CODE_NAMESPACE_EMPTY StatusCode = 8
 )
 
 var StatusCode_name = map[int32]string{
@@ -42,13 +52,23 @@ var StatusCode_name = map[int32]string{
 	1: "STATUS_CODE_SUCCESS",
 	2: "STATUS_CODE_TIMEOUT",
 	3: "STATUS_CODE_ERROR",
+	4: "STATUS_CODE_HEIGHT_FROM_FUTURE",
+	5: "STATUS_CODE_BLOB_TOO_BIG",
+	6: "STATUS_CODE_INSUFFICIENT_FEE",
+	7: "STATUS_CODE_ALREADY_INCLUDED",
+	8: "STATUS_CODE_NAMESPACE_EMPTY",
 }
 
 var StatusCode_value = map[string]int32{
-	"STATUS_CODE_UNSPECIFIED": 0,
-	"STATUS_CODE_SUCCESS":     1,
-	"STATUS_CODE_TIMEOUT":     2,
-	"STATUS_CODE_ERROR":       3,
+	"STATUS_CODE_UNSPECIFIED":        0,
+	"STATUS_CODE_SUCCESS":            1,
+	"STATUS_CODE_TIMEOUT":            2,
+	"STATUS_CODE_ERROR":              3,
+	"STATUS_CODE_HEIGHT_FROM_FUTURE": 4,
+	"STATUS_CODE_BLOB_TOO_BIG":       5,
+	"STATUS_CODE_INSUFFICIENT_FEE":   6,
+	"STATUS_CODE_ALREADY_INCLUDED":   7,
+	"STATUS_CODE_NAMESPACE_EMPTY":    8,
 }
 
 func (x StatusCode) String() string {
@@ -165,6 +185,11 @@ func (m *SubmitBlockRequest) GetBlock() *rollkit.Block {
 
 type SubmitBlockResponse struct {
 	Result *DAResponse `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// DA layer specific identifier of submitted data (e.g. blob commitment or tx hash)
+	Commitment []byte `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Namespace  []byte `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// fee paid for the submission, in DA layer native units
+	Fee uint64 `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
 }
 
 func (m *SubmitBlockResponse) Reset()         { *m = SubmitBlockResponse{} }
@@ -207,6 +232,141 @@ func (m *SubmitBlockResponse) GetResult() *DAResponse {
 	return nil
 }
 
+func (m *SubmitBlockResponse) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *SubmitBlockResponse) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *SubmitBlockResponse) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
+type SubmitBlocksRequest struct {
+	Blocks []*rollkit.Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (m *SubmitBlocksRequest) Reset()         { *m = SubmitBlocksRequest{} }
+func (m *SubmitBlocksRequest) String() string { return proto.CompactTextString(m) }
+func (*SubmitBlocksRequest) ProtoMessage()    {}
+func (*SubmitBlocksRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45d7d8eda2693dc1, []int{3}
+}
+func (m *SubmitBlocksRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubmitBlocksRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubmitBlocksRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubmitBlocksRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitBlocksRequest.Merge(m, src)
+}
+func (m *SubmitBlocksRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubmitBlocksRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitBlocksRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitBlocksRequest proto.InternalMessageInfo
+
+func (m *SubmitBlocksRequest) GetBlocks() []*rollkit.Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+type SubmitBlocksResponse struct {
+	Result *DAResponse `protobuf:"bytes,1,opt,name=result,proto3" json:"result,omitempty"`
+	// DA layer specific identifier of submitted data (e.g. blob commitment or tx hash)
+	Commitment []byte `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Namespace  []byte `protobuf:"bytes,3,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// fee paid for the submission, in DA layer native units
+	Fee uint64 `protobuf:"varint,4,opt,name=fee,proto3" json:"fee,omitempty"`
+}
+
+func (m *SubmitBlocksResponse) Reset()         { *m = SubmitBlocksResponse{} }
+func (m *SubmitBlocksResponse) String() string { return proto.CompactTextString(m) }
+func (*SubmitBlocksResponse) ProtoMessage()    {}
+func (*SubmitBlocksResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45d7d8eda2693dc1, []int{4}
+}
+func (m *SubmitBlocksResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubmitBlocksResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubmitBlocksResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubmitBlocksResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubmitBlocksResponse.Merge(m, src)
+}
+func (m *SubmitBlocksResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubmitBlocksResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubmitBlocksResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubmitBlocksResponse proto.InternalMessageInfo
+
+func (m *SubmitBlocksResponse) GetResult() *DAResponse {
+	if m != nil {
+		return m.Result
+	}
+	return nil
+}
+
+func (m *SubmitBlocksResponse) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *SubmitBlocksResponse) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
+func (m *SubmitBlocksResponse) GetFee() uint64 {
+	if m != nil {
+		return m.Fee
+	}
+	return 0
+}
+
 type CheckBlockAvailabilityRequest struct {
 	DAHeight uint64 `protobuf:"varint,1,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
 }
@@ -215,7 +375,7 @@ func (m *CheckBlockAvailabilityRequest) Reset()         { *m = CheckBlockAvailab
 func (m *CheckBlockAvailabilityRequest) String() string { return proto.CompactTextString(m) }
 func (*CheckBlockAvailabilityRequest) ProtoMessage()    {}
 func (*CheckBlockAvailabilityRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_45d7d8eda2693dc1, []int{3}
+	return fileDescriptor_45d7d8eda2693dc1, []int{5}
 }
 func (m *CheckBlockAvailabilityRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -260,7 +420,7 @@ func (m *CheckBlockAvailabilityResponse) Reset()         { *m = CheckBlockAvaila
 func (m *CheckBlockAvailabilityResponse) String() string { return proto.CompactTextString(m) }
 func (*CheckBlockAvailabilityResponse) ProtoMessage()    {}
 func (*CheckBlockAvailabilityResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_45d7d8eda2693dc1, []int{4}
+	return fileDescriptor_45d7d8eda2693dc1, []int{6}
 }
 func (m *CheckBlockAvailabilityResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -311,7 +471,7 @@ func (m *RetrieveBlocksRequest) Reset()         { *m = RetrieveBlocksRequest{} }
 func (m *RetrieveBlocksRequest) String() string { return proto.CompactTextString(m) }
 func (*RetrieveBlocksRequest) ProtoMessage()    {}
 func (*RetrieveBlocksRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_45d7d8eda2693dc1, []int{5}
+	return fileDescriptor_45d7d8eda2693dc1, []int{7}
 }
 func (m *RetrieveBlocksRequest) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -356,7 +516,7 @@ func (m *RetrieveBlocksResponse) Reset()         { *m = RetrieveBlocksResponse{}
 func (m *RetrieveBlocksResponse) String() string { return proto.CompactTextString(m) }
 func (*RetrieveBlocksResponse) ProtoMessage()    {}
 func (*RetrieveBlocksResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_45d7d8eda2693dc1, []int{6}
+	return fileDescriptor_45d7d8eda2693dc1, []int{8}
 }
 func (m *RetrieveBlocksResponse) XXX_Unmarshal(b []byte) error {
 	return m.Unmarshal(b)
@@ -399,55 +559,154 @@ func (m *RetrieveBlocksResponse) GetBlocks() []*rollkit.Block {
 	return nil
 }
 
+type SubscribeHeightsRequest struct {
+}
+
+func (m *SubscribeHeightsRequest) Reset()         { *m = SubscribeHeightsRequest{} }
+func (m *SubscribeHeightsRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeHeightsRequest) ProtoMessage()    {}
+func (*SubscribeHeightsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45d7d8eda2693dc1, []int{9}
+}
+func (m *SubscribeHeightsRequest) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeHeightsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeHeightsRequest.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeHeightsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeHeightsRequest.Merge(m, src)
+}
+func (m *SubscribeHeightsRequest) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeHeightsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeHeightsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeHeightsRequest proto.InternalMessageInfo
+
+type SubscribeHeightsResponse struct {
+	// height of newly produced DA block
+	DAHeight uint64 `protobuf:"varint,1,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+}
+
+func (m *SubscribeHeightsResponse) Reset()         { *m = SubscribeHeightsResponse{} }
+func (m *SubscribeHeightsResponse) String() string { return proto.CompactTextString(m) }
+func (*SubscribeHeightsResponse) ProtoMessage()    {}
+func (*SubscribeHeightsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_45d7d8eda2693dc1, []int{10}
+}
+func (m *SubscribeHeightsResponse) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *SubscribeHeightsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_SubscribeHeightsResponse.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *SubscribeHeightsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeHeightsResponse.Merge(m, src)
+}
+func (m *SubscribeHeightsResponse) XXX_Size() int {
+	return m.Size()
+}
+func (m *SubscribeHeightsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeHeightsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeHeightsResponse proto.InternalMessageInfo
+
+func (m *SubscribeHeightsResponse) GetDAHeight() uint64 {
+	if m != nil {
+		return m.DAHeight
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterEnum("dalc.StatusCode", StatusCode_name, StatusCode_value)
 	proto.RegisterType((*DAResponse)(nil), "dalc.DAResponse")
 	proto.RegisterType((*SubmitBlockRequest)(nil), "dalc.SubmitBlockRequest")
 	proto.RegisterType((*SubmitBlockResponse)(nil), "dalc.SubmitBlockResponse")
+	proto.RegisterType((*SubmitBlocksRequest)(nil), "dalc.SubmitBlocksRequest")
+	proto.RegisterType((*SubmitBlocksResponse)(nil), "dalc.SubmitBlocksResponse")
 	proto.RegisterType((*CheckBlockAvailabilityRequest)(nil), "dalc.CheckBlockAvailabilityRequest")
 	proto.RegisterType((*CheckBlockAvailabilityResponse)(nil), "dalc.CheckBlockAvailabilityResponse")
 	proto.RegisterType((*RetrieveBlocksRequest)(nil), "dalc.RetrieveBlocksRequest")
 	proto.RegisterType((*RetrieveBlocksResponse)(nil), "dalc.RetrieveBlocksResponse")
+	proto.RegisterType((*SubscribeHeightsRequest)(nil), "dalc.SubscribeHeightsRequest")
+	proto.RegisterType((*SubscribeHeightsResponse)(nil), "dalc.SubscribeHeightsResponse")
 }
 
 func init() { proto.RegisterFile("dalc/dalc.proto", fileDescriptor_45d7d8eda2693dc1) }
 
 var fileDescriptor_45d7d8eda2693dc1 = []byte{
-	// 530 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x9c, 0x94, 0xcf, 0x8f, 0xd2, 0x40,
-	0x14, 0xc7, 0x29, 0x8b, 0xc8, 0x3e, 0x14, 0x71, 0x56, 0x76, 0x2b, 0xab, 0x95, 0x54, 0x34, 0xe8,
-	0x81, 0x26, 0x78, 0xf3, 0x62, 0x4a, 0x5b, 0x23, 0xc6, 0x15, 0x33, 0x85, 0x8b, 0x17, 0xd2, 0x1f,
-	0x13, 0xa8, 0x14, 0xcb, 0x76, 0x06, 0xcc, 0xfe, 0x09, 0xde, 0xfc, 0xb3, 0x3c, 0xee, 0xd1, 0x93,
-	0x31, 0xf0, 0x8f, 0x18, 0x66, 0x8a, 0x0b, 0x58, 0x49, 0xd6, 0x0b, 0xbc, 0x79, 0x9f, 0x79, 0x6f,
-	0xbe, 0xaf, 0xef, 0xe5, 0xc1, 0x1d, 0xdf, 0x09, 0x3d, 0x6d, 0xf5, 0xd3, 0x9c, 0xc6, 0x11, 0x8b,
-	0x50, 0x6e, 0x65, 0x57, 0x2b, 0x71, 0x14, 0x86, 0xe3, 0x80, 0x69, 0xc9, 0xbf, 0x80, 0xd5, 0x7b,
-	0xc3, 0x68, 0x18, 0x71, 0x53, 0x5b, 0x59, 0xc2, 0xab, 0x7e, 0x01, 0x30, 0x75, 0x4c, 0xe8, 0x34,
-	0xfa, 0x4c, 0x09, 0xaa, 0x43, 0xce, 0x8b, 0x7c, 0x22, 0x4b, 0x35, 0xa9, 0x51, 0x6a, 0x95, 0x9b,
-	0x3c, 0xb7, 0xcd, 0x1c, 0x36, 0xa3, 0x46, 0xe4, 0x13, 0xcc, 0x29, 0x92, 0xe1, 0xe6, 0x84, 0x50,
-	0xea, 0x0c, 0x89, 0x9c, 0xad, 0x49, 0x8d, 0x43, 0xbc, 0x3e, 0xa2, 0x67, 0x70, 0xe8, 0x3b, 0x83,
-	0x11, 0x09, 0x86, 0x23, 0x26, 0x1f, 0xd4, 0xa4, 0x46, 0xae, 0x7d, 0x6b, 0xf1, 0xf3, 0x51, 0xc1,
-	0xd4, 0xdf, 0x70, 0x1f, 0x2e, 0xf8, 0x8e, 0xb0, 0xd4, 0x97, 0x80, 0xec, 0x99, 0x3b, 0x09, 0x58,
-	0x3b, 0x8c, 0xbc, 0x31, 0x26, 0xe7, 0x33, 0x42, 0x19, 0xaa, 0xc3, 0x0d, 0x77, 0x75, 0xe6, 0x0a,
-	0x8a, 0xad, 0x52, 0x73, 0x5d, 0x83, 0xb8, 0x25, 0xa0, 0xfa, 0x0a, 0x8e, 0xb6, 0x62, 0x13, 0xf5,
-	0x0d, 0xc8, 0xc7, 0x84, 0xce, 0x42, 0x96, 0x44, 0x27, 0xfa, 0xaf, 0xea, 0xc3, 0x09, 0x57, 0xdf,
-	0xc2, 0x43, 0x63, 0x44, 0xbc, 0x31, 0x8f, 0xd7, 0xe7, 0x4e, 0x10, 0x3a, 0x6e, 0x10, 0x06, 0xec,
-	0x62, 0xad, 0x63, 0xab, 0x10, 0x69, 0x6f, 0x21, 0xe7, 0xa0, 0xfc, 0x2b, 0xd7, 0x75, 0x75, 0xa1,
-	0x27, 0x50, 0xf2, 0x1d, 0xe6, 0x0c, 0x1c, 0x91, 0x26, 0x14, 0x1f, 0xb8, 0x80, 0x6f, 0xaf, 0xbc,
-	0xfa, 0xda, 0xa9, 0xb6, 0xa1, 0x82, 0x09, 0x8b, 0x03, 0x32, 0x27, 0xfc, 0x55, 0xfa, 0x1f, 0xb2,
-	0x3f, 0xc1, 0xf1, 0x6e, 0x8e, 0x6b, 0xcb, 0x7d, 0x0a, 0x79, 0xde, 0x10, 0x2a, 0x67, 0x6b, 0x07,
-	0x29, 0xed, 0x4a, 0xe8, 0xf3, 0x18, 0xe0, 0x6a, 0x88, 0xd0, 0x29, 0x9c, 0xd8, 0x3d, 0xbd, 0xd7,
-	0xb7, 0x07, 0x46, 0xd7, 0xb4, 0x06, 0xfd, 0xf7, 0xf6, 0x07, 0xcb, 0xe8, 0xbc, 0xee, 0x58, 0x66,
-	0x39, 0x83, 0x4e, 0xe0, 0x68, 0x13, 0xda, 0x7d, 0xc3, 0xb0, 0x6c, 0xbb, 0x2c, 0xed, 0x82, 0x5e,
-	0xe7, 0xcc, 0xea, 0xf6, 0x7b, 0xe5, 0x2c, 0xaa, 0xc0, 0xdd, 0x4d, 0x60, 0x61, 0xdc, 0xc5, 0xe5,
-	0x83, 0xd6, 0xd7, 0x2c, 0x14, 0x4d, 0xfd, 0x9d, 0x61, 0x93, 0x78, 0x1e, 0x78, 0x04, 0x99, 0x50,
-	0xdc, 0x98, 0x19, 0x24, 0x27, 0xb3, 0xfd, 0xd7, 0x08, 0x56, 0xef, 0xa7, 0x10, 0x51, 0xb7, 0x9a,
-	0x41, 0x04, 0x8e, 0xd3, 0x9b, 0x8d, 0x1e, 0x8b, 0xb0, 0xbd, 0x63, 0x55, 0xad, 0xef, 0xbf, 0xf4,
-	0xe7, 0x99, 0x33, 0x28, 0x6d, 0x37, 0x07, 0x9d, 0x8a, 0xc8, 0xd4, 0xb6, 0x57, 0x1f, 0xa4, 0xc3,
-	0x75, 0xba, 0x76, 0xfb, 0xfb, 0x42, 0x91, 0x2e, 0x17, 0x8a, 0xf4, 0x6b, 0xa1, 0x48, 0xdf, 0x96,
-	0x4a, 0xe6, 0x72, 0xa9, 0x64, 0x7e, 0x2c, 0x95, 0xcc, 0xc7, 0xc6, 0x30, 0x60, 0xa3, 0x99, 0xdb,
-	0xf4, 0xa2, 0x89, 0xb6, 0xb3, 0x36, 0x34, 0x76, 0x31, 0x25, 0x54, 0x9b, 0xba, 0x7c, 0xc3, 0xb8,
-	0x79, 0xbe, 0x2f, 0x5e, 0xfc, 0x0e, 0x00, 0x00, 0xff, 0xff, 0xb8, 0x8f, 0x4d, 0x6f, 0x75, 0x04,
-	0x00, 0x00,
+	// 757 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x55, 0xcd, 0x72, 0xe2, 0x46,
+	0x10, 0x46, 0xc0, 0xb2, 0xb8, 0x4d, 0x88, 0x32, 0xbb, 0x5e, 0x6b, 0x65, 0x56, 0x4b, 0x29, 0x4e,
+	0x8a, 0xe4, 0x00, 0x29, 0xe7, 0x96, 0xaa, 0x1c, 0xf4, 0x33, 0xd8, 0x4a, 0xf1, 0xe3, 0x1a, 0x49,
+	0x87, 0xcd, 0x45, 0x25, 0x89, 0x09, 0x28, 0x16, 0x16, 0x8b, 0x84, 0x53, 0xfb, 0x14, 0xd9, 0x6b,
+	0x1e, 0x21, 0x6f, 0x92, 0xe3, 0x1e, 0x73, 0x4a, 0xa5, 0xf0, 0x8b, 0xa4, 0xd0, 0x0f, 0x08, 0xcc,
+	0x52, 0xf1, 0x69, 0x2f, 0xd0, 0xea, 0xaf, 0xbb, 0xe7, 0xfb, 0xaa, 0xbb, 0x67, 0xe0, 0xf3, 0x91,
+	0xed, 0xbb, 0x9d, 0xd5, 0x4f, 0x7b, 0x36, 0x0f, 0xa2, 0x00, 0x95, 0x57, 0x36, 0x7f, 0x32, 0x0f,
+	0x7c, 0xff, 0xc6, 0x8b, 0x3a, 0xe9, 0x7f, 0x02, 0xf2, 0xcf, 0xc7, 0xc1, 0x38, 0x88, 0xcd, 0xce,
+	0xca, 0x4a, 0xbc, 0xe2, 0x6f, 0x00, 0xaa, 0x44, 0x68, 0x38, 0x0b, 0x6e, 0x43, 0x8a, 0xce, 0xa1,
+	0xec, 0x06, 0x23, 0xca, 0x31, 0x4d, 0xa6, 0x55, 0xbf, 0x60, 0xdb, 0x71, 0x6d, 0x3d, 0xb2, 0xa3,
+	0x45, 0xa8, 0x04, 0x23, 0x4a, 0x62, 0x14, 0x71, 0xf0, 0x74, 0x4a, 0xc3, 0xd0, 0x1e, 0x53, 0xae,
+	0xd8, 0x64, 0x5a, 0x47, 0x24, 0xfb, 0x44, 0xdf, 0xc0, 0xd1, 0xc8, 0xb6, 0x26, 0xd4, 0x1b, 0x4f,
+	0x22, 0xae, 0xd4, 0x64, 0x5a, 0x65, 0xb9, 0xb6, 0xfc, 0xe7, 0x75, 0x55, 0x95, 0xae, 0x62, 0x1f,
+	0xa9, 0x8e, 0xec, 0xc4, 0x12, 0x7f, 0x00, 0xa4, 0x2f, 0x9c, 0xa9, 0x17, 0xc9, 0x7e, 0xe0, 0xde,
+	0x10, 0xfa, 0x76, 0x41, 0xc3, 0x08, 0x9d, 0xc3, 0x13, 0x67, 0xf5, 0x1d, 0x33, 0x38, 0xbe, 0xa8,
+	0xb7, 0x33, 0x0d, 0x49, 0x54, 0x02, 0x8a, 0xbf, 0x33, 0xf0, 0x6c, 0x2b, 0x39, 0xa5, 0xdf, 0x82,
+	0xca, 0x9c, 0x86, 0x0b, 0x3f, 0x4a, 0xd3, 0x53, 0x01, 0x1b, 0x81, 0x24, 0xc5, 0x91, 0x00, 0xe0,
+	0x06, 0xd3, 0xa9, 0x17, 0x4d, 0xe9, 0x6d, 0x14, 0xab, 0xa8, 0x91, 0x9c, 0x07, 0x35, 0xe0, 0xe8,
+	0xd6, 0x9e, 0xd2, 0x70, 0x66, 0xbb, 0x34, 0x16, 0x52, 0x23, 0x1b, 0x07, 0x62, 0xa1, 0xf4, 0x0b,
+	0xa5, 0x5c, 0x79, 0x25, 0x90, 0xac, 0x4c, 0xf1, 0xc7, 0x2d, 0x42, 0x61, 0x26, 0xe7, 0x6b, 0xa8,
+	0xc4, 0x8c, 0x43, 0x8e, 0x69, 0x96, 0xf6, 0xe8, 0x49, 0x51, 0xf1, 0x3d, 0x03, 0xcf, 0xb7, 0xf3,
+	0x3f, 0xb9, 0xa2, 0x9f, 0xe0, 0x95, 0x32, 0xa1, 0xee, 0x4d, 0x4c, 0x48, 0xba, 0xb3, 0x3d, 0xdf,
+	0x76, 0x3c, 0xdf, 0x8b, 0xde, 0x65, 0xda, 0xb6, 0x7a, 0xcd, 0x1c, 0xec, 0xf5, 0x5b, 0x10, 0x3e,
+	0x56, 0xeb, 0xd1, 0x3a, 0xbf, 0x82, 0xfa, 0xc8, 0x8e, 0x6c, 0xcb, 0x4e, 0xca, 0xf8, 0xc9, 0x0c,
+	0x56, 0xc9, 0x67, 0x2b, 0xaf, 0x94, 0x39, 0x45, 0x19, 0x4e, 0x08, 0x8d, 0xe6, 0x1e, 0xbd, 0xa3,
+	0xdb, 0x2d, 0x79, 0x04, 0xed, 0x5f, 0xe1, 0xc5, 0x6e, 0x8d, 0x47, 0xd3, 0xdd, 0x4c, 0x40, 0xf1,
+	0xe0, 0x04, 0xbc, 0x84, 0x53, 0x7d, 0xe1, 0x84, 0xee, 0xdc, 0x73, 0x68, 0x72, 0x7c, 0xc6, 0x58,
+	0xc4, 0xc0, 0x3d, 0x84, 0x52, 0x22, 0xff, 0x5f, 0xcd, 0xb7, 0x7f, 0x14, 0x01, 0x36, 0xab, 0x8c,
+	0xce, 0xe0, 0x54, 0x37, 0x24, 0xc3, 0xd4, 0x2d, 0x65, 0xa8, 0x62, 0xcb, 0x1c, 0xe8, 0xd7, 0x58,
+	0xd1, 0xba, 0x1a, 0x56, 0xd9, 0x02, 0x3a, 0x85, 0x67, 0x79, 0x50, 0x37, 0x15, 0x05, 0xeb, 0x3a,
+	0xcb, 0xec, 0x02, 0x86, 0xd6, 0xc7, 0x43, 0xd3, 0x60, 0x8b, 0xe8, 0x04, 0xbe, 0xc8, 0x03, 0x98,
+	0x90, 0x21, 0x61, 0x4b, 0x48, 0x04, 0x21, 0xef, 0xbe, 0xc2, 0xda, 0xe5, 0x95, 0x61, 0x75, 0xc9,
+	0xb0, 0x6f, 0x75, 0x4d, 0xc3, 0x24, 0x98, 0x2d, 0xa3, 0x06, 0x70, 0xf9, 0x18, 0xb9, 0x37, 0x94,
+	0x2d, 0x63, 0x38, 0xb4, 0x64, 0xed, 0x92, 0x7d, 0x82, 0x9a, 0xd0, 0xc8, 0xa3, 0xda, 0x40, 0x37,
+	0xbb, 0x5d, 0x4d, 0xd1, 0xf0, 0xc0, 0xb0, 0xba, 0x18, 0xb3, 0x95, 0xdd, 0x08, 0xa9, 0x47, 0xb0,
+	0xa4, 0xbe, 0xb1, 0xb4, 0x81, 0xd2, 0x33, 0x55, 0xac, 0xb2, 0x4f, 0xd1, 0x6b, 0x38, 0xcb, 0x47,
+	0x0c, 0xa4, 0x3e, 0xd6, 0xaf, 0x25, 0x05, 0x5b, 0xb8, 0x7f, 0x6d, 0xbc, 0x61, 0xab, 0x17, 0x7f,
+	0x96, 0xe0, 0x58, 0x95, 0x7a, 0x8a, 0x4e, 0xe7, 0x77, 0x9e, 0x4b, 0x91, 0x0a, 0xc7, 0xb9, 0x75,
+	0x44, 0x5c, 0x7a, 0x11, 0x3e, 0xb8, 0xaf, 0xf8, 0x97, 0x7b, 0x90, 0xa4, 0x35, 0x62, 0x01, 0x5d,
+	0x42, 0x2d, 0xbf, 0xd4, 0xe8, 0x61, 0x70, 0xd6, 0x63, 0x9e, 0xdf, 0x07, 0xad, 0x0b, 0x51, 0x78,
+	0xb1, 0x7f, 0x7f, 0xd0, 0x97, 0x49, 0xde, 0xc1, 0x4d, 0xe5, 0xcf, 0x0f, 0x07, 0xad, 0x8f, 0xe9,
+	0x43, 0x7d, 0x7b, 0xde, 0xd1, 0x59, 0x92, 0xb9, 0x77, 0x93, 0xf8, 0xc6, 0x7e, 0x70, 0x5d, 0xce,
+	0x04, 0x76, 0x77, 0x6e, 0xd1, 0xab, 0xb5, 0xce, 0x7d, 0xa3, 0xce, 0x0b, 0x1f, 0x83, 0xb3, 0xa2,
+	0xdf, 0x31, 0xb2, 0xfc, 0xd7, 0x52, 0x60, 0x3e, 0x2c, 0x05, 0xe6, 0xdf, 0xa5, 0xc0, 0xbc, 0xbf,
+	0x17, 0x0a, 0x1f, 0xee, 0x85, 0xc2, 0xdf, 0xf7, 0x42, 0xe1, 0xe7, 0xd6, 0xd8, 0x8b, 0x26, 0x0b,
+	0xa7, 0xed, 0x06, 0xd3, 0xce, 0xce, 0x1b, 0xd8, 0x89, 0xde, 0xcd, 0x68, 0xd8, 0x99, 0x39, 0xf1,
+	0x73, 0xe9, 0x54, 0xe2, 0xc7, 0xef, 0xfb, 0xff, 0x02, 0x00, 0x00, 0xff, 0xff, 0x7d, 0x50, 0x9b,
+	0x86, 0x42, 0x07, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -463,8 +722,10 @@ const _ = grpc.SupportPackageIsVersion4
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
 type DALCServiceClient interface {
 	SubmitBlock(ctx context.Context, in *SubmitBlockRequest, opts ...grpc.CallOption) (*SubmitBlockResponse, error)
+	SubmitBlocks(ctx context.Context, in *SubmitBlocksRequest, opts ...grpc.CallOption) (*SubmitBlocksResponse, error)
 	CheckBlockAvailability(ctx context.Context, in *CheckBlockAvailabilityRequest, opts ...grpc.CallOption) (*CheckBlockAvailabilityResponse, error)
 	RetrieveBlocks(ctx context.Context, in *RetrieveBlocksRequest, opts ...grpc.CallOption) (*RetrieveBlocksResponse, error)
+	SubscribeHeights(ctx context.Context, in *SubscribeHeightsRequest, opts ...grpc.CallOption) (DALCService_SubscribeHeightsClient, error)
 }
 
 type dALCServiceClient struct {
@@ -484,6 +745,15 @@ func (c *dALCServiceClient) SubmitBlock(ctx context.Context, in *SubmitBlockRequ
 	return out, nil
 }
 
+func (c *dALCServiceClient) SubmitBlocks(ctx context.Context, in *SubmitBlocksRequest, opts ...grpc.CallOption) (*SubmitBlocksResponse, error) {
+	out := new(SubmitBlocksResponse)
+	err := c.cc.Invoke(ctx, "/dalc.DALCService/SubmitBlocks", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *dALCServiceClient) CheckBlockAvailability(ctx context.Context, in *CheckBlockAvailabilityRequest, opts ...grpc.CallOption) (*CheckBlockAvailabilityResponse, error) {
 	out := new(CheckBlockAvailabilityResponse)
 	err := c.cc.Invoke(ctx, "/dalc.DALCService/CheckBlockAvailability", in, out, opts...)
@@ -502,11 +772,45 @@ func (c *dALCServiceClient) RetrieveBlocks(ctx context.Context, in *RetrieveBloc
 	return out, nil
 }
 
+func (c *dALCServiceClient) SubscribeHeights(ctx context.Context, in *SubscribeHeightsRequest, opts ...grpc.CallOption) (DALCService_SubscribeHeightsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_DALCService_serviceDesc.Streams[0], "/dalc.DALCService/SubscribeHeights", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &dALCServiceSubscribeHeightsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DALCService_SubscribeHeightsClient interface {
+	Recv() (*SubscribeHeightsResponse, error)
+	grpc.ClientStream
+}
+
+type dALCServiceSubscribeHeightsClient struct {
+	grpc.ClientStream
+}
+
+func (x *dALCServiceSubscribeHeightsClient) Recv() (*SubscribeHeightsResponse, error) {
+	m := new(SubscribeHeightsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // DALCServiceServer is the server API for DALCService service.
 type DALCServiceServer interface {
 	SubmitBlock(context.Context, *SubmitBlockRequest) (*SubmitBlockResponse, error)
+	SubmitBlocks(context.Context, *SubmitBlocksRequest) (*SubmitBlocksResponse, error)
 	CheckBlockAvailability(context.Context, *CheckBlockAvailabilityRequest) (*CheckBlockAvailabilityResponse, error)
 	RetrieveBlocks(context.Context, *RetrieveBlocksRequest) (*RetrieveBlocksResponse, error)
+	SubscribeHeights(*SubscribeHeightsRequest, DALCService_SubscribeHeightsServer) error
 }
 
 // UnimplementedDALCServiceServer can be embedded to have forward compatible implementations.
@@ -516,12 +820,18 @@ type UnimplementedDALCServiceServer struct {
 func (*UnimplementedDALCServiceServer) SubmitBlock(ctx context.Context, req *SubmitBlockRequest) (*SubmitBlockResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method SubmitBlock not implemented")
 }
+func (*UnimplementedDALCServiceServer) SubmitBlocks(ctx context.Context, req *SubmitBlocksRequest) (*SubmitBlocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitBlocks not implemented")
+}
 func (*UnimplementedDALCServiceServer) CheckBlockAvailability(ctx context.Context, req *CheckBlockAvailabilityRequest) (*CheckBlockAvailabilityResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CheckBlockAvailability not implemented")
 }
 func (*UnimplementedDALCServiceServer) RetrieveBlocks(ctx context.Context, req *RetrieveBlocksRequest) (*RetrieveBlocksResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RetrieveBlocks not implemented")
 }
+func (*UnimplementedDALCServiceServer) SubscribeHeights(req *SubscribeHeightsRequest, srv DALCService_SubscribeHeightsServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeHeights not implemented")
+}
 
 func RegisterDALCServiceServer(s *grpc.Server, srv DALCServiceServer) {
 	s.RegisterService(&_DALCService_serviceDesc, srv)
@@ -545,6 +855,24 @@ func _DALCService_SubmitBlock_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DALCService_SubmitBlocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SubmitBlocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DALCServiceServer).SubmitBlocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dalc.DALCService/SubmitBlocks",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DALCServiceServer).SubmitBlocks(ctx, req.(*SubmitBlocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DALCService_CheckBlockAvailability_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckBlockAvailabilityRequest)
 	if err := dec(in); err != nil {
@@ -581,6 +909,27 @@ func _DALCService_RetrieveBlocks_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DALCService_SubscribeHeights_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeHeightsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DALCServiceServer).SubscribeHeights(m, &dALCServiceSubscribeHeightsServer{stream})
+}
+
+type DALCService_SubscribeHeightsServer interface {
+	Send(*SubscribeHeightsResponse) error
+	grpc.ServerStream
+}
+
+type dALCServiceSubscribeHeightsServer struct {
+	grpc.ServerStream
+}
+
+func (x *dALCServiceSubscribeHeightsServer) Send(m *SubscribeHeightsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 var _DALCService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "dalc.DALCService",
 	HandlerType: (*DALCServiceServer)(nil),
@@ -589,6 +938,10 @@ var _DALCService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "SubmitBlock",
 			Handler:    _DALCService_SubmitBlock_Handler,
 		},
+		{
+			MethodName: "SubmitBlocks",
+			Handler:    _DALCService_SubmitBlocks_Handler,
+		},
 		{
 			MethodName: "CheckBlockAvailability",
 			Handler:    _DALCService_CheckBlockAvailability_Handler,
@@ -598,7 +951,13 @@ var _DALCService_serviceDesc = grpc.ServiceDesc{
 			Handler:    _DALCService_RetrieveBlocks_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribeHeights",
+			Handler:       _DALCService_SubscribeHeights_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "dalc/dalc.proto",
 }
 
@@ -697,6 +1056,25 @@ func (m *SubmitBlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.Fee != 0 {
+		i = encodeVarintDalc(dAtA, i, uint64(m.Fee))
+		i--
+		dAtA[i] = 0x20
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintDalc(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x1a
+	}
+	if len(m.Commitment) > 0 {
+		i -= len(m.Commitment)
+		copy(dAtA[i:], m.Commitment)
+		i = encodeVarintDalc(dAtA, i, uint64(len(m.Commitment)))
+		i--
+		dAtA[i] = 0x12
+	}
 	if m.Result != nil {
 		{
 			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
@@ -712,7 +1090,7 @@ func (m *SubmitBlockResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *CheckBlockAvailabilityRequest) Marshal() (dAtA []byte, err error) {
+func (m *SubmitBlocksRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -722,25 +1100,34 @@ func (m *CheckBlockAvailabilityRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckBlockAvailabilityRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *SubmitBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CheckBlockAvailabilityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SubmitBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.DAHeight != 0 {
-		i = encodeVarintDalc(dAtA, i, uint64(m.DAHeight))
-		i--
-		dAtA[i] = 0x8
+	if len(m.Blocks) > 0 {
+		for iNdEx := len(m.Blocks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Blocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDalc(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
 	return len(dAtA) - i, nil
 }
 
-func (m *CheckBlockAvailabilityResponse) Marshal() (dAtA []byte, err error) {
+func (m *SubmitBlocksResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -750,25 +1137,34 @@ func (m *CheckBlockAvailabilityResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *CheckBlockAvailabilityResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *SubmitBlocksResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *CheckBlockAvailabilityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *SubmitBlocksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if m.DataAvailable {
+	if m.Fee != 0 {
+		i = encodeVarintDalc(dAtA, i, uint64(m.Fee))
 		i--
-		if m.DataAvailable {
-			dAtA[i] = 1
-		} else {
-			dAtA[i] = 0
-		}
+		dAtA[i] = 0x20
+	}
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintDalc(dAtA, i, uint64(len(m.Namespace)))
 		i--
-		dAtA[i] = 0x10
+		dAtA[i] = 0x1a
+	}
+	if len(m.Commitment) > 0 {
+		i -= len(m.Commitment)
+		copy(dAtA[i:], m.Commitment)
+		i = encodeVarintDalc(dAtA, i, uint64(len(m.Commitment)))
+		i--
+		dAtA[i] = 0x12
 	}
 	if m.Result != nil {
 		{
@@ -785,7 +1181,7 @@ func (m *CheckBlockAvailabilityResponse) MarshalToSizedBuffer(dAtA []byte) (int,
 	return len(dAtA) - i, nil
 }
 
-func (m *RetrieveBlocksRequest) Marshal() (dAtA []byte, err error) {
+func (m *CheckBlockAvailabilityRequest) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -795,12 +1191,12 @@ func (m *RetrieveBlocksRequest) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RetrieveBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
+func (m *CheckBlockAvailabilityRequest) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RetrieveBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CheckBlockAvailabilityRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
@@ -813,7 +1209,7 @@ func (m *RetrieveBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func (m *RetrieveBlocksResponse) Marshal() (dAtA []byte, err error) {
+func (m *CheckBlockAvailabilityResponse) Marshal() (dAtA []byte, err error) {
 	size := m.Size()
 	dAtA = make([]byte, size)
 	n, err := m.MarshalToSizedBuffer(dAtA[:size])
@@ -823,26 +1219,99 @@ func (m *RetrieveBlocksResponse) Marshal() (dAtA []byte, err error) {
 	return dAtA[:n], nil
 }
 
-func (m *RetrieveBlocksResponse) MarshalTo(dAtA []byte) (int, error) {
+func (m *CheckBlockAvailabilityResponse) MarshalTo(dAtA []byte) (int, error) {
 	size := m.Size()
 	return m.MarshalToSizedBuffer(dAtA[:size])
 }
 
-func (m *RetrieveBlocksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+func (m *CheckBlockAvailabilityResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	i := len(dAtA)
 	_ = i
 	var l int
 	_ = l
-	if len(m.Blocks) > 0 {
-		for iNdEx := len(m.Blocks) - 1; iNdEx >= 0; iNdEx-- {
-			{
-				size, err := m.Blocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
-				if err != nil {
-					return 0, err
-				}
-				i -= size
-				i = encodeVarintDalc(dAtA, i, uint64(size))
-			}
+	if m.DataAvailable {
+		i--
+		if m.DataAvailable {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Result != nil {
+		{
+			size, err := m.Result.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintDalc(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0xa
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RetrieveBlocksRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetrieveBlocksRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RetrieveBlocksRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DAHeight != 0 {
+		i = encodeVarintDalc(dAtA, i, uint64(m.DAHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *RetrieveBlocksResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *RetrieveBlocksResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *RetrieveBlocksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for iNdEx := len(m.Blocks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Blocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintDalc(dAtA, i, uint64(size))
+			}
 			i--
 			dAtA[i] = 0x12
 		}
@@ -862,6 +1331,57 @@ func (m *RetrieveBlocksResponse) MarshalToSizedBuffer(dAtA []byte) (int, error)
 	return len(dAtA) - i, nil
 }
 
+func (m *SubscribeHeightsRequest) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeHeightsRequest) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeHeightsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	return len(dAtA) - i, nil
+}
+
+func (m *SubscribeHeightsResponse) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *SubscribeHeightsResponse) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *SubscribeHeightsResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DAHeight != 0 {
+		i = encodeVarintDalc(dAtA, i, uint64(m.DAHeight))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintDalc(dAtA []byte, offset int, v uint64) int {
 	offset -= sovDalc(v)
 	base := offset
@@ -915,6 +1435,56 @@ func (m *SubmitBlockResponse) Size() (n int) {
 		l = m.Result.Size()
 		n += 1 + l + sovDalc(uint64(l))
 	}
+	l = len(m.Commitment)
+	if l > 0 {
+		n += 1 + l + sovDalc(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovDalc(uint64(l))
+	}
+	if m.Fee != 0 {
+		n += 1 + sovDalc(uint64(m.Fee))
+	}
+	return n
+}
+
+func (m *SubmitBlocksRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for _, e := range m.Blocks {
+			l = e.Size()
+			n += 1 + l + sovDalc(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *SubmitBlocksResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Result != nil {
+		l = m.Result.Size()
+		n += 1 + l + sovDalc(uint64(l))
+	}
+	l = len(m.Commitment)
+	if l > 0 {
+		n += 1 + l + sovDalc(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovDalc(uint64(l))
+	}
+	if m.Fee != 0 {
+		n += 1 + sovDalc(uint64(m.Fee))
+	}
 	return n
 }
 
@@ -977,6 +1547,27 @@ func (m *RetrieveBlocksResponse) Size() (n int) {
 	return n
 }
 
+func (m *SubscribeHeightsRequest) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	return n
+}
+
+func (m *SubscribeHeightsResponse) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.DAHeight != 0 {
+		n += 1 + sovDalc(uint64(m.DAHeight))
+	}
+	return n
+}
+
 func sovDalc(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -993,30 +1584,272 @@ func (m *DAResponse) Unmarshal(dAtA []byte) error {
 			if shift >= 64 {
 				return ErrIntOverflowDalc
 			}
-			if iNdEx >= l {
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DAResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DAResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			}
+			m.Code = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Code |= StatusCode(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Message = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DAHeight", wireType)
+			}
+			m.DAHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DAHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDalc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitBlockRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDalc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitBlockRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Block == nil {
+				m.Block = &rollkit.Block{}
+			}
+			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDalc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubmitBlockResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDalc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubmitBlockResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubmitBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Result", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			b := dAtA[iNdEx]
-			iNdEx++
-			wire |= uint64(b&0x7F) << shift
-			if b < 0x80 {
-				break
+			if m.Result == nil {
+				m.Result = &DAResponse{}
 			}
-		}
-		fieldNum := int32(wire >> 3)
-		wireType := int(wire & 0x7)
-		if wireType == 4 {
-			return fmt.Errorf("proto: DAResponse: wiretype end group for non-group")
-		}
-		if fieldNum <= 0 {
-			return fmt.Errorf("proto: DAResponse: illegal tag %d (wire type %d)", fieldNum, wire)
-		}
-		switch fieldNum {
-		case 1:
-			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Code", wireType)
+			if err := m.Result.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
-			m.Code = 0
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commitment", wireType)
+			}
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDalc
@@ -1026,16 +1859,31 @@ func (m *DAResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.Code |= StatusCode(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-		case 2:
+			if byteLen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commitment = append(m.Commitment[:0], dAtA[iNdEx:postIndex]...)
+			if m.Commitment == nil {
+				m.Commitment = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Message", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
 			}
-			var stringLen uint64
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDalc
@@ -1045,29 +1893,31 @@ func (m *DAResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthDalc
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthDalc
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Message = string(dAtA[iNdEx:postIndex])
+			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
+			if m.Namespace == nil {
+				m.Namespace = []byte{}
+			}
 			iNdEx = postIndex
-		case 3:
+		case 4:
 			if wireType != 0 {
-				return fmt.Errorf("proto: wrong wireType = %d for field DAHeight", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Fee", wireType)
 			}
-			m.DAHeight = 0
+			m.Fee = 0
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowDalc
@@ -1077,7 +1927,7 @@ func (m *DAResponse) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				m.DAHeight |= uint64(b&0x7F) << shift
+				m.Fee |= uint64(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
@@ -1103,7 +1953,7 @@ func (m *DAResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubmitBlockRequest) Unmarshal(dAtA []byte) error {
+func (m *SubmitBlocksRequest) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1126,15 +1976,15 @@ func (m *SubmitBlockRequest) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubmitBlockRequest: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubmitBlocksRequest: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubmitBlockRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubmitBlocksRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Block", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1161,10 +2011,8 @@ func (m *SubmitBlockRequest) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Block == nil {
-				m.Block = &rollkit.Block{}
-			}
-			if err := m.Block.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Blocks = append(m.Blocks, &rollkit.Block{})
+			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1189,7 +2037,7 @@ func (m *SubmitBlockRequest) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SubmitBlockResponse) Unmarshal(dAtA []byte) error {
+func (m *SubmitBlocksResponse) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1212,10 +2060,10 @@ func (m *SubmitBlockResponse) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SubmitBlockResponse: wiretype end group for non-group")
+			return fmt.Errorf("proto: SubmitBlocksResponse: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SubmitBlockResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: SubmitBlocksResponse: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
@@ -1254,6 +2102,93 @@ func (m *SubmitBlockResponse) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commitment", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commitment = append(m.Commitment[:0], dAtA[iNdEx:postIndex]...)
+			if m.Commitment == nil {
+				m.Commitment = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthDalc
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
+			if m.Namespace == nil {
+				m.Namespace = []byte{}
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Fee", wireType)
+			}
+			m.Fee = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Fee |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipDalc(dAtA[iNdEx:])
@@ -1639,6 +2574,125 @@ func (m *RetrieveBlocksResponse) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *SubscribeHeightsRequest) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDalc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeHeightsRequest: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeHeightsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDalc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SubscribeHeightsResponse) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowDalc
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SubscribeHeightsResponse: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SubscribeHeightsResponse: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DAHeight", wireType)
+			}
+			m.DAHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowDalc
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DAHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipDalc(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthDalc
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipDalc(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
@@ -446,6 +446,267 @@ func (m *Block) GetData() *Data {
 	return nil
 }
 
+// Batch is used to post multiple blocks in a single DA blob.
+type Batch struct {
+	Blocks []*Block `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+}
+
+func (m *Batch) Reset()         { *m = Batch{} }
+func (m *Batch) String() string { return proto.CompactTextString(m) }
+func (*Batch) ProtoMessage()    {}
+func (*Batch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{6}
+}
+func (m *Batch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *Batch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_Batch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *Batch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Batch.Merge(m, src)
+}
+func (m *Batch) XXX_Size() int {
+	return m.Size()
+}
+func (m *Batch) XXX_DiscardUnknown() {
+	xxx_messageInfo_Batch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Batch proto.InternalMessageInfo
+
+func (m *Batch) GetBlocks() []*Block {
+	if m != nil {
+		return m.Blocks
+	}
+	return nil
+}
+
+// HeaderBatch is used to post signed headers to DA layer, separately from block data.
+type HeaderBatch struct {
+	Headers []*SignedHeader `protobuf:"bytes,1,rep,name=headers,proto3" json:"headers,omitempty"`
+}
+
+func (m *HeaderBatch) Reset()         { *m = HeaderBatch{} }
+func (m *HeaderBatch) String() string { return proto.CompactTextString(m) }
+func (*HeaderBatch) ProtoMessage()    {}
+func (*HeaderBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{7}
+}
+func (m *HeaderBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *HeaderBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_HeaderBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *HeaderBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeaderBatch.Merge(m, src)
+}
+func (m *HeaderBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *HeaderBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeaderBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeaderBatch proto.InternalMessageInfo
+
+func (m *HeaderBatch) GetHeaders() []*SignedHeader {
+	if m != nil {
+		return m.Headers
+	}
+	return nil
+}
+
+// DataBatch is used to post block data to DA layer, separately from signed headers.
+type DataBatch struct {
+	Data []*Data `protobuf:"bytes,1,rep,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *DataBatch) Reset()         { *m = DataBatch{} }
+func (m *DataBatch) String() string { return proto.CompactTextString(m) }
+func (*DataBatch) ProtoMessage()    {}
+func (*DataBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{8}
+}
+func (m *DataBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DataBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DataBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DataBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DataBatch.Merge(m, src)
+}
+func (m *DataBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *DataBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_DataBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DataBatch proto.InternalMessageInfo
+
+func (m *DataBatch) GetData() []*Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// BlockPart is a part of a blob (block or batch of blocks) that is too large to be posted as a single DA blob.
+// Field numbers are disjoint from the ones used in Block and Batch, so that all kinds of blobs can be told apart.
+type BlockPart struct {
+	// hash of the whole blob, shared by all the parts of the blob
+	BlobHash []byte `protobuf:"bytes,16,opt,name=blob_hash,json=blobHash,proto3" json:"blob_hash,omitempty"`
+	Index    uint32 `protobuf:"varint,17,opt,name=index,proto3" json:"index,omitempty"`
+	Total    uint32 `protobuf:"varint,18,opt,name=total,proto3" json:"total,omitempty"`
+	// hash of the previous part (empty for the first part)
+	PrevHash []byte `protobuf:"bytes,19,opt,name=prev_hash,json=prevHash,proto3" json:"prev_hash,omitempty"`
+	Data     []byte `protobuf:"bytes,20,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (m *BlockPart) Reset()         { *m = BlockPart{} }
+func (m *BlockPart) String() string { return proto.CompactTextString(m) }
+func (*BlockPart) ProtoMessage()    {}
+func (*BlockPart) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{9}
+}
+func (m *BlockPart) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockPart) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockPart.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockPart) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockPart.Merge(m, src)
+}
+func (m *BlockPart) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockPart) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockPart.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockPart proto.InternalMessageInfo
+
+func (m *BlockPart) GetBlobHash() []byte {
+	if m != nil {
+		return m.BlobHash
+	}
+	return nil
+}
+
+func (m *BlockPart) GetIndex() uint32 {
+	if m != nil {
+		return m.Index
+	}
+	return 0
+}
+
+func (m *BlockPart) GetTotal() uint32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *BlockPart) GetPrevHash() []byte {
+	if m != nil {
+		return m.PrevHash
+	}
+	return nil
+}
+
+func (m *BlockPart) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+// TxBatch is used to post transactions directly to DA layer, in based sequencing mode.
+// Field number is disjoint from the ones used in other blobs, so that tx batches can be told apart.
+type TxBatch struct {
+	Txs [][]byte `protobuf:"bytes,32,rep,name=txs,proto3" json:"txs,omitempty"`
+}
+
+func (m *TxBatch) Reset()         { *m = TxBatch{} }
+func (m *TxBatch) String() string { return proto.CompactTextString(m) }
+func (*TxBatch) ProtoMessage()    {}
+func (*TxBatch) Descriptor() ([]byte, []int) {
+	return fileDescriptor_ed489fb7f4d78b3f, []int{10}
+}
+func (m *TxBatch) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *TxBatch) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_TxBatch.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *TxBatch) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TxBatch.Merge(m, src)
+}
+func (m *TxBatch) XXX_Size() int {
+	return m.Size()
+}
+func (m *TxBatch) XXX_DiscardUnknown() {
+	xxx_messageInfo_TxBatch.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TxBatch proto.InternalMessageInfo
+
+func (m *TxBatch) GetTxs() [][]byte {
+	if m != nil {
+		return m.Txs
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*Version)(nil), "rollkit.Version")
 	proto.RegisterType((*Header)(nil), "rollkit.Header")
@@ -453,51 +714,64 @@ func init() {
 	proto.RegisterType((*SignedHeader)(nil), "rollkit.SignedHeader")
 	proto.RegisterType((*Data)(nil), "rollkit.Data")
 	proto.RegisterType((*Block)(nil), "rollkit.Block")
+	proto.RegisterType((*Batch)(nil), "rollkit.Batch")
+	proto.RegisterType((*HeaderBatch)(nil), "rollkit.HeaderBatch")
+	proto.RegisterType((*DataBatch)(nil), "rollkit.DataBatch")
+	proto.RegisterType((*BlockPart)(nil), "rollkit.BlockPart")
+	proto.RegisterType((*TxBatch)(nil), "rollkit.TxBatch")
 }
 
 func init() { proto.RegisterFile("rollkit/rollkit.proto", fileDescriptor_ed489fb7f4d78b3f) }
 
 var fileDescriptor_ed489fb7f4d78b3f = []byte{
-	// 609 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x5c, 0x94, 0x4f, 0x6f, 0xd3, 0x3e,
-	0x18, 0xc7, 0x97, 0xb5, 0x6b, 0xbb, 0xa7, 0xdd, 0xd6, 0x9f, 0xf5, 0xdb, 0x94, 0x6d, 0x52, 0x54,
-	0x2a, 0x21, 0xc2, 0x90, 0x5a, 0x31, 0x84, 0x84, 0x38, 0x20, 0x31, 0x98, 0x34, 0xae, 0x9e, 0xb4,
-	0x03, 0x97, 0xca, 0x4d, 0x4c, 0x62, 0xad, 0x89, 0x23, 0xdb, 0x9d, 0xe0, 0x25, 0x70, 0xe3, 0x25,
-	0xf0, 0x12, 0x78, 0x19, 0x1c, 0x77, 0xe4, 0x88, 0xb6, 0x37, 0x82, 0xfc, 0xd8, 0xc9, 0x02, 0x97,
-	0xd6, 0xfe, 0x7e, 0x3f, 0xfe, 0xf7, 0xfc, 0x09, 0xec, 0x2b, 0xb9, 0x5a, 0x5d, 0x0b, 0x33, 0xf7,
-	0xff, 0xb3, 0x4a, 0x49, 0x23, 0x49, 0xdf, 0x4f, 0x8f, 0x8e, 0x0d, 0x2f, 0x53, 0xae, 0x0a, 0x51,
-	0x9a, 0x39, 0x5b, 0x26, 0x62, 0x6e, 0xbe, 0x54, 0x5c, 0x3b, 0xea, 0x68, 0xd2, 0x32, 0x51, 0x9f,
-	0xdf, 0xb0, 0x95, 0x48, 0x99, 0x91, 0xca, 0x11, 0xd3, 0xe7, 0xd0, 0xbf, 0xe2, 0x4a, 0x0b, 0x59,
-	0x92, 0xff, 0x61, 0x6b, 0xb9, 0x92, 0xc9, 0x75, 0x18, 0x4c, 0x82, 0xb8, 0x4b, 0xdd, 0x84, 0x8c,
-	0xa1, 0xc3, 0xaa, 0x2a, 0xdc, 0x44, 0xcd, 0x0e, 0xa7, 0x3f, 0x3a, 0xd0, 0xbb, 0xe0, 0x2c, 0xe5,
-	0x8a, 0x9c, 0x40, 0xff, 0xc6, 0xad, 0xc6, 0x45, 0xc3, 0xd3, 0xf1, 0xac, 0xbe, 0xa6, 0xdf, 0x95,
-	0xd6, 0x00, 0x39, 0x80, 0x5e, 0xce, 0x45, 0x96, 0x1b, 0xbf, 0x97, 0x9f, 0x11, 0x02, 0x5d, 0x23,
-	0x0a, 0x1e, 0x76, 0x50, 0xc5, 0x31, 0x89, 0x61, 0xbc, 0x62, 0xda, 0x2c, 0x72, 0x3c, 0x66, 0x91,
-	0x33, 0x9d, 0x87, 0xdd, 0x49, 0x10, 0x8f, 0xe8, 0xae, 0xd5, 0xdd, 0xe9, 0x17, 0x4c, 0xe7, 0x0d,
-	0x99, 0xc8, 0xa2, 0x10, 0xc6, 0x91, 0x5b, 0x0f, 0xe4, 0x3b, 0x94, 0x91, 0x3c, 0x86, 0xed, 0x94,
-	0x19, 0xe6, 0x90, 0x1e, 0x22, 0x03, 0x2b, 0xa0, 0xf9, 0x18, 0x76, 0x13, 0x59, 0x6a, 0x5e, 0xea,
-	0xb5, 0x76, 0x44, 0x1f, 0x89, 0x9d, 0x46, 0x45, 0xec, 0x10, 0x06, 0xac, 0xaa, 0x1c, 0x30, 0x40,
-	0xa0, 0xcf, 0xaa, 0x0a, 0xad, 0x13, 0xf8, 0x0f, 0x2f, 0xa2, 0xb8, 0x5e, 0xaf, 0x8c, 0xdf, 0x64,
-	0x1b, 0x99, 0x3d, 0x6b, 0x50, 0xa7, 0x23, 0xfb, 0x14, 0xc6, 0x95, 0x92, 0x95, 0xd4, 0x5c, 0x2d,
-	0x58, 0x9a, 0x2a, 0xae, 0x75, 0x08, 0x0e, 0xad, 0xf5, 0xb7, 0x4e, 0xb6, 0x28, 0xcb, 0x32, 0xc5,
-	0x33, 0x9b, 0x33, 0xbf, 0xeb, 0xd0, 0xa1, 0x2d, 0xbd, 0xbe, 0x5c, 0x92, 0x33, 0x51, 0x2e, 0x44,
-	0x1a, 0x8e, 0x26, 0x41, 0xbc, 0x4d, 0xfb, 0x38, 0xff, 0x90, 0x4e, 0x63, 0xe8, 0xb9, 0x48, 0x90,
-	0x08, 0x40, 0x8b, 0xac, 0x64, 0x66, 0xad, 0xb8, 0x0e, 0x83, 0x49, 0x27, 0x1e, 0xd1, 0x96, 0x32,
-	0xfd, 0x1e, 0xc0, 0xe8, 0x52, 0x64, 0x25, 0x4f, 0x7d, 0x8a, 0x9f, 0xd8, 0xb4, 0xd9, 0x91, 0xcf,
-	0xf0, 0x5e, 0x93, 0x61, 0x07, 0x50, 0x6f, 0x5b, 0xd0, 0x25, 0x01, 0xf3, 0xdb, 0x06, 0xdd, 0xd1,
-	0xd4, 0xdb, 0xe4, 0x0d, 0x40, 0x53, 0x85, 0x1a, 0xd3, 0x3e, 0x3c, 0x8d, 0x66, 0x0f, 0x95, 0x3a,
-	0x73, 0x15, 0x7c, 0x55, 0x33, 0x97, 0xdc, 0xd0, 0xd6, 0x8a, 0xe9, 0xd7, 0x00, 0xba, 0xef, 0x99,
-	0x61, 0xb6, 0x34, 0xcd, 0xe7, 0xfa, 0x11, 0x76, 0x48, 0x5e, 0x41, 0x28, 0x4a, 0xc3, 0x55, 0xc1,
-	0x53, 0xc1, 0x0c, 0x5f, 0x68, 0x63, 0x7f, 0x95, 0x94, 0x46, 0x87, 0x9b, 0x88, 0x1d, 0xb4, 0xfd,
-	0x4b, 0x6b, 0x53, 0xeb, 0x92, 0x97, 0x30, 0xe0, 0x37, 0x22, 0xe5, 0x65, 0x62, 0x2b, 0xb1, 0x13,
-	0x0f, 0x4f, 0x0f, 0xdb, 0x57, 0xb2, 0x9d, 0x35, 0x3b, 0xf7, 0x00, 0x6d, 0xd0, 0xe9, 0x27, 0xd8,
-	0x3a, 0xc3, 0x36, 0x79, 0x0d, 0x3b, 0x1a, 0xc3, 0xb6, 0xf8, 0x2b, 0x5a, 0xfb, 0x4d, 0x10, 0xda,
-	0x41, 0xa5, 0x23, 0xdd, 0x0e, 0xf1, 0x23, 0xe8, 0xda, 0x42, 0xf4, 0x71, 0xdb, 0x69, 0x96, 0xd8,
-	0x47, 0x52, 0xb4, 0xce, 0xce, 0x7f, 0xde, 0x45, 0xc1, 0xed, 0x5d, 0x14, 0xfc, 0xbe, 0x8b, 0x82,
-	0x6f, 0xf7, 0xd1, 0xc6, 0xed, 0x7d, 0xb4, 0xf1, 0xeb, 0x3e, 0xda, 0xf8, 0xf8, 0x2c, 0x13, 0x26,
-	0x5f, 0x2f, 0x67, 0x89, 0x2c, 0xe6, 0xff, 0x7c, 0x2a, 0x7c, 0xcb, 0x57, 0xcb, 0x5a, 0x58, 0xf6,
-	0xb0, 0xe9, 0x5f, 0xfc, 0x09, 0x00, 0x00, 0xff, 0xff, 0x47, 0x6a, 0xe8, 0x8b, 0x55, 0x04, 0x00,
-	0x00,
+	// 741 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x74, 0x94, 0xcf, 0x6e, 0xfb, 0x44,
+	0x10, 0xc7, 0xeb, 0x5f, 0xfe, 0x38, 0x99, 0x24, 0x6d, 0x6a, 0xda, 0xca, 0x6d, 0x25, 0x2b, 0x58,
+	0x02, 0x4c, 0x91, 0x12, 0x11, 0x84, 0x84, 0x38, 0x54, 0xa2, 0x50, 0xa9, 0xdc, 0x90, 0x8b, 0x7a,
+	0xe0, 0x12, 0x6d, 0xec, 0x25, 0x5e, 0xd5, 0xb1, 0xad, 0xdd, 0x4d, 0x54, 0x1e, 0x80, 0x03, 0x37,
+	0x1e, 0x81, 0x47, 0xe0, 0x31, 0x38, 0xf6, 0xc8, 0x11, 0xb5, 0x2f, 0x82, 0x76, 0x66, 0xed, 0x1a,
+	0x04, 0x97, 0x64, 0xf7, 0x3b, 0x9f, 0x99, 0x1d, 0xcf, 0xcc, 0x2e, 0x9c, 0xca, 0x32, 0xcf, 0x1f,
+	0x85, 0x5e, 0xd8, 0xff, 0x79, 0x25, 0x4b, 0x5d, 0x7a, 0xae, 0xdd, 0x5e, 0x5c, 0x6a, 0x5e, 0xa4,
+	0x5c, 0x6e, 0x45, 0xa1, 0x17, 0x6c, 0x9d, 0x88, 0x85, 0xfe, 0xa9, 0xe2, 0x8a, 0xa8, 0x8b, 0x59,
+	0xcb, 0x88, 0xfa, 0x62, 0xcf, 0x72, 0x91, 0x32, 0x5d, 0x4a, 0x22, 0xc2, 0x4f, 0xc1, 0x7d, 0xe0,
+	0x52, 0x89, 0xb2, 0xf0, 0x4e, 0xa0, 0xb7, 0xce, 0xcb, 0xe4, 0xd1, 0x77, 0x66, 0x4e, 0xd4, 0x8d,
+	0x69, 0xe3, 0x4d, 0xa1, 0xc3, 0xaa, 0xca, 0x7f, 0x87, 0x9a, 0x59, 0x86, 0xbf, 0x77, 0xa0, 0x7f,
+	0xc7, 0x59, 0xca, 0xa5, 0x77, 0x05, 0xee, 0x9e, 0xbc, 0xd1, 0x69, 0xb4, 0x9c, 0xce, 0xeb, 0x34,
+	0x6d, 0xd4, 0xb8, 0x06, 0xbc, 0x33, 0xe8, 0x67, 0x5c, 0x6c, 0x32, 0x6d, 0x63, 0xd9, 0x9d, 0xe7,
+	0x41, 0x57, 0x8b, 0x2d, 0xf7, 0x3b, 0xa8, 0xe2, 0xda, 0x8b, 0x60, 0x9a, 0x33, 0xa5, 0x57, 0x19,
+	0x1e, 0xb3, 0xca, 0x98, 0xca, 0xfc, 0xee, 0xcc, 0x89, 0xc6, 0xf1, 0xa1, 0xd1, 0xe9, 0xf4, 0x3b,
+	0xa6, 0xb2, 0x86, 0x4c, 0xca, 0xed, 0x56, 0x68, 0x22, 0x7b, 0x6f, 0xe4, 0xd7, 0x28, 0x23, 0x79,
+	0x09, 0xc3, 0x94, 0x69, 0x46, 0x48, 0x1f, 0x91, 0x81, 0x11, 0xd0, 0xf8, 0x01, 0x1c, 0x26, 0x65,
+	0xa1, 0x78, 0xa1, 0x76, 0x8a, 0x08, 0x17, 0x89, 0x49, 0xa3, 0x22, 0x76, 0x0e, 0x03, 0x56, 0x55,
+	0x04, 0x0c, 0x10, 0x70, 0x59, 0x55, 0xa1, 0xe9, 0x0a, 0x8e, 0x31, 0x11, 0xc9, 0xd5, 0x2e, 0xd7,
+	0x36, 0xc8, 0x10, 0x99, 0x23, 0x63, 0x88, 0x49, 0x47, 0xf6, 0x63, 0x98, 0x56, 0xb2, 0xac, 0x4a,
+	0xc5, 0xe5, 0x8a, 0xa5, 0xa9, 0xe4, 0x4a, 0xf9, 0x40, 0x68, 0xad, 0x7f, 0x45, 0xb2, 0x41, 0xd9,
+	0x66, 0x23, 0xf9, 0xc6, 0xf4, 0xcc, 0x46, 0x1d, 0x11, 0xda, 0xd2, 0xeb, 0xe4, 0x92, 0x8c, 0x89,
+	0x62, 0x25, 0x52, 0x7f, 0x3c, 0x73, 0xa2, 0x61, 0xec, 0xe2, 0xfe, 0xdb, 0x34, 0x8c, 0xa0, 0x4f,
+	0x95, 0xf0, 0x02, 0x00, 0x25, 0x36, 0x05, 0xd3, 0x3b, 0xc9, 0x95, 0xef, 0xcc, 0x3a, 0xd1, 0x38,
+	0x6e, 0x29, 0xe1, 0x6f, 0x0e, 0x8c, 0xef, 0xc5, 0xa6, 0xe0, 0xa9, 0x6d, 0xf1, 0x47, 0xa6, 0x6d,
+	0x66, 0x65, 0x3b, 0x7c, 0xd4, 0x74, 0x98, 0x80, 0xd8, 0x9a, 0x0d, 0x48, 0x4d, 0xc0, 0xfe, 0xb6,
+	0x41, 0x3a, 0x3a, 0xb6, 0x66, 0xef, 0x1a, 0xa0, 0x99, 0x42, 0x85, 0x6d, 0x1f, 0x2d, 0x83, 0xf9,
+	0xdb, 0xa4, 0xce, 0x69, 0x82, 0x1f, 0x6a, 0xe6, 0x9e, 0xeb, 0xb8, 0xe5, 0x11, 0xfe, 0xe2, 0x40,
+	0xf7, 0x1b, 0xa6, 0x99, 0x19, 0x4d, 0xfd, 0x54, 0x7f, 0x84, 0x59, 0x7a, 0x5f, 0x80, 0x2f, 0x0a,
+	0xcd, 0xe5, 0x96, 0xa7, 0x82, 0x69, 0xbe, 0x52, 0xda, 0xfc, 0xca, 0xb2, 0xd4, 0xca, 0x7f, 0x87,
+	0xd8, 0x59, 0xdb, 0x7e, 0x6f, 0xcc, 0xb1, 0xb1, 0x7a, 0x9f, 0xc3, 0x80, 0xef, 0x45, 0xca, 0x8b,
+	0xc4, 0x4c, 0x62, 0x27, 0x1a, 0x2d, 0xcf, 0xdb, 0x29, 0x99, 0x9b, 0x35, 0xbf, 0xb5, 0x40, 0xdc,
+	0xa0, 0xe1, 0x8f, 0xd0, 0xbb, 0xc1, 0x6b, 0xf2, 0x25, 0x4c, 0x14, 0x96, 0x6d, 0xf5, 0x8f, 0x6a,
+	0x9d, 0x36, 0x45, 0x68, 0x17, 0x35, 0x1e, 0xab, 0x76, 0x89, 0xdf, 0x87, 0xae, 0x19, 0x44, 0x5b,
+	0xb7, 0x49, 0xe3, 0x62, 0x3e, 0x32, 0x46, 0x53, 0xb8, 0x80, 0xde, 0x0d, 0xd3, 0x49, 0xe6, 0x7d,
+	0x08, 0x7d, 0xbc, 0x97, 0xf4, 0xd9, 0xa3, 0xe5, 0x61, 0x43, 0x63, 0x1e, 0xb1, 0xb5, 0x86, 0xd7,
+	0x30, 0xa2, 0xe8, 0xe4, 0xb6, 0x00, 0x97, 0xf2, 0xaa, 0xfd, 0xfe, 0x27, 0xb1, 0x9a, 0x0a, 0xe7,
+	0x30, 0x34, 0xc7, 0x93, 0x77, 0x9d, 0x20, 0xb9, 0xfe, 0x67, 0x82, 0x3f, 0x3b, 0x30, 0xc4, 0x0c,
+	0xbe, 0x63, 0x52, 0x9b, 0xbb, 0xb6, 0xce, 0xcb, 0x35, 0x8d, 0xeb, 0x94, 0xee, 0x9a, 0x11, 0x70,
+	0x4e, 0x4f, 0xa0, 0x27, 0x8a, 0x94, 0x3f, 0xf9, 0xc7, 0x33, 0x27, 0x9a, 0xc4, 0xb4, 0x31, 0xaa,
+	0x2e, 0x35, 0xcb, 0x7d, 0x8f, 0x54, 0xdc, 0x98, 0x40, 0x95, 0xe4, 0x7b, 0x0a, 0xf4, 0x1e, 0x05,
+	0x32, 0x02, 0x06, 0xf2, 0x6c, 0x5a, 0x27, 0xa8, 0x53, 0x1e, 0x97, 0xe0, 0x7e, 0xff, 0x44, 0x59,
+	0xdb, 0xf1, 0x98, 0x35, 0xe3, 0x71, 0x73, 0xfb, 0xc7, 0x4b, 0xe0, 0x3c, 0xbf, 0x04, 0xce, 0x5f,
+	0x2f, 0x81, 0xf3, 0xeb, 0x6b, 0x70, 0xf0, 0xfc, 0x1a, 0x1c, 0xfc, 0xf9, 0x1a, 0x1c, 0xfc, 0xf0,
+	0xc9, 0x46, 0xe8, 0x6c, 0xb7, 0x9e, 0x27, 0xe5, 0x76, 0xf1, 0xaf, 0x07, 0xd7, 0x3e, 0x9c, 0xd5,
+	0xba, 0x16, 0xd6, 0x7d, 0x7c, 0x3a, 0x3f, 0xfb, 0x3b, 0x00, 0x00, 0xff, 0xff, 0x96, 0x08, 0x09,
+	0x9f, 0x9b, 0x05, 0x00, 0x00,
 }
 
 func (m *Version) Marshal() (dAtA []byte, err error) {
@@ -834,65 +1108,274 @@ func (m *Block) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
-func encodeVarintRollkit(dAtA []byte, offset int, v uint64) int {
-	offset -= sovRollkit(v)
-	base := offset
-	for v >= 1<<7 {
-		dAtA[offset] = uint8(v&0x7f | 0x80)
-		v >>= 7
-		offset++
+func (m *Batch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	dAtA[offset] = uint8(v)
-	return base
+	return dAtA[:n], nil
 }
-func (m *Version) Size() (n int) {
-	if m == nil {
-		return 0
-	}
+
+func (m *Batch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *Batch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Block != 0 {
-		n += 1 + sovRollkit(uint64(m.Block))
-	}
-	if m.App != 0 {
-		n += 1 + sovRollkit(uint64(m.App))
+	if len(m.Blocks) > 0 {
+		for iNdEx := len(m.Blocks) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Blocks[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRollkit(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	return n
+	return len(dAtA) - i, nil
 }
 
-func (m *Header) Size() (n int) {
-	if m == nil {
-		return 0
+func (m *HeaderBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
+	return dAtA[:n], nil
+}
+
+func (m *HeaderBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *HeaderBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
 	var l int
 	_ = l
-	if m.Version != nil {
-		l = m.Version.Size()
-		n += 1 + l + sovRollkit(uint64(l))
-	}
-	if m.Height != 0 {
-		n += 1 + sovRollkit(uint64(m.Height))
-	}
-	if m.Time != 0 {
-		n += 1 + sovRollkit(uint64(m.Time))
-	}
-	l = len(m.LastHeaderHash)
-	if l > 0 {
-		n += 1 + l + sovRollkit(uint64(l))
-	}
-	l = len(m.LastCommitHash)
-	if l > 0 {
-		n += 1 + l + sovRollkit(uint64(l))
-	}
-	l = len(m.DataHash)
-	if l > 0 {
-		n += 1 + l + sovRollkit(uint64(l))
+	if len(m.Headers) > 0 {
+		for iNdEx := len(m.Headers) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Headers[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRollkit(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
 	}
-	l = len(m.ConsensusHash)
-	if l > 0 {
-		n += 1 + l + sovRollkit(uint64(l))
+	return len(dAtA) - i, nil
+}
+
+func (m *DataBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
 	}
-	l = len(m.AppHash)
+	return dAtA[:n], nil
+}
+
+func (m *DataBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DataBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for iNdEx := len(m.Data) - 1; iNdEx >= 0; iNdEx-- {
+			{
+				size, err := m.Data[iNdEx].MarshalToSizedBuffer(dAtA[:i])
+				if err != nil {
+					return 0, err
+				}
+				i -= size
+				i = encodeVarintRollkit(dAtA, i, uint64(size))
+			}
+			i--
+			dAtA[i] = 0xa
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *BlockPart) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockPart) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockPart) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		i -= len(m.Data)
+		copy(dAtA[i:], m.Data)
+		i = encodeVarintRollkit(dAtA, i, uint64(len(m.Data)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0xa2
+	}
+	if len(m.PrevHash) > 0 {
+		i -= len(m.PrevHash)
+		copy(dAtA[i:], m.PrevHash)
+		i = encodeVarintRollkit(dAtA, i, uint64(len(m.PrevHash)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x9a
+	}
+	if m.Total != 0 {
+		i = encodeVarintRollkit(dAtA, i, uint64(m.Total))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x90
+	}
+	if m.Index != 0 {
+		i = encodeVarintRollkit(dAtA, i, uint64(m.Index))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x88
+	}
+	if len(m.BlobHash) > 0 {
+		i -= len(m.BlobHash)
+		copy(dAtA[i:], m.BlobHash)
+		i = encodeVarintRollkit(dAtA, i, uint64(len(m.BlobHash)))
+		i--
+		dAtA[i] = 0x1
+		i--
+		dAtA[i] = 0x82
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *TxBatch) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *TxBatch) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *TxBatch) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for iNdEx := len(m.Txs) - 1; iNdEx >= 0; iNdEx-- {
+			i -= len(m.Txs[iNdEx])
+			copy(dAtA[i:], m.Txs[iNdEx])
+			i = encodeVarintRollkit(dAtA, i, uint64(len(m.Txs[iNdEx])))
+			i--
+			dAtA[i] = 0x2
+			i--
+			dAtA[i] = 0x82
+		}
+	}
+	return len(dAtA) - i, nil
+}
+
+func encodeVarintRollkit(dAtA []byte, offset int, v uint64) int {
+	offset -= sovRollkit(v)
+	base := offset
+	for v >= 1<<7 {
+		dAtA[offset] = uint8(v&0x7f | 0x80)
+		v >>= 7
+		offset++
+	}
+	dAtA[offset] = uint8(v)
+	return base
+}
+func (m *Version) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Block != 0 {
+		n += 1 + sovRollkit(uint64(m.Block))
+	}
+	if m.App != 0 {
+		n += 1 + sovRollkit(uint64(m.App))
+	}
+	return n
+}
+
+func (m *Header) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Version != nil {
+		l = m.Version.Size()
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	if m.Height != 0 {
+		n += 1 + sovRollkit(uint64(m.Height))
+	}
+	if m.Time != 0 {
+		n += 1 + sovRollkit(uint64(m.Time))
+	}
+	l = len(m.LastHeaderHash)
+	if l > 0 {
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	l = len(m.LastCommitHash)
+	if l > 0 {
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	l = len(m.DataHash)
+	if l > 0 {
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	l = len(m.ConsensusHash)
+	if l > 0 {
+		n += 1 + l + sovRollkit(uint64(l))
+	}
+	l = len(m.AppHash)
 	if l > 0 {
 		n += 1 + l + sovRollkit(uint64(l))
 	}
@@ -995,6 +1478,93 @@ func (m *Block) Size() (n int) {
 	return n
 }
 
+func (m *Batch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Blocks) > 0 {
+		for _, e := range m.Blocks {
+			l = e.Size()
+			n += 1 + l + sovRollkit(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *HeaderBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Headers) > 0 {
+		for _, e := range m.Headers {
+			l = e.Size()
+			n += 1 + l + sovRollkit(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *DataBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Data) > 0 {
+		for _, e := range m.Data {
+			l = e.Size()
+			n += 1 + l + sovRollkit(uint64(l))
+		}
+	}
+	return n
+}
+
+func (m *BlockPart) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	l = len(m.BlobHash)
+	if l > 0 {
+		n += 2 + l + sovRollkit(uint64(l))
+	}
+	if m.Index != 0 {
+		n += 2 + sovRollkit(uint64(m.Index))
+	}
+	if m.Total != 0 {
+		n += 2 + sovRollkit(uint64(m.Total))
+	}
+	l = len(m.PrevHash)
+	if l > 0 {
+		n += 2 + l + sovRollkit(uint64(l))
+	}
+	l = len(m.Data)
+	if l > 0 {
+		n += 2 + l + sovRollkit(uint64(l))
+	}
+	return n
+}
+
+func (m *TxBatch) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if len(m.Txs) > 0 {
+		for _, b := range m.Txs {
+			l = len(b)
+			n += 2 + l + sovRollkit(uint64(l))
+		}
+	}
+	return n
+}
+
 func sovRollkit(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -1432,9 +2002,513 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 			iNdEx = postIndex
 		case 11:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field AggregatorsHash", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatorsHash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatorsHash = append(m.AggregatorsHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatorsHash == nil {
+				m.AggregatorsHash = []byte{}
+			}
+			iNdEx = postIndex
+		case 12:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ChainId = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Commit) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
+			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *SignedHeader) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: SignedHeader: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: SignedHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Header == nil {
+				m.Header = &Header{}
+			}
+			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Commit == nil {
+				m.Commit = &Commit{}
+			}
+			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.Validators == nil {
+				m.Validators = &types.ValidatorSet{}
+			}
+			if err := m.Validators.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Data) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Data: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Data: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IntermediateStateRoots", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.IntermediateStateRoots = append(m.IntermediateStateRoots, make([]byte, postIndex-iNdEx))
+			copy(m.IntermediateStateRoots[len(m.IntermediateStateRoots)-1], dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Evidence = append(m.Evidence, &types1.Evidence{})
+			if err := m.Evidence[len(m.Evidence)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthRollkit
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *Block) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: Block: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: Block: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field SignedHeader", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRollkit
@@ -1444,31 +2518,33 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRollkit
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRollkit
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.AggregatorsHash = append(m.AggregatorsHash[:0], dAtA[iNdEx:postIndex]...)
-			if m.AggregatorsHash == nil {
-				m.AggregatorsHash = []byte{}
+			if m.SignedHeader == nil {
+				m.SignedHeader = &SignedHeader{}
+			}
+			if err := m.SignedHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
 			}
 			iNdEx = postIndex
-		case 12:
+		case 2:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field ChainId", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var stringLen uint64
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRollkit
@@ -1478,23 +2554,27 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				stringLen |= uint64(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			intStringLen := int(stringLen)
-			if intStringLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRollkit
 			}
-			postIndex := iNdEx + intStringLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRollkit
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.ChainId = string(dAtA[iNdEx:postIndex])
+			if m.Data == nil {
+				m.Data = &Data{}
+			}
+			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1517,7 +2597,7 @@ func (m *Header) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Commit) Unmarshal(dAtA []byte) error {
+func (m *Batch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1540,17 +2620,17 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Commit: wiretype end group for non-group")
+			return fmt.Errorf("proto: Batch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Commit: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: Batch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Signatures", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Blocks", wireType)
 			}
-			var byteLen int
+			var msglen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRollkit
@@ -1560,23 +2640,25 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				byteLen |= int(b&0x7F) << shift
+				msglen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if byteLen < 0 {
+			if msglen < 0 {
 				return ErrInvalidLengthRollkit
 			}
-			postIndex := iNdEx + byteLen
+			postIndex := iNdEx + msglen
 			if postIndex < 0 {
 				return ErrInvalidLengthRollkit
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Signatures = append(m.Signatures, make([]byte, postIndex-iNdEx))
-			copy(m.Signatures[len(m.Signatures)-1], dAtA[iNdEx:postIndex])
+			m.Blocks = append(m.Blocks, &Block{})
+			if err := m.Blocks[len(m.Blocks)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -1599,7 +2681,7 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *SignedHeader) Unmarshal(dAtA []byte) error {
+func (m *HeaderBatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1622,15 +2704,15 @@ func (m *SignedHeader) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: SignedHeader: wiretype end group for non-group")
+			return fmt.Errorf("proto: HeaderBatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: SignedHeader: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: HeaderBatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
 		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Header", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Headers", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1657,52 +2739,64 @@ func (m *SignedHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Header == nil {
-				m.Header = &Header{}
-			}
-			if err := m.Header.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Headers = append(m.Headers, &SignedHeader{})
+			if err := m.Headers[len(m.Headers)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
-		case 2:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Commit", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRollkit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRollkit
+		default:
+			iNdEx = preIndex
+			skippy, err := skipRollkit(dAtA[iNdEx:])
+			if err != nil {
+				return err
 			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
 				return ErrInvalidLengthRollkit
 			}
-			if postIndex > l {
+			if (iNdEx + skippy) > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Commit == nil {
-				m.Commit = &Commit{}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DataBatch) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowRollkit
 			}
-			if err := m.Commit.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
 			}
-			iNdEx = postIndex
-		case 3:
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DataBatch: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DataBatch: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Validators", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
 			var msglen int
 			for shift := uint(0); ; shift += 7 {
@@ -1729,10 +2823,8 @@ func (m *SignedHeader) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Validators == nil {
-				m.Validators = &types.ValidatorSet{}
-			}
-			if err := m.Validators.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+			m.Data = append(m.Data, &Data{})
+			if err := m.Data[len(m.Data)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
 				return err
 			}
 			iNdEx = postIndex
@@ -1757,7 +2849,7 @@ func (m *SignedHeader) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Data) Unmarshal(dAtA []byte) error {
+func (m *BlockPart) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1780,15 +2872,15 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Data: wiretype end group for non-group")
+			return fmt.Errorf("proto: BlockPart: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Data: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: BlockPart: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
+		case 16:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field BlobHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1815,12 +2907,52 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
-			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
+			m.BlobHash = append(m.BlobHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.BlobHash == nil {
+				m.BlobHash = []byte{}
+			}
 			iNdEx = postIndex
-		case 2:
+		case 17:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Index", wireType)
+			}
+			m.Index = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Index |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 18:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Total", wireType)
+			}
+			m.Total = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowRollkit
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Total |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 19:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field IntermediateStateRoots", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field PrevHash", wireType)
 			}
 			var byteLen int
 			for shift := uint(0); ; shift += 7 {
@@ -1847,14 +2979,16 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.IntermediateStateRoots = append(m.IntermediateStateRoots, make([]byte, postIndex-iNdEx))
-			copy(m.IntermediateStateRoots[len(m.IntermediateStateRoots)-1], dAtA[iNdEx:postIndex])
+			m.PrevHash = append(m.PrevHash[:0], dAtA[iNdEx:postIndex]...)
+			if m.PrevHash == nil {
+				m.PrevHash = []byte{}
+			}
 			iNdEx = postIndex
-		case 3:
+		case 20:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Evidence", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRollkit
@@ -1864,24 +2998,24 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRollkit
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRollkit
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			m.Evidence = append(m.Evidence, &types1.Evidence{})
-			if err := m.Evidence[len(m.Evidence)-1].Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
+			m.Data = append(m.Data[:0], dAtA[iNdEx:postIndex]...)
+			if m.Data == nil {
+				m.Data = []byte{}
 			}
 			iNdEx = postIndex
 		default:
@@ -1905,7 +3039,7 @@ func (m *Data) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
-func (m *Block) Unmarshal(dAtA []byte) error {
+func (m *TxBatch) Unmarshal(dAtA []byte) error {
 	l := len(dAtA)
 	iNdEx := 0
 	for iNdEx < l {
@@ -1928,53 +3062,17 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 		fieldNum := int32(wire >> 3)
 		wireType := int(wire & 0x7)
 		if wireType == 4 {
-			return fmt.Errorf("proto: Block: wiretype end group for non-group")
+			return fmt.Errorf("proto: TxBatch: wiretype end group for non-group")
 		}
 		if fieldNum <= 0 {
-			return fmt.Errorf("proto: Block: illegal tag %d (wire type %d)", fieldNum, wire)
+			return fmt.Errorf("proto: TxBatch: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
-		case 1:
-			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field SignedHeader", wireType)
-			}
-			var msglen int
-			for shift := uint(0); ; shift += 7 {
-				if shift >= 64 {
-					return ErrIntOverflowRollkit
-				}
-				if iNdEx >= l {
-					return io.ErrUnexpectedEOF
-				}
-				b := dAtA[iNdEx]
-				iNdEx++
-				msglen |= int(b&0x7F) << shift
-				if b < 0x80 {
-					break
-				}
-			}
-			if msglen < 0 {
-				return ErrInvalidLengthRollkit
-			}
-			postIndex := iNdEx + msglen
-			if postIndex < 0 {
-				return ErrInvalidLengthRollkit
-			}
-			if postIndex > l {
-				return io.ErrUnexpectedEOF
-			}
-			if m.SignedHeader == nil {
-				m.SignedHeader = &SignedHeader{}
-			}
-			if err := m.SignedHeader.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
-			iNdEx = postIndex
-		case 2:
+		case 32:
 			if wireType != 2 {
-				return fmt.Errorf("proto: wrong wireType = %d for field Data", wireType)
+				return fmt.Errorf("proto: wrong wireType = %d for field Txs", wireType)
 			}
-			var msglen int
+			var byteLen int
 			for shift := uint(0); ; shift += 7 {
 				if shift >= 64 {
 					return ErrIntOverflowRollkit
@@ -1984,27 +3082,23 @@ func (m *Block) Unmarshal(dAtA []byte) error {
 				}
 				b := dAtA[iNdEx]
 				iNdEx++
-				msglen |= int(b&0x7F) << shift
+				byteLen |= int(b&0x7F) << shift
 				if b < 0x80 {
 					break
 				}
 			}
-			if msglen < 0 {
+			if byteLen < 0 {
 				return ErrInvalidLengthRollkit
 			}
-			postIndex := iNdEx + msglen
+			postIndex := iNdEx + byteLen
 			if postIndex < 0 {
 				return ErrInvalidLengthRollkit
 			}
 			if postIndex > l {
 				return io.ErrUnexpectedEOF
 			}
-			if m.Data == nil {
-				m.Data = &Data{}
-			}
-			if err := m.Data.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
-				return err
-			}
+			m.Txs = append(m.Txs, make([]byte, postIndex-iNdEx))
+			copy(m.Txs[len(m.Txs)-1], dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
@@ -186,51 +186,201 @@ func (m *State) GetAppHash() []byte {
 	return nil
 }
 
+// BlockStatus describes finality of a block at given height.
+type BlockStatus struct {
+	Height uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	// finality: 1 - produced, 2 - soft-confirmed, 3 - DA-included
+	Finality uint32 `protobuf:"varint,2,opt,name=finality,proto3" json:"finality,omitempty"`
+	// DA height at which the block was included (only for DA-included blocks)
+	DaHeight uint64 `protobuf:"varint,3,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+}
+
+func (m *BlockStatus) Reset()         { *m = BlockStatus{} }
+func (m *BlockStatus) String() string { return proto.CompactTextString(m) }
+func (*BlockStatus) ProtoMessage()    {}
+func (*BlockStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c88f9697fdbf8e5, []int{1}
+}
+func (m *BlockStatus) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *BlockStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_BlockStatus.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *BlockStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BlockStatus.Merge(m, src)
+}
+func (m *BlockStatus) XXX_Size() int {
+	return m.Size()
+}
+func (m *BlockStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_BlockStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BlockStatus proto.InternalMessageInfo
+
+func (m *BlockStatus) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *BlockStatus) GetFinality() uint32 {
+	if m != nil {
+		return m.Finality
+	}
+	return 0
+}
+
+func (m *BlockStatus) GetDaHeight() uint64 {
+	if m != nil {
+		return m.DaHeight
+	}
+	return 0
+}
+
+// DAInclusion describes where a block is stored in DA layer.
+type DAInclusion struct {
+	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
+	Hash     []byte `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	DaHeight uint64 `protobuf:"varint,3,opt,name=da_height,json=daHeight,proto3" json:"da_height,omitempty"`
+	// DA layer specific identifier of data containing the block (e.g. blob commitment or tx hash)
+	Commitment []byte `protobuf:"bytes,4,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Namespace  []byte `protobuf:"bytes,5,opt,name=namespace,proto3" json:"namespace,omitempty"`
+}
+
+func (m *DAInclusion) Reset()         { *m = DAInclusion{} }
+func (m *DAInclusion) String() string { return proto.CompactTextString(m) }
+func (*DAInclusion) ProtoMessage()    {}
+func (*DAInclusion) Descriptor() ([]byte, []int) {
+	return fileDescriptor_6c88f9697fdbf8e5, []int{2}
+}
+func (m *DAInclusion) XXX_Unmarshal(b []byte) error {
+	return m.Unmarshal(b)
+}
+func (m *DAInclusion) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	if deterministic {
+		return xxx_messageInfo_DAInclusion.Marshal(b, m, deterministic)
+	} else {
+		b = b[:cap(b)]
+		n, err := m.MarshalToSizedBuffer(b)
+		if err != nil {
+			return nil, err
+		}
+		return b[:n], nil
+	}
+}
+func (m *DAInclusion) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DAInclusion.Merge(m, src)
+}
+func (m *DAInclusion) XXX_Size() int {
+	return m.Size()
+}
+func (m *DAInclusion) XXX_DiscardUnknown() {
+	xxx_messageInfo_DAInclusion.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DAInclusion proto.InternalMessageInfo
+
+func (m *DAInclusion) GetHeight() uint64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *DAInclusion) GetHash() []byte {
+	if m != nil {
+		return m.Hash
+	}
+	return nil
+}
+
+func (m *DAInclusion) GetDaHeight() uint64 {
+	if m != nil {
+		return m.DaHeight
+	}
+	return 0
+}
+
+func (m *DAInclusion) GetCommitment() []byte {
+	if m != nil {
+		return m.Commitment
+	}
+	return nil
+}
+
+func (m *DAInclusion) GetNamespace() []byte {
+	if m != nil {
+		return m.Namespace
+	}
+	return nil
+}
+
 func init() {
 	proto.RegisterType((*State)(nil), "rollkit.State")
+	proto.RegisterType((*BlockStatus)(nil), "rollkit.BlockStatus")
+	proto.RegisterType((*DAInclusion)(nil), "rollkit.DAInclusion")
 }
 
 func init() { proto.RegisterFile("rollkit/state.proto", fileDescriptor_6c88f9697fdbf8e5) }
 
 var fileDescriptor_6c88f9697fdbf8e5 = []byte{
-	// 586 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0x4f, 0x6f, 0xd3, 0x30,
-	0x18, 0xc6, 0x1b, 0xd6, 0xf5, 0x8f, 0xfb, 0x0f, 0x32, 0x0e, 0x59, 0x07, 0x69, 0x40, 0x20, 0x15,
-	0x90, 0x12, 0x89, 0xdd, 0x91, 0x48, 0x8b, 0x58, 0xa5, 0x09, 0xa1, 0x0c, 0xed, 0xc0, 0x25, 0x72,
-	0x12, 0x93, 0x58, 0x4b, 0xe3, 0x28, 0x76, 0x27, 0xf8, 0x16, 0xfb, 0x58, 0x3b, 0xee, 0xc8, 0xa9,
-	0xa0, 0xf6, 0x53, 0x70, 0x43, 0xb6, 0xe3, 0x36, 0x5b, 0x77, 0xd8, 0xa9, 0xcd, 0xf3, 0xfe, 0xde,
-	0xa7, 0xcf, 0xeb, 0xd7, 0x29, 0x38, 0x28, 0x48, 0x9a, 0x5e, 0x60, 0xe6, 0x50, 0x06, 0x19, 0xb2,
-	0xf3, 0x82, 0x30, 0xa2, 0x37, 0x4b, 0x71, 0xf8, 0x34, 0x26, 0x31, 0x11, 0x9a, 0xc3, 0xbf, 0xc9,
-	0xf2, 0x70, 0x14, 0x13, 0x12, 0xa7, 0xc8, 0x11, 0x4f, 0xc1, 0xe2, 0x87, 0xc3, 0xf0, 0x1c, 0x51,
-	0x06, 0xe7, 0x79, 0x09, 0x1c, 0x31, 0x94, 0x45, 0xa8, 0x98, 0xe3, 0x8c, 0x39, 0x30, 0x08, 0xb1,
-	0xc3, 0x7e, 0xe5, 0x88, 0x96, 0xc5, 0x67, 0x95, 0xa2, 0xd0, 0x6f, 0x55, 0xad, 0x9d, 0xea, 0x25,
-	0x4c, 0x71, 0x04, 0x19, 0x29, 0x4a, 0xe2, 0xf9, 0x0e, 0x91, 0xc3, 0x02, 0xce, 0xef, 0xb3, 0x17,
-	0x33, 0x55, 0xed, 0x5f, 0xfe, 0x6b, 0x80, 0xfd, 0x33, 0xae, 0xea, 0xc7, 0xa0, 0x79, 0x89, 0x0a,
-	0x8a, 0x49, 0x66, 0x68, 0x96, 0x36, 0xee, 0xbc, 0x3f, 0xb4, 0xb7, 0x9d, 0xb6, 0x3c, 0x8d, 0x73,
-	0x09, 0x78, 0x8a, 0xd4, 0x0f, 0x41, 0x2b, 0x4c, 0x20, 0xce, 0x7c, 0x1c, 0x19, 0x8f, 0x2c, 0x6d,
-	0xdc, 0xf6, 0x9a, 0xe2, 0x79, 0x16, 0xe9, 0xaf, 0x41, 0x1f, 0x67, 0x98, 0x61, 0x98, 0xfa, 0x09,
-	0xc2, 0x71, 0xc2, 0x8c, 0x3d, 0x4b, 0x1b, 0xef, 0x79, 0xbd, 0x52, 0x3d, 0x11, 0xa2, 0xfe, 0x16,
-	0x3c, 0x49, 0x21, 0x65, 0x7e, 0x90, 0x92, 0xf0, 0x42, 0x91, 0x75, 0x41, 0x0e, 0x78, 0xc1, 0xe5,
-	0x7a, 0xc9, 0x7a, 0xa0, 0x57, 0x61, 0x71, 0x64, 0xec, 0xef, 0x06, 0x95, 0xc3, 0x89, 0xae, 0xd9,
-	0xd4, 0x3d, 0xb8, 0x5e, 0x8e, 0x6a, 0xab, 0xe5, 0xa8, 0x73, 0xaa, 0xac, 0x66, 0x53, 0xaf, 0xb3,
-	0xf1, 0x9d, 0x45, 0xfa, 0x29, 0x18, 0x54, 0x3c, 0xf9, 0xe2, 0x8c, 0x86, 0x70, 0x1d, 0xda, 0x72,
-	0xab, 0xb6, 0xda, 0xaa, 0xfd, 0x4d, 0x6d, 0xd5, 0x6d, 0x71, 0xdb, 0xab, 0x3f, 0x23, 0xcd, 0xeb,
-	0x6d, 0xbc, 0x78, 0x55, 0x7f, 0x03, 0xda, 0x11, 0x54, 0x53, 0x34, 0x2d, 0x6d, 0x5c, 0x77, 0xbb,
-	0xab, 0xe5, 0xa8, 0x35, 0xfd, 0x28, 0x47, 0xf0, 0x5a, 0x11, 0x2c, 0x87, 0xf9, 0x0c, 0x06, 0x19,
-	0xfa, 0xc9, 0xfc, 0xcd, 0x3a, 0xa9, 0xd1, 0x12, 0x3f, 0x6c, 0xee, 0x8e, 0x73, 0xae, 0x98, 0x33,
-	0xc4, 0xbc, 0x3e, 0x6f, 0xdb, 0x28, 0x54, 0xff, 0x00, 0x40, 0xc5, 0xa3, 0xfd, 0x20, 0x8f, 0x4a,
-	0x07, 0x0f, 0x22, 0x4e, 0xa0, 0x62, 0x02, 0x1e, 0x16, 0x84, 0xb7, 0x55, 0x82, 0x4c, 0x80, 0x29,
-	0x8c, 0xe4, 0xf8, 0x15, 0x3f, 0x3f, 0x4c, 0x60, 0x16, 0xa3, 0xc8, 0xe8, 0x88, 0xbd, 0x1e, 0x71,
-	0x4a, 0x9e, 0xc2, 0xb6, 0x7b, 0x22, 0x11, 0xdd, 0x03, 0x8f, 0x43, 0x92, 0x51, 0x94, 0xd1, 0x05,
-	0xf5, 0xe5, 0x45, 0x36, 0xba, 0x22, 0xce, 0x8b, 0xdd, 0x38, 0x13, 0x45, 0x7e, 0x15, 0xa0, 0x5b,
-	0xe7, 0x7b, 0xf1, 0x06, 0xe1, 0x6d, 0x59, 0xff, 0x02, 0x5e, 0x55, 0x83, 0xdd, 0xf5, 0xdf, 0xc4,
-	0xeb, 0x89, 0x78, 0xd6, 0x36, 0xde, 0x1d, 0x7f, 0x95, 0x51, 0xdd, 0xd9, 0x02, 0xd1, 0x45, 0xca,
-	0xa8, 0x9f, 0x40, 0x9a, 0x18, 0x7d, 0x4b, 0x1b, 0x77, 0xe5, 0x9d, 0xf5, 0xa4, 0x7e, 0x02, 0x69,
-	0xc2, 0xdf, 0x10, 0x98, 0xe7, 0x12, 0x19, 0x08, 0xa4, 0x09, 0xf3, 0x9c, 0x97, 0xdc, 0x4f, 0xd7,
-	0x2b, 0x53, 0xbb, 0x59, 0x99, 0xda, 0xdf, 0x95, 0xa9, 0x5d, 0xad, 0xcd, 0xda, 0xcd, 0xda, 0xac,
-	0xfd, 0x5e, 0x9b, 0xb5, 0xef, 0xef, 0x62, 0xcc, 0x92, 0x45, 0x60, 0x87, 0x64, 0xee, 0xa8, 0xff,
-	0x23, 0xf5, 0x59, 0xbe, 0xe2, 0x81, 0x12, 0x82, 0x86, 0xb8, 0xa0, 0xc7, 0xff, 0x03, 0x00, 0x00,
-	0xff, 0xff, 0x5c, 0xc7, 0x2e, 0xd8, 0xba, 0x04, 0x00, 0x00,
+	// 690 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x54, 0x4d, 0x4f, 0xdb, 0x4a,
+	0x14, 0x8d, 0x21, 0xe4, 0x63, 0x92, 0x90, 0xf7, 0x86, 0xa7, 0x27, 0x13, 0xa8, 0xe3, 0xa2, 0x56,
+	0x4a, 0x5b, 0xc9, 0x96, 0xca, 0xbe, 0x12, 0x21, 0x55, 0x89, 0x84, 0xaa, 0x6a, 0xa8, 0x58, 0x74,
+	0x51, 0x6b, 0x62, 0x0f, 0xf6, 0x08, 0x7f, 0xc9, 0x33, 0x41, 0xe5, 0x5f, 0xb0, 0xe8, 0x8f, 0x62,
+	0xc9, 0xb2, 0x2b, 0x5a, 0x85, 0x5f, 0xd1, 0x5d, 0x35, 0x33, 0xb6, 0x63, 0x48, 0x5b, 0xb1, 0xb2,
+	0xe7, 0xdc, 0x73, 0x8f, 0xcf, 0x9d, 0x7b, 0xaf, 0xc1, 0x56, 0x96, 0x84, 0xe1, 0x39, 0xe5, 0x36,
+	0xe3, 0x98, 0x13, 0x2b, 0xcd, 0x12, 0x9e, 0xc0, 0x66, 0x0e, 0x0e, 0xfe, 0xf3, 0x13, 0x3f, 0x91,
+	0x98, 0x2d, 0xde, 0x54, 0x78, 0x30, 0xf4, 0x93, 0xc4, 0x0f, 0x89, 0x2d, 0x4f, 0xb3, 0xf9, 0x99,
+	0xcd, 0x69, 0x44, 0x18, 0xc7, 0x51, 0x9a, 0x13, 0x76, 0x38, 0x89, 0x3d, 0x92, 0x45, 0x34, 0xe6,
+	0x36, 0x9e, 0xb9, 0xd4, 0xe6, 0x97, 0x29, 0x61, 0x79, 0x70, 0xb7, 0x12, 0x94, 0xf8, 0xbd, 0xa8,
+	0xb9, 0x12, 0xbd, 0xc0, 0x21, 0xf5, 0x30, 0x4f, 0xb2, 0x9c, 0xf1, 0x64, 0x85, 0x91, 0xe2, 0x0c,
+	0x47, 0xbf, 0x93, 0x97, 0x35, 0x55, 0xe5, 0xf7, 0x7e, 0x36, 0xc0, 0xc6, 0x89, 0x40, 0xe1, 0x3e,
+	0x68, 0x5e, 0x90, 0x8c, 0xd1, 0x24, 0xd6, 0x35, 0x53, 0x1b, 0x75, 0x5e, 0x6f, 0x5b, 0xcb, 0x4c,
+	0x4b, 0xdd, 0xc6, 0xa9, 0x22, 0xa0, 0x82, 0x09, 0xb7, 0x41, 0xcb, 0x0d, 0x30, 0x8d, 0x1d, 0xea,
+	0xe9, 0x6b, 0xa6, 0x36, 0x6a, 0xa3, 0xa6, 0x3c, 0x4f, 0x3d, 0xf8, 0x1c, 0x6c, 0xd2, 0x98, 0x72,
+	0x8a, 0x43, 0x27, 0x20, 0xd4, 0x0f, 0xb8, 0xbe, 0x6e, 0x6a, 0xa3, 0x75, 0xd4, 0xcb, 0xd1, 0x23,
+	0x09, 0xc2, 0x97, 0xe0, 0xdf, 0x10, 0x33, 0xee, 0xcc, 0xc2, 0xc4, 0x3d, 0x2f, 0x98, 0x75, 0xc9,
+	0xec, 0x8b, 0xc0, 0x58, 0xe0, 0x39, 0x17, 0x81, 0x5e, 0x85, 0x4b, 0x3d, 0x7d, 0x63, 0xd5, 0xa8,
+	0x2a, 0x4e, 0x66, 0x4d, 0x27, 0xe3, 0xad, 0xeb, 0xdb, 0x61, 0x6d, 0x71, 0x3b, 0xec, 0x1c, 0x17,
+	0x52, 0xd3, 0x09, 0xea, 0x94, 0xba, 0x53, 0x0f, 0x1e, 0x83, 0x7e, 0x45, 0x53, 0x34, 0x4e, 0x6f,
+	0x48, 0xd5, 0x81, 0xa5, 0xba, 0x6a, 0x15, 0x5d, 0xb5, 0x3e, 0x16, 0x5d, 0x1d, 0xb7, 0x84, 0xec,
+	0xd5, 0xf7, 0xa1, 0x86, 0x7a, 0xa5, 0x96, 0x88, 0xc2, 0x17, 0xa0, 0xed, 0xe1, 0xa2, 0x8a, 0xa6,
+	0xa9, 0x8d, 0xea, 0xe3, 0xee, 0xe2, 0x76, 0xd8, 0x9a, 0x1c, 0xa8, 0x12, 0x50, 0xcb, 0xc3, 0x79,
+	0x31, 0xef, 0x40, 0x3f, 0x26, 0x5f, 0xb8, 0x53, 0xb6, 0x93, 0xe9, 0x2d, 0xf9, 0x61, 0x63, 0xb5,
+	0x9c, 0xd3, 0x82, 0x73, 0x42, 0x38, 0xda, 0x14, 0x69, 0x25, 0xc2, 0xe0, 0x1b, 0x00, 0x2a, 0x1a,
+	0xed, 0x47, 0x69, 0x54, 0x32, 0x84, 0x11, 0x79, 0x03, 0x15, 0x11, 0xf0, 0x38, 0x23, 0x22, 0xad,
+	0x62, 0xe4, 0x10, 0x18, 0x52, 0x48, 0x95, 0x5f, 0xd1, 0x73, 0xdc, 0x00, 0xc7, 0x3e, 0xf1, 0xf4,
+	0x8e, 0xec, 0xeb, 0x8e, 0x60, 0xa9, 0x5b, 0x58, 0x66, 0x1f, 0x2a, 0x0a, 0x44, 0xe0, 0x1f, 0x37,
+	0x89, 0x19, 0x89, 0xd9, 0x9c, 0x39, 0x6a, 0x90, 0xf5, 0xae, 0xb4, 0xf3, 0x74, 0xd5, 0xce, 0x61,
+	0xc1, 0xfc, 0x20, 0x89, 0xe3, 0xba, 0xe8, 0x0b, 0xea, 0xbb, 0xf7, 0x61, 0xf8, 0x1e, 0x3c, 0xab,
+	0x1a, 0x7b, 0xa8, 0x5f, 0xda, 0xeb, 0x49, 0x7b, 0xe6, 0xd2, 0xde, 0x03, 0xfd, 0xc2, 0x63, 0x31,
+	0xb3, 0x19, 0x61, 0xf3, 0x90, 0x33, 0x27, 0xc0, 0x2c, 0xd0, 0x37, 0x4d, 0x6d, 0xd4, 0x55, 0x33,
+	0x8b, 0x14, 0x7e, 0x84, 0x59, 0x20, 0x36, 0x04, 0xa7, 0xa9, 0xa2, 0xf4, 0x25, 0xa5, 0x89, 0xd3,
+	0x54, 0x84, 0xf6, 0x3e, 0x83, 0x8e, 0x9c, 0x1c, 0xb1, 0x7f, 0x73, 0x06, 0xff, 0x07, 0x8d, 0x7c,
+	0x70, 0xc4, 0xfe, 0xd5, 0x51, 0x7e, 0x82, 0x03, 0xd0, 0x3a, 0xa3, 0x31, 0x0e, 0x29, 0xbf, 0x94,
+	0x3b, 0xd6, 0x43, 0xe5, 0x19, 0xee, 0x54, 0xe7, 0x6d, 0x5d, 0xa6, 0x95, 0x13, 0xb6, 0xf7, 0x55,
+	0x03, 0x9d, 0xc9, 0xc1, 0x34, 0x76, 0xc3, 0xb9, 0x5c, 0xd6, 0x3f, 0x7d, 0x00, 0x82, 0xba, 0xb4,
+	0xb7, 0x26, 0xed, 0xc9, 0xf7, 0xbf, 0x0a, 0x43, 0x03, 0x00, 0x37, 0x89, 0x22, 0xca, 0x23, 0x12,
+	0xab, 0x65, 0xed, 0xa2, 0x0a, 0x02, 0x77, 0x41, 0x3b, 0xc6, 0x11, 0x61, 0x29, 0x76, 0x89, 0xdc,
+	0xd1, 0x2e, 0x5a, 0x02, 0xe3, 0xb7, 0xd7, 0x0b, 0x43, 0xbb, 0x59, 0x18, 0xda, 0x8f, 0x85, 0xa1,
+	0x5d, 0xdd, 0x19, 0xb5, 0x9b, 0x3b, 0xa3, 0xf6, 0xed, 0xce, 0xa8, 0x7d, 0x7a, 0xe5, 0x53, 0x1e,
+	0xcc, 0x67, 0x96, 0x9b, 0x44, 0x76, 0xf1, 0x1b, 0x2e, 0x9e, 0xf9, 0x9f, 0x6d, 0x56, 0x00, 0xb3,
+	0x86, 0xdc, 0xcb, 0xfd, 0x5f, 0x01, 0x00, 0x00, 0xff, 0xff, 0xf7, 0x9d, 0x43, 0x1c, 0xb1, 0x05,
+	0x00, 0x00,
 }
 
 func (m *State) Marshal() (dAtA []byte, err error) {
@@ -378,6 +528,98 @@ func (m *State) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	return len(dAtA) - i, nil
 }
 
+func (m *BlockStatus) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *BlockStatus) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *BlockStatus) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if m.DaHeight != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.DaHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if m.Finality != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Finality))
+		i--
+		dAtA[i] = 0x10
+	}
+	if m.Height != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
+func (m *DAInclusion) Marshal() (dAtA []byte, err error) {
+	size := m.Size()
+	dAtA = make([]byte, size)
+	n, err := m.MarshalToSizedBuffer(dAtA[:size])
+	if err != nil {
+		return nil, err
+	}
+	return dAtA[:n], nil
+}
+
+func (m *DAInclusion) MarshalTo(dAtA []byte) (int, error) {
+	size := m.Size()
+	return m.MarshalToSizedBuffer(dAtA[:size])
+}
+
+func (m *DAInclusion) MarshalToSizedBuffer(dAtA []byte) (int, error) {
+	i := len(dAtA)
+	_ = i
+	var l int
+	_ = l
+	if len(m.Namespace) > 0 {
+		i -= len(m.Namespace)
+		copy(dAtA[i:], m.Namespace)
+		i = encodeVarintState(dAtA, i, uint64(len(m.Namespace)))
+		i--
+		dAtA[i] = 0x2a
+	}
+	if len(m.Commitment) > 0 {
+		i -= len(m.Commitment)
+		copy(dAtA[i:], m.Commitment)
+		i = encodeVarintState(dAtA, i, uint64(len(m.Commitment)))
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.DaHeight != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.DaHeight))
+		i--
+		dAtA[i] = 0x18
+	}
+	if len(m.Hash) > 0 {
+		i -= len(m.Hash)
+		copy(dAtA[i:], m.Hash)
+		i = encodeVarintState(dAtA, i, uint64(len(m.Hash)))
+		i--
+		dAtA[i] = 0x12
+	}
+	if m.Height != 0 {
+		i = encodeVarintState(dAtA, i, uint64(m.Height))
+		i--
+		dAtA[i] = 0x8
+	}
+	return len(dAtA) - i, nil
+}
+
 func encodeVarintState(dAtA []byte, offset int, v uint64) int {
 	offset -= sovState(v)
 	base := offset
@@ -447,6 +689,51 @@ func (m *State) Size() (n int) {
 	return n
 }
 
+func (m *BlockStatus) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovState(uint64(m.Height))
+	}
+	if m.Finality != 0 {
+		n += 1 + sovState(uint64(m.Finality))
+	}
+	if m.DaHeight != 0 {
+		n += 1 + sovState(uint64(m.DaHeight))
+	}
+	return n
+}
+
+func (m *DAInclusion) Size() (n int) {
+	if m == nil {
+		return 0
+	}
+	var l int
+	_ = l
+	if m.Height != 0 {
+		n += 1 + sovState(uint64(m.Height))
+	}
+	l = len(m.Hash)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	if m.DaHeight != 0 {
+		n += 1 + sovState(uint64(m.DaHeight))
+	}
+	l = len(m.Commitment)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	l = len(m.Namespace)
+	if l > 0 {
+		n += 1 + l + sovState(uint64(l))
+	}
+	return n
+}
+
 func sovState(x uint64) (n int) {
 	return (math_bits.Len64(x|1) + 6) / 7
 }
@@ -941,6 +1228,303 @@ func (m *State) Unmarshal(dAtA []byte) error {
 	}
 	return nil
 }
+func (m *BlockStatus) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: BlockStatus: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: BlockStatus: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Finality", wireType)
+			}
+			m.Finality = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Finality |= uint32(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DaHeight", wireType)
+			}
+			m.DaHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DaHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
+func (m *DAInclusion) Unmarshal(dAtA []byte) error {
+	l := len(dAtA)
+	iNdEx := 0
+	for iNdEx < l {
+		preIndex := iNdEx
+		var wire uint64
+		for shift := uint(0); ; shift += 7 {
+			if shift >= 64 {
+				return ErrIntOverflowState
+			}
+			if iNdEx >= l {
+				return io.ErrUnexpectedEOF
+			}
+			b := dAtA[iNdEx]
+			iNdEx++
+			wire |= uint64(b&0x7F) << shift
+			if b < 0x80 {
+				break
+			}
+		}
+		fieldNum := int32(wire >> 3)
+		wireType := int(wire & 0x7)
+		if wireType == 4 {
+			return fmt.Errorf("proto: DAInclusion: wiretype end group for non-group")
+		}
+		if fieldNum <= 0 {
+			return fmt.Errorf("proto: DAInclusion: illegal tag %d (wire type %d)", fieldNum, wire)
+		}
+		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Height", wireType)
+			}
+			m.Height = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Height |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 2:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Hash", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Hash = append(m.Hash[:0], dAtA[iNdEx:postIndex]...)
+			if m.Hash == nil {
+				m.Hash = []byte{}
+			}
+			iNdEx = postIndex
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field DaHeight", wireType)
+			}
+			m.DaHeight = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.DaHeight |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Commitment", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Commitment = append(m.Commitment[:0], dAtA[iNdEx:postIndex]...)
+			if m.Commitment == nil {
+				m.Commitment = []byte{}
+			}
+			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Namespace", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowState
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthState
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthState
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Namespace = append(m.Namespace[:0], dAtA[iNdEx:postIndex]...)
+			if m.Namespace == nil {
+				m.Namespace = []byte{}
+			}
+			iNdEx = postIndex
+		default:
+			iNdEx = preIndex
+			skippy, err := skipState(dAtA[iNdEx:])
+			if err != nil {
+				return err
+			}
+			if (skippy < 0) || (iNdEx+skippy) < 0 {
+				return ErrInvalidLengthState
+			}
+			if (iNdEx + skippy) > l {
+				return io.ErrUnexpectedEOF
+			}
+			iNdEx += skippy
+		}
+	}
+
+	if iNdEx > l {
+		return io.ErrUnexpectedEOF
+	}
+	return nil
+}
 func skipState(dAtA []byte) (n int, err error) {
 	l := len(dAtA)
 	iNdEx := 0
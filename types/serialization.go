@@ -1,6 +1,9 @@
 package types
 
 import (
+	"errors"
+	"fmt"
+
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	"github.com/tendermint/tendermint/types"
@@ -28,6 +31,124 @@ func (b *Block) UnmarshalBinary(data []byte) error {
 	return err
 }
 
+// MarshalBatch encodes blocks into binary form of a batch of blocks.
+func MarshalBatch(blocks []*Block) ([]byte, error) {
+	batch := &pb.Batch{Blocks: make([]*pb.Block, len(blocks))}
+	for i := range blocks {
+		bp, err := blocks[i].ToProto()
+		if err != nil {
+			return nil, err
+		}
+		batch.Blocks[i] = bp
+	}
+	return batch.Marshal()
+}
+
+// UnmarshalBlocks decodes binary form of either a single Block or a batch of blocks.
+func UnmarshalBlocks(data []byte) ([]*Block, error) {
+	var block pb.Block
+	blockErr := block.Unmarshal(data)
+	if blockErr == nil && block.SignedHeader != nil && block.Data != nil {
+		b := new(Block)
+		if err := b.FromProto(&block); err != nil {
+			return nil, err
+		}
+		return []*Block{b}, nil
+	}
+
+	var batch pb.Batch
+	if err := batch.Unmarshal(data); err != nil || len(batch.Blocks) == 0 {
+		return nil, fmt.Errorf("data is neither a block nor a batch of blocks: %v", blockErr)
+	}
+	blocks := make([]*Block, len(batch.Blocks))
+	for i := range batch.Blocks {
+		blocks[i] = new(Block)
+		if err := blocks[i].FromProto(batch.Blocks[i]); err != nil {
+			return nil, err
+		}
+	}
+	return blocks, nil
+}
+
+// MarshalHeaders encodes signed headers into binary form of a batch of headers.
+func MarshalHeaders(headers []*SignedHeader) ([]byte, error) {
+	batch := &pb.HeaderBatch{Headers: make([]*pb.SignedHeader, len(headers))}
+	for i := range headers {
+		hp, err := headers[i].ToProto()
+		if err != nil {
+			return nil, err
+		}
+		batch.Headers[i] = hp
+	}
+	return batch.Marshal()
+}
+
+// UnmarshalHeaders decodes binary form of a batch of signed headers.
+func UnmarshalHeaders(data []byte) ([]*SignedHeader, error) {
+	var batch pb.HeaderBatch
+	if err := batch.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if len(batch.Headers) == 0 {
+		return nil, errors.New("empty batch of headers")
+	}
+	headers := make([]*SignedHeader, len(batch.Headers))
+	for i := range batch.Headers {
+		headers[i] = new(SignedHeader)
+		if err := headers[i].FromProto(batch.Headers[i]); err != nil {
+			return nil, err
+		}
+	}
+	return headers, nil
+}
+
+// MarshalDataBatch encodes block data into binary form of a batch of block data.
+func MarshalDataBatch(data []*Data) ([]byte, error) {
+	batch := &pb.DataBatch{Data: make([]*pb.Data, len(data))}
+	for i := range data {
+		batch.Data[i] = data[i].ToProto()
+	}
+	return batch.Marshal()
+}
+
+// UnmarshalDataBatch decodes binary form of a batch of block data.
+func UnmarshalDataBatch(data []byte) ([]*Data, error) {
+	var batch pb.DataBatch
+	if err := batch.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if len(batch.Data) == 0 {
+		return nil, errors.New("empty batch of block data")
+	}
+	decoded := make([]*Data, len(batch.Data))
+	for i := range batch.Data {
+		decoded[i] = new(Data)
+		decoded[i].FromProto(batch.Data[i])
+	}
+	return decoded, nil
+}
+
+// MarshalTxBatch encodes transactions into binary form of a batch of transactions, posted directly to DA layer.
+func MarshalTxBatch(txs Txs) ([]byte, error) {
+	if len(txs) == 0 {
+		return nil, errors.New("empty batch of transactions")
+	}
+	batch := &pb.TxBatch{Txs: txsToByteSlices(txs)}
+	return batch.Marshal()
+}
+
+// UnmarshalTxBatch decodes binary form of a batch of transactions.
+func UnmarshalTxBatch(data []byte) (Txs, error) {
+	var batch pb.TxBatch
+	if err := batch.Unmarshal(data); err != nil {
+		return nil, err
+	}
+	if len(batch.Txs) == 0 {
+		return nil, errors.New("empty batch of transactions")
+	}
+	return byteSlicesToTxs(batch.Txs), nil
+}
+
 // MarshalBinary encodes Header into binary form and returns it.
 func (h *Header) MarshalBinary() ([]byte, error) {
 	return h.ToProto().Marshal()
@@ -193,13 +314,18 @@ func (b *Block) FromProto(other *pb.Block) error {
 	if err != nil {
 		return err
 	}
-	b.Data.Txs = byteSlicesToTxs(other.Data.Txs)
-	b.Data.IntermediateStateRoots.RawRootsList = other.Data.IntermediateStateRoots
-	b.Data.Evidence = evidenceFromProto(other.Data.Evidence)
+	b.Data.FromProto(other.Data)
 
 	return nil
 }
 
+// FromProto fills Data with data from its protobuf representation.
+func (d *Data) FromProto(other *pb.Data) {
+	d.Txs = byteSlicesToTxs(other.Txs)
+	d.IntermediateStateRoots.RawRootsList = other.IntermediateStateRoots
+	d.Evidence = evidenceFromProto(other.Evidence)
+}
+
 // ToProto converts Commit into protobuf representation and returns it.
 func (c *Commit) ToProto() *pb.Commit {
 	return &pb.Commit{
@@ -283,6 +409,74 @@ func (s *State) FromProto(other *pb.State) error {
 	return nil
 }
 
+// ToProto converts BlockStatus into protobuf representation and returns it.
+func (s *BlockStatus) ToProto() *pb.BlockStatus {
+	return &pb.BlockStatus{
+		Height:   s.Height,
+		Finality: uint32(s.Finality),
+		DaHeight: s.DAHeight,
+	}
+}
+
+// FromProto fills BlockStatus with data from its protobuf representation.
+func (s *BlockStatus) FromProto(other *pb.BlockStatus) error {
+	s.Height = other.Height
+	s.Finality = FinalityStatus(other.Finality)
+	s.DAHeight = other.DaHeight
+	return nil
+}
+
+// MarshalBinary encodes BlockStatus into binary form and returns it.
+func (s *BlockStatus) MarshalBinary() ([]byte, error) {
+	return s.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of BlockStatus into object.
+func (s *BlockStatus) UnmarshalBinary(data []byte) error {
+	var pStatus pb.BlockStatus
+	err := pStatus.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	return s.FromProto(&pStatus)
+}
+
+// ToProto converts DAInclusion into protobuf representation and returns it.
+func (i *DAInclusion) ToProto() *pb.DAInclusion {
+	return &pb.DAInclusion{
+		Height:     i.Height,
+		Hash:       i.Hash[:],
+		DaHeight:   i.DAHeight,
+		Commitment: i.Commitment,
+		Namespace:  i.Namespace,
+	}
+}
+
+// FromProto fills DAInclusion with data from its protobuf representation.
+func (i *DAInclusion) FromProto(other *pb.DAInclusion) error {
+	i.Height = other.Height
+	i.Hash = other.Hash
+	i.DAHeight = other.DaHeight
+	i.Commitment = other.Commitment
+	i.Namespace = other.Namespace
+	return nil
+}
+
+// MarshalBinary encodes DAInclusion into binary form and returns it.
+func (i *DAInclusion) MarshalBinary() ([]byte, error) {
+	return i.ToProto().Marshal()
+}
+
+// UnmarshalBinary decodes binary form of DAInclusion into object.
+func (i *DAInclusion) UnmarshalBinary(data []byte) error {
+	var pInclusion pb.DAInclusion
+	err := pInclusion.Unmarshal(data)
+	if err != nil {
+		return err
+	}
+	return i.FromProto(&pInclusion)
+}
+
 func txsToByteSlices(txs Txs) [][]byte {
 	bytes := make([][]byte, len(txs))
 	for i := range txs {
@@ -18,6 +18,7 @@ func (tx Tx) Hash() []byte {
 }
 
 // Proof returns a simple merkle proof for this node.
+// Root of the proof is equal to DataHash only if block contains no ISRs and evidence, see Data.TxProof.
 // Panics if i < 0 or i >= len(txs)
 // TODO: optimize this!
 func (txs Txs) Proof(i int) TxProof {
@@ -35,6 +36,19 @@ func (txs Txs) Proof(i int) TxProof {
 	}
 }
 
+// TxProof returns a merkle proof of the presence of i-th transaction in block data.
+// Root of the proof is equal to DataHash of block header.
+// Panics if i < 0 or i >= len(d.Txs)
+func (d *Data) TxProof(i int) TxProof {
+	root, proofs := merkle.ProofsFromByteSlices(d.merkleLeaves())
+
+	return TxProof{
+		RootHash: root,
+		Data:     d.Txs[i],
+		Proof:    *proofs[i],
+	}
+}
+
 // TxProof represents a Merkle proof of the presence of a transaction in the Merkle tree.
 type TxProof struct {
 	RootHash tmbytes.HexBytes `json:"root_hash"`
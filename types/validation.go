@@ -7,6 +7,9 @@ import (
 	"github.com/tendermint/tendermint/crypto/ed25519"
 )
 
+// legacyDataHash is DataHash of blocks created before headers committed to block data.
+var legacyDataHash = make(Hash, 32)
+
 // ValidateBasic performs basic validation of a block.
 func (b *Block) ValidateBasic() error {
 	err := b.SignedHeader.ValidateBasic()
@@ -19,9 +22,19 @@ func (b *Block) ValidateBasic() error {
 		return err
 	}
 
+	// legacy blocks are validated by BlockExecutor, as it depends on configuration
+	if !b.SignedHeader.HasLegacyDataHash() && !bytes.Equal(b.SignedHeader.DataHash, b.Data.Hash()) {
+		return errors.New("DataHash in header and hash of block data do not match")
+	}
+
 	return nil
 }
 
+// HasLegacyDataHash returns true if header was created before DataHash committed to block data.
+func (h *Header) HasLegacyDataHash() bool {
+	return bytes.Equal(h.DataHash, legacyDataHash)
+}
+
 // ValidateBasic performs basic validation of a header.
 func (h *Header) ValidateBasic() error {
 	if len(h.ProposerAddress) == 0 {
@@ -45,8 +58,25 @@ func (c *Commit) ValidateBasic() error {
 	return nil
 }
 
+// IsBased returns true if header belongs to a block derived from transactions posted directly to DA layer
+// (based sequencing). Such blocks have no proposer.
+func (h *Header) IsBased() bool {
+	return len(h.ProposerAddress) == 0
+}
+
 // ValidateBasic performs basic validation of a signed header.
 func (h *SignedHeader) ValidateBasic() error {
+	// Handle Based Rollup case: block is built by every node from DA layer, so it's not signed
+	if h.IsBased() {
+		if h.Validators != nil && len(h.Validators.Validators) > 0 {
+			return errors.New("based block can't have aggregators")
+		}
+		if len(h.Commit.Signatures) > 0 {
+			return errors.New("based block can't be signed")
+		}
+		return nil
+	}
+
 	err := h.Header.ValidateBasic()
 	if err != nil {
 		return err
@@ -57,8 +87,8 @@ func (h *SignedHeader) ValidateBasic() error {
 		return err
 	}
 
-	// Handle Based Rollup case
-	if len(h.Validators.Validators) == 0 {
+	// blocks of chains without aggregator set are signed, but signature can't be verified
+	if h.Validators == nil || len(h.Validators.Validators) == 0 {
 		return nil
 	}
 